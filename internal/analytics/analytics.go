@@ -0,0 +1,77 @@
+// Package analytics разбирает переход по короткой ссылке на обезличенные признаки:
+// хост источника, класс клиента, страну и хэш IP.
+package analytics
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/url"
+	"strings"
+)
+
+// Классы User-Agent.
+const (
+	AgentBot     = "bot"
+	AgentMobile  = "mobile"
+	AgentTablet  = "tablet"
+	AgentDesktop = "desktop"
+	AgentOther   = "other"
+)
+
+var botMarkers = []string{"bot", "crawler", "spider", "slurp", "curl/", "wget/", "python-requests", "go-http-client", "headless", "preview"}
+
+// ClassifyAgent относит User-Agent к одному из классов Agent*.
+func ClassifyAgent(ua string) string {
+	ua = strings.ToLower(ua)
+	switch {
+	case ua == "":
+		return AgentOther
+	case containsAny(ua, botMarkers):
+		return AgentBot
+	case strings.Contains(ua, "ipad") || strings.Contains(ua, "tablet") ||
+		(strings.Contains(ua, "android") && !strings.Contains(ua, "mobile")):
+		return AgentTablet
+	case strings.Contains(ua, "mobi") || strings.Contains(ua, "iphone"):
+		return AgentMobile
+	case strings.Contains(ua, "windows") || strings.Contains(ua, "macintosh") ||
+		strings.Contains(ua, "x11") || strings.Contains(ua, "cros"):
+		return AgentDesktop
+	default:
+		return AgentOther
+	}
+}
+
+// ReferrerHost возвращает хост из заголовка Referer без www. и порта; пустая строка — прямой переход.
+func ReferrerHost(referrer string) string {
+	u, err := url.Parse(strings.TrimSpace(referrer))
+	if err != nil || u.Hostname() == "" {
+		return ""
+	}
+	return strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+}
+
+// IPHasher хэширует IP с секретной солью: уникальных посетителей можно посчитать,
+// но восстановить адрес по хэшу нельзя.
+type IPHasher struct {
+	salt []byte
+}
+
+func NewIPHasher(salt string) IPHasher {
+	return IPHasher{salt: []byte(salt)}
+}
+
+func (h IPHasher) Hash(ip string) string {
+	mac := hmac.New(sha256.New, h.salt)
+	mac.Write([]byte(ip))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func containsAny(s string, subs []string) bool {
+	for _, sub := range subs {
+		if strings.Contains(s, sub) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,45 @@
+package analytics
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClassifyAgent(t *testing.T) {
+	tests := []struct {
+		ua   string
+		want string
+	}{
+		{"", AgentOther},
+		{"Mozilla/5.0 (compatible; Googlebot/2.1; +http://www.google.com/bot.html)", AgentBot},
+		{"curl/8.5.0", AgentBot},
+		{"Mozilla/5.0 (iPhone; CPU iPhone OS 17_0 like Mac OS X) Mobile/15E148 Safari/604.1", AgentMobile},
+		{"Mozilla/5.0 (Linux; Android 14; Pixel 8) Chrome/120.0 Mobile Safari/537.36", AgentMobile},
+		{"Mozilla/5.0 (iPad; CPU OS 17_0 like Mac OS X) Safari/604.1", AgentTablet},
+		{"Mozilla/5.0 (Linux; Android 13; SM-X200) Chrome/120.0 Safari/537.36", AgentTablet},
+		{"Mozilla/5.0 (Windows NT 10.0; Win64; x64) Chrome/120.0 Safari/537.36", AgentDesktop},
+		{"Mozilla/5.0 (Macintosh; Intel Mac OS X 14_0) Safari/605.1.15", AgentDesktop},
+		{"SomethingElse/1.0", AgentOther},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, ClassifyAgent(tt.ua), tt.ua)
+	}
+}
+
+func TestReferrerHost(t *testing.T) {
+	assert.Equal(t, "", ReferrerHost(""))
+	assert.Equal(t, "", ReferrerHost("not a url"))
+	assert.Equal(t, "news.ycombinator.com", ReferrerHost("https://news.ycombinator.com/item?id=1"))
+	assert.Equal(t, "example.com", ReferrerHost("http://WWW.Example.com:8080/path"))
+}
+
+func TestIPHasher(t *testing.T) {
+	a := NewIPHasher("salt-a")
+	b := NewIPHasher("salt-b")
+
+	assert.Equal(t, a.Hash("203.0.113.7"), a.Hash("203.0.113.7"))
+	assert.NotEqual(t, a.Hash("203.0.113.7"), a.Hash("203.0.113.8"))
+	assert.NotEqual(t, a.Hash("203.0.113.7"), b.Hash("203.0.113.7"))
+	assert.NotContains(t, a.Hash("203.0.113.7"), "203.0.113.7")
+}
@@ -0,0 +1,46 @@
+package analytics
+
+import (
+	"net"
+
+	"github.com/oschwald/geoip2-golang"
+)
+
+// GeoResolver определяет страну (ISO 3166-1 alpha-2) по IP; пустая строка — неизвестно.
+type GeoResolver interface {
+	Country(ip string) string
+}
+
+// NoGeo используется, когда база GeoIP не настроена.
+type NoGeo struct{}
+
+func (NoGeo) Country(string) string { return "" }
+
+// GeoIP читает локальный файл MaxMind GeoLite2/GeoIP2 Country (.mmdb).
+type GeoIP struct {
+	db *geoip2.Reader
+}
+
+func OpenGeoIP(path string) (*GeoIP, error) {
+	db, err := geoip2.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	return &GeoIP{db: db}, nil
+}
+
+func (g *GeoIP) Country(ip string) string {
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return ""
+	}
+	record, err := g.db.Country(parsed)
+	if err != nil {
+		return ""
+	}
+	return record.Country.IsoCode
+}
+
+func (g *GeoIP) Close() error {
+	return g.db.Close()
+}
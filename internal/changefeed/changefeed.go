@@ -0,0 +1,185 @@
+// Package changefeed — изменения из PostgreSQL для всех экземпляров сервиса. Триггеры на таблицах
+// pastes, shorturls и stats отправляют уведомления в канал Channel при фиксации транзакции,
+// а Listener каждого экземпляра слушает канал и публикует их как доменные события. Так живая лента
+// и кэши видят изменения, сделанные через любой экземпляр.
+package changefeed
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/GritsyukLeonid/pastebin-go/internal/config"
+	"github.com/GritsyukLeonid/pastebin-go/internal/events"
+	"github.com/GritsyukLeonid/pastebin-go/internal/metrics"
+	"github.com/GritsyukLeonid/pastebin-go/internal/model"
+)
+
+// Channel — канал NOTIFY, в который пишут триггеры из миграции 009_change_notify.
+const Channel = "pastebin_changes"
+
+var ErrInvalidNotification = errors.New("invalid change notification")
+
+// Types — события, которые приходят из PostgreSQL, кроме Resync.
+func Types() []events.Type {
+	return []events.Type{
+		events.PasteCreated, events.PasteUpdated, events.PasteDeleted, events.PasteExpired, events.PasteViewed,
+		events.ShortURLCreated, events.ShortURLDeleted,
+		events.StatsCreated, events.StatsDeleted,
+	}
+}
+
+type Config struct {
+	// MinReconnect и MaxReconnect — первая и наибольшая пауза перед повторным подключением.
+	MinReconnect time.Duration
+	MaxReconnect time.Duration
+	// PingInterval — период проверки соединения, если уведомлений нет.
+	PingInterval time.Duration
+}
+
+func DefaultConfig() Config {
+	return Config{MinReconnect: time.Second, MaxReconnect: time.Minute, PingInterval: 90 * time.Second}
+}
+
+func ConfigFrom(c config.ChangeFeedConfig) Config {
+	return Config{
+		MinReconnect: time.Duration(c.MinReconnect),
+		MaxReconnect: time.Duration(c.MaxReconnect),
+		PingInterval: time.Duration(c.PingInterval),
+	}
+}
+
+// Listener слушает Channel на отдельном соединении и публикует изменения в pub.
+// После переподключения публикуется events.Resync: уведомления, отправленные без соединения, потеряны.
+type Listener struct {
+	conn *pq.Listener
+	cfg  Config
+	pub  events.Publisher
+}
+
+// NewListener открывает соединение к dsn; при обрыве оно восстанавливается само.
+func NewListener(dsn string, cfg Config, pub events.Publisher) *Listener {
+	l := &Listener{cfg: cfg, pub: pub}
+	l.conn = pq.NewListener(dsn, cfg.MinReconnect, cfg.MaxReconnect, l.report)
+	return l
+}
+
+// Run подписывается на Channel и публикует изменения до отмены ctx или Close.
+// Подписка ждёт первого подключения к PostgreSQL.
+func (l *Listener) Run(ctx context.Context) error {
+	if err := l.conn.Listen(Channel); err != nil {
+		if ctx.Err() != nil {
+			return nil
+		}
+		return fmt.Errorf("listen %s: %w", Channel, err)
+	}
+	ping := time.NewTicker(l.cfg.PingInterval)
+	defer ping.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ping.C:
+			// Ping обнаруживает соединение, разорванное без ошибки на стороне клиента.
+			go func() { _ = l.conn.Ping() }()
+		case n, ok := <-l.conn.Notify:
+			if !ok {
+				return nil
+			}
+			l.handle(ctx, n)
+		}
+	}
+}
+
+func (l *Listener) handle(ctx context.Context, n *pq.Notification) {
+	if n == nil {
+		metrics.ChangeResync()
+		l.pub.Publish(ctx, events.Event{Type: events.Resync})
+		return
+	}
+	e, err := Decode(n.Extra)
+	if err != nil {
+		metrics.ChangeNotification("invalid")
+		slog.WarnContext(ctx, "invalid change notification", "error", err)
+		return
+	}
+	metrics.ChangeNotification(string(e.Type))
+	l.pub.Publish(ctx, e)
+}
+
+// Ping проверяет соединение слушателя; подходит для проверки готовности.
+func (l *Listener) Ping(context.Context) error {
+	return l.conn.Ping()
+}
+
+func (l *Listener) Close() error {
+	return l.conn.Close()
+}
+
+func (l *Listener) report(ev pq.ListenerEventType, err error) {
+	switch ev {
+	case pq.ListenerEventDisconnected:
+		slog.Warn("change listener disconnected", "error", err)
+	case pq.ListenerEventConnectionAttemptFailed:
+		slog.Warn("change listener failed to connect", "error", err)
+	case pq.ListenerEventReconnected:
+		slog.Info("change listener reconnected")
+	}
+}
+
+// notification — тело уведомления, которое формируют триггеры.
+type notification struct {
+	Type     events.Type     `json:"type"`
+	ID       string          `json:"id"`
+	At       time.Time       `json:"at"`
+	Paste    *pasteChange    `json:"paste"`
+	ShortURL *model.ShortURL `json:"shortUrl"`
+	Stats    *model.Stats    `json:"stats"`
+}
+
+type pasteChange struct {
+	Hash      string    `json:"hash"`
+	UserID    int64     `json:"userId"`
+	CreatedAt time.Time `json:"createdAt"`
+	ExpiresAt time.Time `json:"expiresAt"`
+	Size      int       `json:"size"`
+}
+
+// Decode превращает уведомление в доменное событие. У пасты заполнены все поля, кроме содержимого
+// (его размер — в Paste.Size) и владельческих секретов.
+func Decode(payload string) (events.Event, error) {
+	var n notification
+	if err := json.Unmarshal([]byte(payload), &n); err != nil {
+		return events.Event{}, fmt.Errorf("%w: %v", ErrInvalidNotification, err)
+	}
+	if n.ID == "" || !known(n.Type) {
+		return events.Event{}, fmt.Errorf("%w: type %q, id %q", ErrInvalidNotification, n.Type, n.ID)
+	}
+
+	e := events.Event{Type: n.Type, EntityID: n.ID, At: n.At.UTC(), ShortURL: n.ShortURL, Stats: n.Stats}
+	if p := n.Paste; p != nil {
+		e.Paste = &model.Paste{
+			ID:        n.ID,
+			Hash:      p.Hash,
+			UserID:    p.UserID,
+			CreatedAt: p.CreatedAt.UTC(),
+			ExpiresAt: p.ExpiresAt.UTC(),
+			Size:      p.Size,
+		}
+	}
+	return e, nil
+}
+
+func known(t events.Type) bool {
+	for _, k := range Types() {
+		if t == k {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,85 @@
+package changefeed
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/GritsyukLeonid/pastebin-go/internal/events"
+)
+
+// Тела уведомлений в том виде, в котором их формирует json_build_object в PostgreSQL.
+const (
+	pasteCreated = `{"type" : "paste.created", "id" : "1760870400000000000", "at" : "2026-10-19T15:00:00.123456+03:00", ` +
+		`"paste" : {"hash" : "a1b2c3d4e5", "userId" : 7, "createdAt" : "2026-10-19T15:00:00+03:00", "expiresAt" : "2026-10-20T15:00:00+03:00", "size" : 12}}`
+	shortURLTombstone = `{"type" : "shorturl.deleted", "id" : "abc123", "at" : "2026-10-19T12:00:00+00:00", ` +
+		`"shortUrl" : {"id" : "abc123", "original" : "a1b2c3d4e5", "targetType" : "paste", "permanent" : false, "expiresAt" : null, "deletedAt" : "2026-10-19T12:00:00+00:00"}}`
+	statsViewed = `{"type" : "paste.viewed", "id" : "1760870400000000000", "at" : "2026-10-19T12:00:00+00:00", "stats" : {"id" : "1760870400000000000", "views" : 42}}`
+)
+
+type recorder []events.Event
+
+func (r *recorder) Publish(_ context.Context, e events.Event) {
+	*r = append(*r, e)
+}
+
+func TestDecodePaste(t *testing.T) {
+	e, err := Decode(pasteCreated)
+	require.NoError(t, err)
+
+	assert.Equal(t, events.PasteCreated, e.Type)
+	assert.Equal(t, "1760870400000000000", e.EntityID)
+	assert.Equal(t, time.UTC, e.At.Location())
+	require.NotNil(t, e.Paste)
+	assert.Equal(t, "a1b2c3d4e5", e.Paste.Hash)
+	assert.Equal(t, int64(7), e.Paste.UserID)
+	assert.Equal(t, time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC), e.Paste.CreatedAt)
+	assert.Equal(t, 12, e.Paste.ContentSize())
+	assert.Empty(t, e.Paste.Content)
+}
+
+func TestDecodeShortURLAndStats(t *testing.T) {
+	e, err := Decode(shortURLTombstone)
+	require.NoError(t, err)
+	assert.Equal(t, events.ShortURLDeleted, e.Type)
+	require.NotNil(t, e.ShortURL)
+	assert.Equal(t, "a1b2c3d4e5", e.ShortURL.Original)
+	assert.Nil(t, e.ShortURL.ExpiresAt)
+	assert.NotNil(t, e.ShortURL.DeletedAt)
+
+	e, err = Decode(statsViewed)
+	require.NoError(t, err)
+	assert.Equal(t, events.PasteViewed, e.Type)
+	require.NotNil(t, e.Stats)
+	assert.Equal(t, 42, e.Stats.Views)
+}
+
+func TestDecodeRejectsUnknownPayloads(t *testing.T) {
+	for name, payload := range map[string]string{
+		"not json":     "paste.created",
+		"unknown type": `{"type":"user.created","id":"1"}`,
+		"resync":       `{"type":"changes.resync","id":"1"}`,
+		"no id":        `{"type":"paste.deleted"}`,
+	} {
+		_, err := Decode(payload)
+		assert.ErrorIs(t, err, ErrInvalidNotification, name)
+	}
+}
+
+func TestListenerPublishesResyncAfterReconnect(t *testing.T) {
+	var got recorder
+	l := &Listener{cfg: DefaultConfig(), pub: &got}
+	ctx := context.Background()
+
+	l.handle(ctx, &pq.Notification{Channel: Channel, Extra: pasteCreated})
+	l.handle(ctx, &pq.Notification{Channel: Channel, Extra: "garbage"})
+	l.handle(ctx, nil)
+
+	require.Len(t, got, 2)
+	assert.Equal(t, events.PasteCreated, got[0].Type)
+	assert.Equal(t, events.Resync, got[1].Type)
+}
@@ -2,15 +2,41 @@ package main // ТЕСТОВАЯ РЕАЛИЗАЦИЯ
 
 import (
 	"context"
+	"flag"
 	"log"
 	"time"
 
-	pb "github.com/GritsyukLeonid/pastebin-go/internal/pb"
+	pb "github.com/GritsyukLeonid/pastebin-go/internal/pb/pastebin/v1"
+	"github.com/GritsyukLeonid/pastebin-go/internal/tlsconfig"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 )
 
 func main() {
-	conn, err := grpc.Dial("localhost:9090", grpc.WithInsecure())
+	addr := flag.String("addr", "localhost:9090", "адрес gRPC-сервера")
+	useTLS := flag.Bool("tls", false, "подключаться по TLS")
+	var tlsCfg tlsconfig.ClientConfig
+	flag.StringVar(&tlsCfg.CAFile, "ca-file", "", "центры сертификации сервера (PEM); пусто — системные")
+	flag.StringVar(&tlsCfg.CertFile, "cert-file", "", "клиентский сертификат для mTLS")
+	flag.StringVar(&tlsCfg.KeyFile, "key-file", "", "ключ клиентского сертификата")
+	flag.StringVar(&tlsCfg.ServerName, "server-name", "", "имя сервера для проверки сертификата")
+	flag.StringVar(&tlsCfg.MinVersion, "tls-min-version", "1.2", "минимальная версия TLS: 1.2 или 1.3")
+	apiKey := flag.String("api-key", "", "API-ключ клиента (x-api-key)")
+	flag.Parse()
+
+	creds := insecure.NewCredentials()
+	if *useTLS || tlsCfg.CAFile != "" || tlsCfg.CertFile != "" {
+		cfg, err := tlsconfig.NewClient(tlsCfg)
+		if err != nil {
+			log.Fatalf("некорректные настройки TLS: %v", err)
+		}
+		creds = credentials.NewTLS(cfg)
+	}
+	conn, err := grpc.NewClient(*addr, grpc.WithTransportCredentials(creds))
 	if err != nil {
 		log.Fatalf("не удалось подключиться: %v", err)
 	}
@@ -23,138 +49,97 @@ func main() {
 
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
+	if *apiKey != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, "x-api-key", *apiKey)
+	}
 
 	// ==== USER ====
-	userResp, err := userClient.CreateUser(ctx, &pb.User{
-		Username: "test_user",
-		Email:    "test@example.com",
-		Password: "123456",
-	})
+	userResp, err := userClient.CreateUser(ctx, &pb.CreateUserRequest{Username: "test_user"})
 	if err != nil {
 		log.Fatalf("CreateUser error: %v", err)
 	}
-	log.Printf("User created: %v", userResp)
+	log.Printf("User created: %v", userResp.User)
 
-	userID := userResp.Id
+	userID := userResp.User.Id
 
-	getUser, err := userClient.GetUser(ctx, &pb.IDRequestInt{Id: userID})
+	getUser, err := userClient.GetUser(ctx, &pb.GetUserRequest{Id: userID})
 	if err != nil {
 		log.Fatalf("GetUser error: %v", err)
 	}
-	log.Printf("Fetched user by ID: %v", getUser)
+	log.Printf("Fetched user by ID: %v", getUser.User)
 
-	allUsersStream, err := userClient.ListUsers(ctx, &pb.Empty{})
+	allUsers, err := userClient.ListUsers(ctx, &pb.ListUsersRequest{})
 	if err != nil {
 		log.Fatalf("ListUsers error: %v", err)
 	}
 	log.Println("All users:")
-	for {
-		user, err := allUsersStream.Recv()
-		if err != nil {
-			break
-		}
-		log.Printf("- %v", user)
-	}
-
-	_, err = userClient.UpdateUser(ctx, &pb.User{
-		Id:       userID,
-		Username: "updated_user",
-		Email:    "updated@example.com",
-		Password: "654321",
-	})
-	if err != nil {
-		log.Fatalf("UpdateUser error: %v", err)
-	}
-
-	_, err = userClient.DeleteUser(ctx, &pb.IDRequestInt{Id: userID})
-	if err != nil {
-		log.Fatalf("DeleteUser error: %v", err)
+	for _, u := range allUsers.Users {
+		log.Printf("- %v", u)
 	}
 
 	// ==== PASTE ====
-	pasteResp, err := pasteClient.CreatePaste(ctx, &pb.Paste{
-		Title:   "Test title",
-		Content: "Hello world!",
+	pasteResp, err := pasteClient.CreatePaste(ctx, &pb.CreatePasteRequest{
+		Content:    "Hello world!",
+		Expiration: &pb.CreatePasteRequest_Ttl{Ttl: durationpb.New(time.Hour)},
+		UserId:     userID,
 	})
 	if err != nil {
 		log.Fatalf("CreatePaste error: %v", err)
 	}
-	log.Printf("Paste created: %v", pasteResp)
+	log.Printf("Paste created: %v, short URL %s", pasteResp.Paste, pasteResp.ShortUrl)
 
-	pasteID := pasteResp.Id
+	pasteID := pasteResp.Paste.Id
 
-	pasteByID, err := pasteClient.GetPaste(ctx, &pb.IDRequest{
-		Id: pasteID,
-	})
+	pasteByID, err := pasteClient.GetPaste(ctx, &pb.GetPasteRequest{Id: pasteID})
 	if err != nil {
 		log.Fatalf("GetPaste error: %v", err)
 	}
-	log.Printf("Fetched paste: %v", pasteByID)
+	log.Printf("Fetched paste: %v", pasteByID.Paste)
 
-	_, err = pasteClient.UpdatePaste(ctx, &pb.Paste{
-		Id:      pasteID,
-		Content: "Updated content",
-		UserId:  userID,
+	updated, err := pasteClient.UpdatePaste(ctx, &pb.UpdatePasteRequest{
+		Paste:       &pb.Paste{Id: pasteID, Content: "Updated content"},
+		UpdateMask:  &fieldmaskpb.FieldMask{Paths: []string{"content"}},
+		DeleteToken: pasteResp.DeleteToken,
 	})
 	if err != nil {
 		log.Fatalf("UpdatePaste error: %v", err)
 	}
+	log.Printf("Paste updated: %v", updated.Paste)
 
-	_, err = pasteClient.DeletePaste(ctx, &pb.IDRequest{Id: pasteID})
+	// ==== STATS ====
+	stats, err := statsClient.GetStats(ctx, &pb.GetStatsRequest{Id: pasteID})
 	if err != nil {
-		log.Fatalf("DeletePaste error: %v", err)
+		log.Fatalf("GetStats error: %v", err)
 	}
+	log.Printf("Stats: %v", stats.Stats)
 
 	// ==== SHORT URL ====
-	shortResp, err := shortURLClient.CreateShortURL(ctx, &pb.ShortURL{
-		OriginalUrl: "https://example.com",
-		ShortCode:   "exmpl",
+	shortResp, err := shortURLClient.CreateShortURL(ctx, &pb.CreateShortURLRequest{
+		Target: &pb.CreateShortURLRequest_Url{Url: "https://example.com"},
 	})
 	if err != nil {
 		log.Fatalf("CreateShortURL error: %v", err)
 	}
-	shortID := shortResp.Id
-	log.Printf("ShortURL created: %v", shortResp)
+	log.Printf("ShortURL created: %v", shortResp.ShortUrl)
 
-	_, err = shortURLClient.UpdateShortURL(ctx, &pb.ShortURL{
-		Id:          shortID,
-		OriginalUrl: "https://updated.com",
-		ShortCode:   "updcd",
+	_, err = shortURLClient.DeleteShortURL(ctx, &pb.DeleteShortURLRequest{
+		Id:          shortResp.ShortUrl.Id,
+		DeleteToken: shortResp.ShortUrl.DeleteToken,
 	})
-	if err != nil {
-		log.Fatalf("UpdateShortURL error: %v", err)
-	}
-
-	_, err = shortURLClient.DeleteShortURL(ctx, &pb.IDRequest{Id: shortID})
 	if err != nil {
 		log.Fatalf("DeleteShortURL error: %v", err)
 	}
 
-	// ==== STATS ====
-
-	statsResp, err := statsClient.CreateStats(ctx, &pb.Stats{
-		Id:      "stat1",
-		PasteId: pasteID,
-		Views:   5,
+	_, err = pasteClient.DeletePaste(ctx, &pb.DeletePasteRequest{
+		Id:          pasteID,
+		DeleteToken: pasteResp.DeleteToken,
 	})
 	if err != nil {
-		log.Fatalf("CreateStats error: %v", err)
-	}
-	log.Printf("Stats created: %v", statsResp)
-
-	statsID := statsResp.Id
-
-	_, err = statsClient.UpdateStats(ctx, &pb.Stats{
-		Id:      statsID,
-		PasteId: pasteID,
-		Views:   10,
-	})
-	if err != nil {
-		log.Fatalf("UpdateStats error: %v", err)
+		log.Fatalf("DeletePaste error: %v", err)
 	}
 
-	_, err = statsClient.DeleteStats(ctx, &pb.IDRequest{Id: statsID})
+	_, err = userClient.DeleteUser(ctx, &pb.DeleteUserRequest{Id: userID})
 	if err != nil {
-		log.Fatalf("DeleteStats error: %v", err)
+		log.Fatalf("DeleteUser error: %v", err)
 	}
 }
@@ -1,37 +1,134 @@
 package main // ТЕСТОВАЯ РЕАЛИЗАЦИЯ
 
 import (
-	"log"
+	"context"
+	"errors"
+	"flag"
+	"log/slog"
 	"net"
+	"net/http"
+	"os"
+	"time"
 
+	"github.com/GritsyukLeonid/pastebin-go/internal/config"
+	"github.com/GritsyukLeonid/pastebin-go/internal/events"
+	"github.com/GritsyukLeonid/pastebin-go/internal/feed"
 	"github.com/GritsyukLeonid/pastebin-go/internal/grpc/grpcimpl"
-	"github.com/GritsyukLeonid/pastebin-go/internal/pb"
+	"github.com/GritsyukLeonid/pastebin-go/internal/grpc/grpcserver"
+	"github.com/GritsyukLeonid/pastebin-go/internal/health"
+	"github.com/GritsyukLeonid/pastebin-go/internal/links"
+	"github.com/GritsyukLeonid/pastebin-go/internal/logging"
+	"github.com/GritsyukLeonid/pastebin-go/internal/metrics"
+	pb "github.com/GritsyukLeonid/pastebin-go/internal/pb/pastebin/v1"
+	"github.com/GritsyukLeonid/pastebin-go/internal/ratelimit"
 	"github.com/GritsyukLeonid/pastebin-go/internal/repository"
-	"google.golang.org/grpc"
+	"github.com/GritsyukLeonid/pastebin-go/internal/service"
+	"github.com/GritsyukLeonid/pastebin-go/internal/shortcode"
+	"github.com/GritsyukLeonid/pastebin-go/internal/tracing"
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
 )
 
 func main() {
+	cfg, opts, err := config.Load(os.Args[1:], os.Getenv)
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if err != nil {
+		fatal("invalid config", err)
+	}
+	if opts.Dump {
+		if err := cfg.Dump(os.Stdout); err != nil {
+			fatal("failed to dump config", err)
+		}
+		return
+	}
+	appLogger, err := logging.NewSlogLogger(os.Stderr, cfg.Log.Format, cfg.Log.Level)
+	if err != nil {
+		fatal("invalid log config", err)
+	}
+	slog.SetDefault(appLogger)
+
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg.Tracing.Config())
+	if err != nil {
+		fatal("failed to set up tracing", err)
+	}
+	defer shutdownTracing(context.Background())
+
 	repository.LoadData()
 
-	lis, err := net.Listen("tcp", ":9090")
+	lis, err := net.Listen("tcp", cfg.GRPC.Addr)
 	if err != nil {
-		log.Fatalf("failed to listen: %v", err)
+		fatal("failed to listen", err)
 	}
 
-	s := grpc.NewServer()
+	rateLimiter := ratelimit.NewMiddleware(ratelimit.NewMemoryLimiter(), ratelimit.PolicyFrom(cfg.RateLimit))
+
+	grpcConfig := grpcserver.ConfigFrom(cfg.GRPC)
+	if grpcConfig.TLS, err = cfg.GRPC.TLS.Server(); err != nil {
+		fatal("failed to load TLS certificate", err)
+	}
+	s := grpcserver.New(grpcConfig, rateLimiter)
 
 	reflection.Register(s)
 
-	srv := &grpcimpl.Server{}
+	// У тестового сервера нет PostgreSQL и Redis: готовность определяется файловым хранилищем.
+	healthServer := grpchealth.NewServer()
+	healthpb.RegisterHealthServer(s, healthServer)
+	checker := health.NewChecker(time.Duration(cfg.Health.Timeout)).
+		Add("storage", repository.CheckFileStorage)
+	go checker.Serve(context.Background(), healthServer, time.Duration(cfg.Health.Interval),
+		pb.UserService_ServiceDesc.ServiceName,
+		pb.PasteService_ServiceDesc.ServiceName,
+		pb.StatsService_ServiceDesc.ServiceName,
+		pb.ShortURLService_ServiceDesc.ServiceName,
+	)
+
+	linkBuilder, err := links.NewBuilder(cfg.Links.Config())
+	if err != nil {
+		fatal("invalid public URL config", err)
+	}
+
+	storage := repository.NewFileStorage()
+	hub := feed.NewHub(linkBuilder, feed.Config{History: cfg.Feed.History, Buffer: cfg.Feed.Buffer})
+	// У тестового сервера нет PostgreSQL и Redis для журнала изменений: события идут в метрики и живую ленту.
+	bus := events.NewBus().
+		Subscribe("metrics", events.CountMetrics, events.PasteCreated, events.PasteExpired).
+		Subscribe("feed", hub.Handle, feed.Types()...)
+	statsService := service.NewStatsService(storage, bus)
+	shortURLService := service.NewShortURLService(storage, bus, shortcode.NewGenerator(shortcode.RandomSource{}, cfg.ShortCode.Config()))
+	quotas := service.QuotaConfigFrom(cfg.Quota)
+	pasteService := service.NewPasteService(storage, bus, statsService, shortURLService, quotas)
+	userService := service.NewUserService(storage, bus, quotas)
+
+	srv := grpcimpl.NewServer(pasteService, userService, statsService, shortURLService, linkBuilder, hub, cfg.Stats.PopularLimit).
+		WithAdminToken(cfg.Admin.Token)
 
 	pb.RegisterUserServiceServer(s, srv)
 	pb.RegisterPasteServiceServer(s, srv)
 	pb.RegisterStatsServiceServer(s, srv)
 	pb.RegisterShortURLServiceServer(s, srv)
 
-	log.Println("gRPC server listening on :9090")
+	if cfg.GRPC.MetricsAddr != "" {
+		go func() {
+			mux := http.NewServeMux()
+			mux.Handle("/metrics", metrics.Handler())
+			slog.Info("metrics server started", "addr", cfg.GRPC.MetricsAddr)
+			if err := http.ListenAndServe(cfg.GRPC.MetricsAddr, mux); err != nil {
+				slog.Error("metrics server error", "error", err)
+			}
+		}()
+	}
+
+	slog.Info("gRPC server started", "addr", cfg.GRPC.Addr, "tls", grpcConfig.TLS != nil)
 	if err := s.Serve(lis); err != nil {
-		log.Fatalf("failed to serve: %v", err)
+		fatal("failed to serve", err)
 	}
 }
+
+// fatal пишет ошибку в журнал и завершает процесс.
+func fatal(msg string, err error) {
+	slog.Error(msg, "error", err)
+	os.Exit(1)
+}
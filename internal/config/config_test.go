@@ -0,0 +1,154 @@
+package config
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func env(m map[string]string) func(string) string {
+	return func(k string) string { return m[k] }
+}
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestDefaultsAreValid(t *testing.T) {
+	cfg, opts, err := Load(nil, env(nil))
+	require.NoError(t, err)
+	assert.Equal(t, Default(), *cfg)
+	assert.Empty(t, opts.File)
+	assert.Equal(t, ":8080", cfg.HTTP.Addr)
+	assert.Equal(t, 10*time.Minute, time.Duration(cfg.Redis.LogTTL))
+	assert.Equal(t, 300, cfg.RateLimit.Read.PerMinute)
+}
+
+func TestPrecedence(t *testing.T) {
+	path := writeFile(t, "pastebin.yaml", `
+http:
+  addr: ":8081"
+redis:
+  addr: "redis-from-file:6379"
+  log_ttl: 5m
+stats:
+  popular_limit: 7
+`)
+
+	cfg, opts, err := Load(
+		[]string{"-config", path, "-redis.addr", "redis-from-flag:6379"},
+		env(map[string]string{"REDIS_ADDR": "redis-from-env:6379", "POPULAR_LIMIT": "9"}),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, path, opts.File)
+	assert.Equal(t, ":8081", cfg.HTTP.Addr)
+	assert.Equal(t, 5*time.Minute, time.Duration(cfg.Redis.LogTTL))
+	assert.Equal(t, 9, cfg.Stats.PopularLimit)
+	assert.Equal(t, "redis-from-flag:6379", cfg.Redis.Addr)
+}
+
+func TestTOMLFileFromEnv(t *testing.T) {
+	path := writeFile(t, "pastebin.toml", `
+[grpc]
+addr = ":9191"
+
+[links]
+base_url = "https://paste.example.com"
+short_domains = ["https://pst.io"]
+
+[quota.anonymous]
+max_live_pastes = 3
+`)
+
+	cfg, _, err := Load(nil, env(map[string]string{FileEnv: path}))
+	require.NoError(t, err)
+	assert.Equal(t, ":9191", cfg.GRPC.Addr)
+	assert.Equal(t, []string{"https://pst.io"}, cfg.Links.ShortDomains)
+	assert.Equal(t, 3, cfg.Quota.Anonymous.MaxLivePastes)
+	assert.Equal(t, Default().Quota.Anonymous.MaxPasteBytes, cfg.Quota.Anonymous.MaxPasteBytes)
+}
+
+func TestLegacyEnvNames(t *testing.T) {
+	cfg, _, err := Load(nil, env(map[string]string{
+		"POSTGRES_DSN":                  "postgres://app:secret@db:5432/pastebin",
+		"RATE_LIMIT_WRITE_PER_MINUTE":   "12",
+		"QUOTA_USER_MAX_PASTE_BYTES":    "2048",
+		"SHORT_LINK_DOMAINS":            "https://pst.io, https://p.example",
+		"TRUST_FORWARDED_HEADERS":       "true",
+		"CLICK_RETENTION_DAYS":          "7",
+		"SHORT_CODE_MIN_LENGTH":         "8",
+		"QUOTA_ANON_MAX_PASTES_PER_DAY": "0",
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, 12, cfg.RateLimit.Write.PerMinute)
+	assert.Equal(t, int64(2048), cfg.Quota.User.MaxPasteBytes)
+	assert.Equal(t, []string{"https://pst.io", "https://p.example"}, cfg.Links.ShortDomains)
+	assert.True(t, cfg.Links.TrustForwarded)
+	assert.Equal(t, 7, cfg.Analytics.RetentionDays)
+	assert.Equal(t, 8, cfg.ShortCode.Config().MinLength)
+	assert.Equal(t, 0, cfg.Quota.Anonymous.MaxPastesPerDay)
+}
+
+func TestLoadErrors(t *testing.T) {
+	unknown := writeFile(t, "bad.yaml", "http:\n  adress: \":8080\"\n")
+	_, _, err := Load([]string{"-config", unknown}, env(nil))
+	assert.ErrorIs(t, err, ErrInvalid)
+
+	_, _, err = Load([]string{"-config", writeFile(t, "cfg.json", "{}")}, env(nil))
+	assert.ErrorIs(t, err, ErrInvalid)
+
+	_, _, err = Load(nil, env(map[string]string{"POPULAR_LIMIT": "many"}))
+	assert.ErrorIs(t, err, ErrInvalid)
+
+	_, _, err = Load([]string{"-redis.log_ttl", "soon"}, env(nil))
+	assert.ErrorIs(t, err, ErrInvalid)
+}
+
+func TestValidate(t *testing.T) {
+	cfg := Default()
+	cfg.HTTP.Addr = ""
+	cfg.ShortCode.MinLength = 20
+	cfg.RateLimit.Backend = "memcached"
+	cfg.Links.BaseURL = "localhost"
+	cfg.ChangeLog.Sinks = []string{"audit", "kafka", "file"}
+	cfg.HTTP.TLS.CertFile = "server.crt"
+	cfg.GRPC.ClientIdentities = map[string]string{"billing": "billing"}
+	cfg.Log.Format = "xml"
+
+	err := cfg.Validate()
+	assert.ErrorIs(t, err, ErrInvalid)
+	for _, want := range []string{"http.addr", "short_code", "rate_limit.backend", "base url", `unknown sink "kafka"`, "change_log.file",
+		"http.tls", "grpc.client_identities", "log.format"} {
+		assert.Contains(t, err.Error(), want)
+	}
+}
+
+func TestDumpRedactsSecrets(t *testing.T) {
+	cfg := Default()
+	cfg.Postgres.DSN = "postgres://app:s3cret@db:5432/pastebin?sslmode=disable"
+	cfg.Analytics.IPSalt = "pepper"
+	cfg.Admin.Token = "hunter2"
+	cfg.GRPC.APIKeys = []string{"client-key"}
+
+	var buf bytes.Buffer
+	require.NoError(t, cfg.Dump(&buf))
+	out := buf.String()
+
+	assert.NotContains(t, out, "s3cret")
+	assert.NotContains(t, out, "pepper")
+	assert.NotContains(t, out, "hunter2")
+	assert.NotContains(t, out, "client-key")
+	assert.Contains(t, out, "app:REDACTED@db:5432")
+	assert.Contains(t, out, "log_ttl: 10m0s")
+	assert.Equal(t, "pepper", cfg.Analytics.IPSalt)
+
+	assert.Equal(t, "REDACTED", redactDSN("host=db user=app password=s3cret"))
+}
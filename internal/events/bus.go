@@ -0,0 +1,140 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/GritsyukLeonid/pastebin-go/internal/metrics"
+)
+
+var ErrBusClosed = errors.New("event bus is closed")
+
+// Bus доставляет события подписчикам. Синхронные подписчики вызываются внутри Publish
+// по порядку подписки, асинхронные получают события через собственную очередь и горутину;
+// при заполненной очереди событие для подписчика отбрасывается.
+type Bus struct {
+	mu     sync.RWMutex
+	subs   []*subscriber
+	closed bool
+	wg     sync.WaitGroup
+}
+
+type subscriber struct {
+	name   string
+	handle Handler
+	types  map[Type]bool
+	queue  chan delivery
+}
+
+type delivery struct {
+	ctx context.Context
+	e   Event
+}
+
+func NewBus() *Bus {
+	return &Bus{}
+}
+
+// Subscribe подписывает синхронный обработчик name на события types (без types — на все).
+// Обработчик выполняется в горутине публикующего и должен быть быстрым.
+func (b *Bus) Subscribe(name string, h Handler, types ...Type) *Bus {
+	b.add(&subscriber{name: name, handle: h, types: typeSet(types)})
+	return b
+}
+
+// SubscribeAsync подписывает обработчик name с очередью на buffer событий.
+func (b *Bus) SubscribeAsync(name string, buffer int, h Handler, types ...Type) *Bus {
+	s := &subscriber{name: name, handle: h, types: typeSet(types), queue: make(chan delivery, buffer)}
+	b.add(s)
+	b.wg.Add(1)
+	go func() {
+		defer b.wg.Done()
+		for d := range s.queue {
+			s.deliver(d.ctx, d.e)
+		}
+	}()
+	return b
+}
+
+func (b *Bus) add(s *subscriber) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.subs = append(b.subs, s)
+}
+
+// Publish доставляет событие подписчикам. Асинхронные подписчики получают контекст без отмены,
+// но с request ID, пользователем и спаном запроса.
+func (b *Bus) Publish(ctx context.Context, e Event) {
+	if e.At.IsZero() {
+		e.At = time.Now().UTC()
+	}
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if b.closed {
+		slog.WarnContext(ctx, "event published after bus was closed", "type", e.Type, "error", ErrBusClosed)
+		return
+	}
+	metrics.EventPublished(string(e.Type))
+
+	for _, s := range b.subs {
+		if len(s.types) > 0 && !s.types[e.Type] {
+			continue
+		}
+		if s.queue == nil {
+			s.deliver(ctx, e)
+			continue
+		}
+		select {
+		case s.queue <- delivery{ctx: context.WithoutCancel(ctx), e: e}:
+		default:
+			metrics.EventDelivery(s.name, metrics.EventDropped)
+			slog.WarnContext(ctx, "event dropped, subscriber queue is full", "subscriber", s.name, "type", e.Type)
+		}
+	}
+}
+
+// Close перестаёт принимать события и ждёт, пока асинхронные подписчики обработают очереди, или отмены ctx.
+func (b *Bus) Close(ctx context.Context) error {
+	b.mu.Lock()
+	if !b.closed {
+		b.closed = true
+		for _, s := range b.subs {
+			if s.queue != nil {
+				close(s.queue)
+			}
+		}
+	}
+	b.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		b.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s *subscriber) deliver(ctx context.Context, e Event) {
+	if err := s.handle(ctx, e); err != nil {
+		metrics.EventDelivery(s.name, metrics.EventFailed)
+		slog.WarnContext(ctx, "event handler failed", "subscriber", s.name, "type", e.Type, "error", err)
+		return
+	}
+	metrics.EventDelivery(s.name, metrics.EventDelivered)
+}
+
+func typeSet(types []Type) map[Type]bool {
+	set := make(map[Type]bool, len(types))
+	for _, t := range types {
+		set[t] = true
+	}
+	return set
+}
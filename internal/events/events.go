@@ -0,0 +1,92 @@
+// Package events — шина доменных событий. Сервисы публикуют события о пастах, пользователях,
+// коротких ссылках и статистике, а журнал изменений, метрики и вебхуки подписываются на них,
+// не вмешиваясь в методы сервисов.
+package events
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/GritsyukLeonid/pastebin-go/internal/model"
+)
+
+// Type — тип события в виде «сущность.действие».
+type Type string
+
+const (
+	PasteCreated Type = "paste.created"
+	PasteViewed  Type = "paste.viewed"
+	PasteUpdated Type = "paste.updated"
+	PasteDeleted Type = "paste.deleted"
+	PasteExpired Type = "paste.expired"
+	// PasteExpiring — до истечения срока пасты осталось меньше заданного времени; публикуется один раз.
+	PasteExpiring Type = "paste.expiring"
+
+	UserCreated Type = "user.created"
+	UserDeleted Type = "user.deleted"
+
+	ShortURLCreated Type = "shorturl.created"
+	ShortURLDeleted Type = "shorturl.deleted"
+
+	StatsCreated Type = "stats.created"
+	StatsDeleted Type = "stats.deleted"
+
+	// Resync — часть изменений могла быть пропущена (например, после обрыва соединения с PostgreSQL):
+	// подписчики сбрасывают кэши и состояние, построенное по событиям.
+	Resync Type = "changes.resync"
+)
+
+// Entity возвращает сущность события: paste, user, shorturl, stats или changes (Resync).
+func (t Type) Entity() string {
+	entity, _, _ := strings.Cut(string(t), ".")
+	return entity
+}
+
+// Action возвращает действие события: created, viewed, updated, deleted, expired, expiring или resync.
+func (t Type) Action() string {
+	_, action, _ := strings.Cut(string(t), ".")
+	return action
+}
+
+// Changes — события, которые меняют данные (все, кроме просмотров и предупреждений об истечении срока).
+func Changes() []Type {
+	return []Type{
+		PasteCreated, PasteUpdated, PasteDeleted, PasteExpired,
+		UserCreated, UserDeleted,
+		ShortURLCreated, ShortURLDeleted,
+		StatsCreated, StatsDeleted,
+	}
+}
+
+// Event — доменное событие. Заполнено поле сущности события (Paste, User, ShortURL или Stats),
+// если она известна; для просмотров и удалений может быть только EntityID.
+// Paste может содержать содержимое и токен удаления: подписчики, которые отправляют события
+// наружу, сами выбирают, что публиковать. Before и After — краткие описания сущности для журнала.
+type Event struct {
+	Type     Type
+	EntityID string
+	At       time.Time
+
+	Paste    *model.Paste
+	User     *model.User
+	ShortURL *model.ShortURL
+	Stats    *model.Stats
+
+	Before any
+	After  any
+}
+
+// Handler обрабатывает событие. Ошибка не влияет на операцию, которая породила событие:
+// шина пишет её в журнал приложения и учитывает в метриках.
+type Handler func(ctx context.Context, e Event) error
+
+// Publisher публикует события; сервисы зависят только от него.
+type Publisher interface {
+	Publish(ctx context.Context, e Event)
+}
+
+// Nop — Publisher без подписчиков.
+type Nop struct{}
+
+func (Nop) Publish(context.Context, Event) {}
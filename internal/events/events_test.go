@@ -0,0 +1,95 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTypeEntityAndAction(t *testing.T) {
+	assert.Equal(t, "shorturl", ShortURLCreated.Entity())
+	assert.Equal(t, "created", ShortURLCreated.Action())
+	assert.Equal(t, "paste", PasteExpired.Entity())
+	assert.Equal(t, "expired", PasteExpired.Action())
+	assert.NotContains(t, Changes(), PasteViewed)
+}
+
+func TestBusFiltersByType(t *testing.T) {
+	var all, pastes []Type
+	bus := NewBus().
+		Subscribe("all", func(_ context.Context, e Event) error { all = append(all, e.Type); return nil }).
+		Subscribe("pastes", func(_ context.Context, e Event) error { pastes = append(pastes, e.Type); return nil }, PasteCreated)
+
+	ctx := context.Background()
+	bus.Publish(ctx, Event{Type: PasteCreated, EntityID: "p1"})
+	bus.Publish(ctx, Event{Type: UserCreated, EntityID: "1"})
+
+	assert.Equal(t, []Type{PasteCreated, UserCreated}, all)
+	assert.Equal(t, []Type{PasteCreated}, pastes)
+}
+
+func TestBusFailingHandlerDoesNotStopOthers(t *testing.T) {
+	var got []string
+	bus := NewBus().
+		Subscribe("broken", func(context.Context, Event) error { return errors.New("boom") }).
+		Subscribe("ok", func(_ context.Context, e Event) error { got = append(got, e.EntityID); return nil })
+
+	bus.Publish(context.Background(), Event{Type: PasteDeleted, EntityID: "p1"})
+
+	assert.Equal(t, []string{"p1"}, got)
+}
+
+func TestBusAsyncDeliversAndDrainsOnClose(t *testing.T) {
+	received := make(chan Event, 10)
+	bus := NewBus().SubscribeAsync("async", 10, func(_ context.Context, e Event) error {
+		received <- e
+		return nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	bus.Publish(ctx, Event{Type: PasteCreated, EntityID: "p1"})
+	bus.Publish(ctx, Event{Type: PasteCreated, EntityID: "p2"})
+	cancel()
+
+	closeCtx, stop := context.WithTimeout(context.Background(), time.Second)
+	defer stop()
+	require.NoError(t, bus.Close(closeCtx))
+	close(received)
+
+	var ids []string
+	for e := range received {
+		assert.False(t, e.At.IsZero())
+		ids = append(ids, e.EntityID)
+	}
+	assert.Equal(t, []string{"p1", "p2"}, ids)
+
+	bus.Publish(context.Background(), Event{Type: PasteCreated, EntityID: "p3"})
+}
+
+func TestBusAsyncDropsWhenQueueIsFull(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	var handled []string
+	bus := NewBus().SubscribeAsync("slow", 1, func(_ context.Context, e Event) error {
+		if e.EntityID == "p1" {
+			close(started)
+			<-release
+		}
+		handled = append(handled, e.EntityID)
+		return nil
+	})
+
+	ctx := context.Background()
+	bus.Publish(ctx, Event{Type: PasteCreated, EntityID: "p1"})
+	<-started
+	bus.Publish(ctx, Event{Type: PasteCreated, EntityID: "p2"})
+	bus.Publish(ctx, Event{Type: PasteCreated, EntityID: "p3"})
+	close(release)
+
+	require.NoError(t, bus.Close(context.Background()))
+	assert.Equal(t, []string{"p1", "p2"}, handled)
+}
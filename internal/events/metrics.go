@@ -0,0 +1,18 @@
+package events
+
+import (
+	"context"
+
+	"github.com/GritsyukLeonid/pastebin-go/internal/metrics"
+)
+
+// CountMetrics — подписчик, который ведёт бизнес-метрики по событиям.
+func CountMetrics(_ context.Context, e Event) error {
+	switch e.Type {
+	case PasteCreated:
+		metrics.PasteCreated()
+	case PasteExpired:
+		metrics.PastesExpired(1)
+	}
+	return nil
+}
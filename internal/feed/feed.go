@@ -0,0 +1,245 @@
+// Package feed — живая лента паст: новые, удалённые и истёкшие пасты для SSE (/api/v1/paste/stream)
+// и gRPC WatchPastes. Лента хранит последние события, чтобы клиент мог продолжить с последнего
+// полученного ID, а у каждого подписчика своя ограниченная очередь: медленный клиент отключается
+// и переподключается сам, не задерживая публикацию.
+package feed
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/GritsyukLeonid/pastebin-go/internal/config"
+	"github.com/GritsyukLeonid/pastebin-go/internal/events"
+	"github.com/GritsyukLeonid/pastebin-go/internal/links"
+	"github.com/GritsyukLeonid/pastebin-go/internal/metrics"
+)
+
+var (
+	// ErrSlowConsumer закрывает подписку, очередь которой переполнилась.
+	ErrSlowConsumer = errors.New("feed subscriber is too slow")
+	ErrClosed       = errors.New("feed is closed")
+)
+
+// TypeReset отправляется первым, если продолжить с переданного ID нельзя: событие вытеснено
+// из истории или ID выдан до перезапуска сервиса. Его же получают подключённые клиенты,
+// когда лента могла пропустить изменения (events.Resync). Клиенту стоит перечитать состояние целиком.
+const TypeReset = "feed.reset"
+
+// Types — события, которые обрабатывает лента: изменения паст и events.Resync.
+func Types() []events.Type {
+	return []events.Type{events.PasteCreated, events.PasteDeleted, events.PasteExpired, events.Resync}
+}
+
+// Item — событие ленты.
+type Item struct {
+	ID    string    `json:"id,omitempty"`
+	Type  string    `json:"type"`
+	At    time.Time `json:"at"`
+	Paste *Paste    `json:"paste,omitempty"`
+}
+
+// Paste — описание пасты в ленте без содержимого и токена удаления.
+type Paste struct {
+	ID        string    `json:"id"`
+	Hash      string    `json:"hash,omitempty"`
+	UserID    int64     `json:"userId,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+	ExpiresAt time.Time `json:"expiresAt"`
+	Size      int       `json:"size,omitempty"`
+	URL       string    `json:"url,omitempty"`
+}
+
+type Config struct {
+	// History — сколько последних событий хранится для продолжения по ID.
+	History int
+	// Buffer — очередь одного подписчика.
+	Buffer int
+}
+
+func DefaultConfig() Config {
+	return Config{History: 1000, Buffer: 64}
+}
+
+func ConfigFrom(c config.FeedConfig) Config {
+	return Config{History: c.History, Buffer: c.Buffer}
+}
+
+// Hub раздаёт события ленты подписчикам. ID событий имеют вид "<эпоха>-<номер>": эпоха меняется
+// при каждом запуске и после events.Resync, поэтому ID из прошлой эпохи распознаётся и приводит к TypeReset.
+type Hub struct {
+	links *links.Builder
+	cfg   Config
+	epoch string
+
+	mu      sync.Mutex
+	seq     uint64
+	history []Item
+	subs    map[*Subscription]struct{}
+	closed  bool
+}
+
+func NewHub(lb *links.Builder, cfg Config) *Hub {
+	return &Hub{
+		links: lb,
+		cfg:   cfg,
+		epoch: newEpoch(),
+		subs:  make(map[*Subscription]struct{}),
+	}
+}
+
+// Handle — подписчик шины событий. Не блокируется: подписчик с полной очередью отключается.
+func (h *Hub) Handle(_ context.Context, e events.Event) error {
+	if e.Type == events.Resync {
+		h.reset(e.At)
+		return nil
+	}
+	item := Item{Type: string(e.Type), At: e.At, Paste: h.paste(e)}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.closed {
+		return nil
+	}
+	h.seq++
+	item.ID = h.epoch + "-" + strconv.FormatUint(h.seq, 10)
+	h.history = append(h.history, item)
+	if len(h.history) > h.cfg.History {
+		h.history = h.history[len(h.history)-h.cfg.History:]
+	}
+	h.broadcast(item)
+	return nil
+}
+
+// reset начинает новую эпоху: история больше не описывает пропущенное, продолжить по старым ID нельзя.
+func (h *Hub) reset(at time.Time) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.closed {
+		return
+	}
+	h.epoch, h.seq, h.history = newEpoch(), 0, nil
+	h.broadcast(Item{Type: TypeReset, At: at})
+}
+
+// broadcast вызывается под h.mu.
+func (h *Hub) broadcast(item Item) {
+	for sub := range h.subs {
+		select {
+		case sub.ch <- item:
+		default:
+			h.drop(sub, ErrSlowConsumer)
+			metrics.FeedSlowConsumer()
+		}
+	}
+}
+
+// Subscribe подписывает клиента на события после lastID (пусто — только новые).
+// Сначала нужно отправить Subscription.Replay, затем читать Subscription.C до закрытия.
+func (h *Hub) Subscribe(lastID string) (*Subscription, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.closed {
+		return nil, ErrClosed
+	}
+
+	sub := &Subscription{hub: h, ch: make(chan Item, h.cfg.Buffer)}
+	if lastID != "" {
+		replay, ok := h.since(lastID)
+		if !ok {
+			replay = append([]Item{{Type: TypeReset, At: time.Now().UTC()}}, replay...)
+		}
+		sub.Replay = replay
+	}
+	h.subs[sub] = struct{}{}
+	metrics.FeedSubscribers(1)
+	return sub, nil
+}
+
+// Close отключает всех подписчиков; после него Subscribe возвращает ErrClosed, а события не сохраняются.
+func (h *Hub) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.closed = true
+	for sub := range h.subs {
+		h.drop(sub, ErrClosed)
+	}
+}
+
+// since возвращает события после lastID; false — продолжить без пропусков нельзя,
+// тогда возвращается вся история.
+func (h *Hub) since(lastID string) ([]Item, bool) {
+	epoch, seqStr, _ := strings.Cut(lastID, "-")
+	seq, err := strconv.ParseUint(seqStr, 10, 64)
+	if err != nil || epoch != h.epoch || seq > h.seq {
+		return append([]Item(nil), h.history...), false
+	}
+	missed := int(h.seq - seq)
+	if missed > len(h.history) {
+		return append([]Item(nil), h.history...), false
+	}
+	return append([]Item(nil), h.history[len(h.history)-missed:]...), true
+}
+
+// drop вызывается под h.mu.
+func (h *Hub) drop(sub *Subscription, reason error) {
+	delete(h.subs, sub)
+	sub.err = reason
+	close(sub.ch)
+	metrics.FeedSubscribers(-1)
+}
+
+func newEpoch() string {
+	b := make([]byte, 4)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+func (h *Hub) paste(e events.Event) *Paste {
+	p := &Paste{ID: e.EntityID}
+	if e.Paste == nil {
+		return p
+	}
+	p.Hash, p.UserID, p.CreatedAt, p.ExpiresAt, p.Size = e.Paste.Hash, e.Paste.UserID, e.Paste.CreatedAt, e.Paste.ExpiresAt, e.Paste.ContentSize()
+	if e.Type == events.PasteCreated && p.Hash != "" {
+		p.URL = h.links.URL(nil, "/api/v1/paste/hash/"+url.PathEscape(p.Hash))
+	}
+	return p
+}
+
+// Subscription — подписка на ленту.
+type Subscription struct {
+	// Replay — пропущенные события, которые нужно отправить до чтения C.
+	Replay []Item
+
+	hub *Hub
+	ch  chan Item
+	err error
+}
+
+// C закрывается, когда подписка завершена; причину возвращает Err.
+func (s *Subscription) C() <-chan Item {
+	return s.ch
+}
+
+// Err возвращает ErrSlowConsumer или ErrClosed после закрытия C, иначе nil.
+func (s *Subscription) Err() error {
+	s.hub.mu.Lock()
+	defer s.hub.mu.Unlock()
+	return s.err
+}
+
+// Close отписывает клиента; повторный вызов безопасен.
+func (s *Subscription) Close() {
+	s.hub.mu.Lock()
+	defer s.hub.mu.Unlock()
+	if _, ok := s.hub.subs[s]; ok {
+		s.hub.drop(s, nil)
+	}
+}
@@ -0,0 +1,164 @@
+package feed
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/GritsyukLeonid/pastebin-go/internal/events"
+	"github.com/GritsyukLeonid/pastebin-go/internal/links"
+	"github.com/GritsyukLeonid/pastebin-go/internal/model"
+)
+
+func newTestHub(t *testing.T, cfg Config) *Hub {
+	t.Helper()
+	lb, err := links.NewBuilder(links.DefaultConfig())
+	require.NoError(t, err)
+	return NewHub(lb, cfg)
+}
+
+func publish(t *testing.T, h *Hub, typ events.Type, id string) {
+	t.Helper()
+	paste := model.Paste{ID: id, Hash: "h-" + id, Content: "secret", DeleteToken: "token"}
+	require.NoError(t, h.Handle(context.Background(), events.Event{Type: typ, EntityID: id, At: time.Now(), Paste: &paste}))
+}
+
+func receive(t *testing.T, sub *Subscription) Item {
+	t.Helper()
+	select {
+	case item, ok := <-sub.C():
+		require.True(t, ok, "subscription closed: %v", sub.Err())
+		return item
+	case <-time.After(time.Second):
+		t.Fatal("no item received")
+		return Item{}
+	}
+}
+
+func TestHubDeliversItemsWithoutContent(t *testing.T) {
+	h := newTestHub(t, DefaultConfig())
+	sub, err := h.Subscribe("")
+	require.NoError(t, err)
+	defer sub.Close()
+	assert.Empty(t, sub.Replay)
+
+	publish(t, h, events.PasteCreated, "p1")
+	publish(t, h, events.PasteDeleted, "p1")
+
+	created := receive(t, sub)
+	assert.Equal(t, "paste.created", created.Type)
+	assert.Equal(t, "p1", created.Paste.ID)
+	assert.Equal(t, len("secret"), created.Paste.Size)
+	assert.Equal(t, "http://localhost:8080/api/v1/paste/hash/h-p1", created.Paste.URL)
+
+	deleted := receive(t, sub)
+	assert.Equal(t, "paste.deleted", deleted.Type)
+	assert.Empty(t, deleted.Paste.URL)
+	assert.NotEqual(t, created.ID, deleted.ID)
+}
+
+func TestHubResumesFromLastID(t *testing.T) {
+	h := newTestHub(t, Config{History: 3, Buffer: 8})
+	for _, id := range []string{"p1", "p2", "p3"} {
+		publish(t, h, events.PasteCreated, id)
+	}
+	first := h.history[0].ID
+
+	sub, err := h.Subscribe(first)
+	require.NoError(t, err)
+	defer sub.Close()
+	require.Len(t, sub.Replay, 2)
+	assert.Equal(t, "p2", sub.Replay[0].Paste.ID)
+	assert.Equal(t, "p3", sub.Replay[1].Paste.ID)
+
+	last, err := h.Subscribe(h.history[2].ID)
+	require.NoError(t, err)
+	defer last.Close()
+	assert.Empty(t, last.Replay)
+}
+
+func TestHubResetsWhenResumeIsImpossible(t *testing.T) {
+	h := newTestHub(t, Config{History: 2, Buffer: 8})
+	publish(t, h, events.PasteCreated, "p1")
+	evicted := h.history[0].ID
+	for _, id := range []string{"p2", "p3", "p4"} {
+		publish(t, h, events.PasteCreated, id)
+	}
+
+	for name, lastID := range map[string]string{
+		"evicted":        evicted,
+		"previous epoch": "deadbeef-2",
+		"garbage":        "not-an-id",
+		"from future":    h.epoch + "-99",
+	} {
+		sub, err := h.Subscribe(lastID)
+		require.NoError(t, err, name)
+		require.Len(t, sub.Replay, 3, name)
+		assert.Equal(t, TypeReset, sub.Replay[0].Type, name)
+		assert.Empty(t, sub.Replay[0].ID, name)
+		assert.Equal(t, "p3", sub.Replay[1].Paste.ID, name)
+		sub.Close()
+	}
+}
+
+func TestHubResyncStartsNewEpoch(t *testing.T) {
+	h := newTestHub(t, DefaultConfig())
+	publish(t, h, events.PasteCreated, "p1")
+	before := h.history[0].ID
+	sub, err := h.Subscribe("")
+	require.NoError(t, err)
+	defer sub.Close()
+
+	require.NoError(t, h.Handle(context.Background(), events.Event{Type: events.Resync, At: time.Now()}))
+	reset := receive(t, sub)
+	assert.Equal(t, TypeReset, reset.Type)
+	assert.Empty(t, reset.ID)
+
+	resumed, err := h.Subscribe(before)
+	require.NoError(t, err)
+	defer resumed.Close()
+	require.Len(t, resumed.Replay, 1)
+	assert.Equal(t, TypeReset, resumed.Replay[0].Type)
+
+	publish(t, h, events.PasteCreated, "p2")
+	assert.NotEqual(t, before[:8], receive(t, sub).ID[:8])
+}
+
+func TestHubDropsSlowConsumer(t *testing.T) {
+	h := newTestHub(t, Config{History: 10, Buffer: 1})
+	slow, err := h.Subscribe("")
+	require.NoError(t, err)
+	fast, err := h.Subscribe("")
+	require.NoError(t, err)
+	defer fast.Close()
+
+	publish(t, h, events.PasteCreated, "p1")
+	receive(t, fast)
+	publish(t, h, events.PasteCreated, "p2")
+	receive(t, fast)
+
+	receive(t, slow)
+	_, ok := <-slow.C()
+	assert.False(t, ok)
+	assert.ErrorIs(t, slow.Err(), ErrSlowConsumer)
+	slow.Close()
+}
+
+func TestHubCloseEndsSubscriptions(t *testing.T) {
+	h := newTestHub(t, DefaultConfig())
+	sub, err := h.Subscribe("")
+	require.NoError(t, err)
+
+	h.Close()
+	_, ok := <-sub.C()
+	assert.False(t, ok)
+	assert.ErrorIs(t, sub.Err(), ErrClosed)
+	sub.Close()
+
+	_, err = h.Subscribe("")
+	assert.ErrorIs(t, err, ErrClosed)
+	publish(t, h, events.PasteCreated, "p1")
+}
@@ -0,0 +1,177 @@
+// Package gateway — REST API, который grpc-gateway генерирует из аннотаций google.api.http
+// в internal/pb/pastebin/v1. Запросы HTTP вызывают те же реализации сервисов, что и сервер gRPC,
+// через клиентов поверх grpcserver.Local: без сетевого вызова, но с той же аутентификацией,
+// лимитами, сроком вызова и проверкой запроса.
+package gateway
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/textproto"
+	"net/url"
+	"strings"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/utilities"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/GritsyukLeonid/pastebin-go/internal/grpc/grpcimpl"
+	pb "github.com/GritsyukLeonid/pastebin-go/internal/pb/pastebin/v1"
+	"github.com/GritsyukLeonid/pastebin-go/internal/reqctx"
+)
+
+// forwardedHeaders — заголовки, которые передаются в метаданные вызова под тем же именем в нижнем регистре.
+var forwardedHeaders = map[string]bool{
+	textproto.CanonicalMIMEHeaderKey(grpcimpl.DeleteTokenKey):   true,
+	textproto.CanonicalMIMEHeaderKey(grpcimpl.WebhookSecretKey): true,
+	textproto.CanonicalMIMEHeaderKey(grpcimpl.AdminTokenKey):    true,
+	"X-Api-Key":     true,
+	"Authorization": true,
+}
+
+// NewServeMux создаёт мультиплексор шлюза; сервисы на нём регистрирует Register.
+func NewServeMux() *runtime.ServeMux {
+	return runtime.NewServeMux(
+		runtime.WithMarshalerOption(runtime.MIMEWildcard, &runtime.JSONPb{
+			MarshalOptions:   protojson.MarshalOptions{EmitUnpopulated: true, UseProtoNames: true},
+			UnmarshalOptions: protojson.UnmarshalOptions{DiscardUnknown: true},
+		}),
+		runtime.WithIncomingHeaderMatcher(matchHeader),
+		runtime.WithMetadata(forwardedOrigin),
+		runtime.WithOutgoingHeaderMatcher(matchResponseHeader),
+		runtime.WithForwardResponseOption(setStatus),
+		runtime.WithErrorHandler(handleError),
+		runtime.WithMiddlewares(withPeer),
+		runtime.SetQueryParameterParser(secretsQueryParser{}),
+	)
+}
+
+// Register подключает к mux все сервисы pastebin.v1 через conn — в монолите это grpcserver.Local.
+func Register(ctx context.Context, mux *runtime.ServeMux, conn grpc.ClientConnInterface) error {
+	return errors.Join(
+		pb.RegisterPasteServiceHandlerClient(ctx, mux, pb.NewPasteServiceClient(conn)),
+		pb.RegisterUserServiceHandlerClient(ctx, mux, pb.NewUserServiceClient(conn)),
+		pb.RegisterStatsServiceHandlerClient(ctx, mux, pb.NewStatsServiceClient(conn)),
+		pb.RegisterShortURLServiceHandlerClient(ctx, mux, pb.NewShortURLServiceClient(conn)),
+		pb.RegisterAnalyticsServiceHandlerClient(ctx, mux, pb.NewAnalyticsServiceClient(conn)),
+		pb.RegisterWebhookServiceHandlerClient(ctx, mux, pb.NewWebhookServiceClient(conn)),
+		pb.RegisterAuditServiceHandlerClient(ctx, mux, pb.NewAuditServiceClient(conn)),
+	)
+}
+
+// secretQueryParams — поля запросов с секретами. В строке запроса они попали бы в журналы доступа
+// и историю браузера, поэтому передаются только заголовками или в теле запроса.
+var secretQueryParams = map[string]bool{
+	"delete_token": true,
+	"deleteToken":  true,
+	"secret":       true,
+}
+
+// secretsQueryParser отклоняет запросы с секретами в строке запроса, остальное разбирает как обычно.
+type secretsQueryParser struct{}
+
+func (secretsQueryParser) Parse(msg proto.Message, values url.Values, filter *utilities.DoubleArray) error {
+	for key := range values {
+		if secretQueryParams[key] {
+			return fmt.Errorf("%s must be sent in a header, not in the query string", key)
+		}
+	}
+	return (&runtime.DefaultQueryParser{}).Parse(msg, values, filter)
+}
+
+func matchHeader(key string) (string, bool) {
+	if canonical := textproto.CanonicalMIMEHeaderKey(key); forwardedHeaders[canonical] {
+		return strings.ToLower(canonical), true
+	}
+	name, ok := runtime.DefaultHeaderMatcher(key)
+	if lower := strings.ToLower(name); lower == grpcimpl.ForwardedHostKey || lower == grpcimpl.ForwardedProtoKey {
+		return "", false
+	}
+	return name, ok
+}
+
+// forwardedOrigin передаёт сервисам X-Forwarded-Host/Proto, чтобы ссылки в ответах строились
+// по публичному адресу, как в ответах HTTP. Заголовки учитываются, только если запрос пришёл
+// от доверенного прокси: это проверяет links.Builder по адресу из withPeer.
+func forwardedOrigin(_ context.Context, r *http.Request) metadata.MD {
+	md := metadata.MD{}
+	if host := r.Header.Get("X-Forwarded-Host"); host != "" {
+		md.Set(grpcimpl.ForwardedHostKey, host)
+	}
+	if proto := r.Header.Get("X-Forwarded-Proto"); proto != "" {
+		md.Set(grpcimpl.ForwardedProtoKey, proto)
+	}
+	return md
+}
+
+// matchResponseHeader отдаёт заголовки лимитов без префикса Grpc-Metadata-, как их ждут клиенты HTTP.
+func matchResponseHeader(key string) (string, bool) {
+	if strings.HasPrefix(key, "x-ratelimit-") || key == "retry-after" {
+		return textproto.CanonicalMIMEHeaderKey(key), true
+	}
+	return runtime.MetadataHeaderPrefix + key, true
+}
+
+// setStatus отвечает 201 на создание и 204 на удаление; остальные успешные ответы — 200.
+func setStatus(ctx context.Context, w http.ResponseWriter, _ proto.Message) error {
+	method, ok := runtime.RPCMethod(ctx)
+	if !ok {
+		return nil
+	}
+	name := method[strings.LastIndex(method, "/")+1:]
+	switch {
+	case strings.HasPrefix(name, "Create"):
+		w.WriteHeader(http.StatusCreated)
+	case strings.HasPrefix(name, "Delete"):
+		w.WriteHeader(http.StatusNoContent)
+	}
+	return nil
+}
+
+// handleError отвечает, как runtime.DefaultHTTPErrorHandler, но удалённые и истёкшие сущности
+// (grpcimpl.IsGone) получают 410 Gone, как на /s/{code}.
+func handleError(ctx context.Context, mux *runtime.ServeMux, m runtime.Marshaler, w http.ResponseWriter, r *http.Request, err error) {
+	if grpcimpl.IsGone(err) {
+		w = statusWriter{ResponseWriter: w, status: http.StatusGone}
+	}
+	runtime.DefaultHTTPErrorHandler(ctx, mux, m, w, r, err)
+}
+
+// statusWriter заменяет код ответа на status.
+type statusWriter struct {
+	http.ResponseWriter
+	status int
+}
+
+func (w statusWriter) WriteHeader(int) {
+	w.ResponseWriter.WriteHeader(w.status)
+}
+
+// withPeer передаёт сервисам адрес клиента и состояние TLS HTTP-запроса, как у соединения gRPC:
+// по ним определяются IP для квот и лимитов и сервис по клиентскому сертификату.
+func withPeer(next runtime.HandlerFunc) runtime.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, params map[string]string) {
+		p := &peer.Peer{Addr: remoteAddr(r)}
+		if r.TLS != nil {
+			p.AuthInfo = credentials.TLSInfo{State: *r.TLS}
+		}
+		ctx := peer.NewContext(r.Context(), p)
+		next(w, r.WithContext(reqctx.WithClientIP(ctx, reqctx.RemoteIP(r))), params)
+	}
+}
+
+func remoteAddr(r *http.Request) net.Addr {
+	if addr, err := netip.ParseAddrPort(r.RemoteAddr); err == nil {
+		return net.TCPAddrFromAddrPort(addr)
+	}
+	return &net.TCPAddr{IP: net.ParseIP(reqctx.RemoteIP(r))}
+}
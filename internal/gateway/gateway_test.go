@@ -0,0 +1,295 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/GritsyukLeonid/pastebin-go/internal/docs"
+	"github.com/GritsyukLeonid/pastebin-go/internal/grpc/grpcimpl"
+	"github.com/GritsyukLeonid/pastebin-go/internal/grpc/grpcserver"
+	"github.com/GritsyukLeonid/pastebin-go/internal/links"
+	"github.com/GritsyukLeonid/pastebin-go/internal/model"
+	pb "github.com/GritsyukLeonid/pastebin-go/internal/pb/pastebin/v1"
+	"github.com/GritsyukLeonid/pastebin-go/internal/ratelimit"
+	"github.com/GritsyukLeonid/pastebin-go/internal/reqctx"
+	"github.com/GritsyukLeonid/pastebin-go/internal/service"
+)
+
+type stubPastes struct {
+	pb.UnimplementedPasteServiceServer
+
+	created  *pb.CreatePasteRequest
+	clientIP string
+	token    string
+	update   *pb.UpdatePasteRequest
+}
+
+func (s *stubPastes) CreatePaste(ctx context.Context, req *pb.CreatePasteRequest) (*pb.CreatePasteResponse, error) {
+	s.created = req
+	s.clientIP = reqctx.ClientIP(ctx)
+	return &pb.CreatePasteResponse{Paste: &pb.Paste{Id: "1"}, DeleteToken: "secret"}, nil
+}
+
+func (s *stubPastes) GetPaste(context.Context, *pb.GetPasteRequest) (*pb.GetPasteResponse, error) {
+	return nil, status.Error(codes.NotFound, "paste not found")
+}
+
+func (s *stubPastes) UpdatePaste(_ context.Context, req *pb.UpdatePasteRequest) (*pb.UpdatePasteResponse, error) {
+	s.update = req
+	return &pb.UpdatePasteResponse{Paste: req.Paste}, nil
+}
+
+func (s *stubPastes) DeletePaste(ctx context.Context, _ *pb.DeletePasteRequest) (*pb.DeletePasteResponse, error) {
+	if vals := metadata.ValueFromIncomingContext(ctx, grpcimpl.DeleteTokenKey); len(vals) > 0 {
+		s.token = vals[0]
+	}
+	return &pb.DeletePasteResponse{}, nil
+}
+
+type stubs struct {
+	pb.UnimplementedUserServiceServer
+	pb.UnimplementedStatsServiceServer
+	pb.UnimplementedShortURLServiceServer
+	pb.UnimplementedAnalyticsServiceServer
+	pb.UnimplementedWebhookServiceServer
+	pb.UnimplementedAuditServiceServer
+}
+
+func newTestMux(t *testing.T, pastes pb.PasteServiceServer) *runtime.ServeMux {
+	t.Helper()
+	return newLocalMux(t, grpcserver.DefaultConfig(), ratelimit.Policy{}, pastes)
+}
+
+// newLocalMux подключает шлюз к сервисам через grpcserver.Local с цепочкой перехватчиков из cfg и policy.
+func newLocalMux(t *testing.T, cfg grpcserver.Config, policy ratelimit.Policy, pastes pb.PasteServiceServer) *runtime.ServeMux {
+	t.Helper()
+	local := grpcserver.NewLocal(cfg, ratelimit.NewMiddleware(ratelimit.NewMemoryLimiter(), policy))
+	var s stubs
+	pb.RegisterPasteServiceServer(local, pastes)
+	pb.RegisterUserServiceServer(local, s)
+	pb.RegisterStatsServiceServer(local, s)
+	pb.RegisterShortURLServiceServer(local, s)
+	pb.RegisterAnalyticsServiceServer(local, s)
+	pb.RegisterWebhookServiceServer(local, s)
+	pb.RegisterAuditServiceServer(local, s)
+
+	mux := NewServeMux()
+	require.NoError(t, Register(context.Background(), mux, local))
+	return mux
+}
+
+func serve(mux http.Handler, method, target, body string, header http.Header) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestCreatePaste(t *testing.T) {
+	pastes := &stubPastes{}
+	mux := newTestMux(t, pastes)
+
+	rec := serve(mux, http.MethodPost, "/api/v1/paste", `{"content":"hello","ttl":"60s","unknown":true}`, nil)
+
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+	assert.Contains(t, rec.Body.String(), `"delete_token":"secret"`, "поля называются как в proto")
+	assert.Contains(t, rec.Body.String(), `"views":"0"`, "нулевые поля не пропускаются")
+	require.NotNil(t, pastes.created)
+	assert.Equal(t, "hello", pastes.created.Content)
+	assert.Equal(t, time.Minute, pastes.created.GetTtl().AsDuration())
+	assert.Equal(t, "192.0.2.1", pastes.clientIP)
+}
+
+func TestCreatePasteUsesClientIPBehindProxy(t *testing.T) {
+	builder, err := links.NewBuilder(links.Config{BaseURL: links.DefaultBaseURL, TrustForwarded: true, TrustedProxies: []string{"10.0.0.0/8"}})
+	require.NoError(t, err)
+	pastes := &stubPastes{}
+	handler := reqctx.ClientIPHandler(newTestMux(t, pastes), builder.ClientIP)
+
+	create := func(peer string) string {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/paste", strings.NewReader(`{"content":"hello"}`))
+		req.RemoteAddr = peer
+		req.Header.Set("X-Forwarded-For", "198.51.100.7")
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+		return pastes.clientIP
+	}
+
+	assert.Equal(t, "198.51.100.7", create("10.0.0.5:41000"), "квота анонимного клиента считается по его IP, а не по IP прокси")
+	assert.Equal(t, "203.0.113.9", create("203.0.113.9:41000"))
+}
+
+func TestUpdatePasteMasksBodyFields(t *testing.T) {
+	pastes := &stubPastes{}
+	mux := newTestMux(t, pastes)
+
+	rec := serve(mux, http.MethodPatch, "/api/v1/paste/42", `{"expiresAt":"2030-01-01T00:00:00Z"}`, nil)
+
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	require.NotNil(t, pastes.update)
+	assert.Equal(t, "42", pastes.update.Paste.Id)
+	assert.Equal(t, []string{"expires_at"}, pastes.update.UpdateMask.GetPaths())
+}
+
+func TestDeletePasteForwardsTokenHeader(t *testing.T) {
+	pastes := &stubPastes{}
+	mux := newTestMux(t, pastes)
+
+	rec := serve(mux, http.MethodDelete, "/api/v1/paste/42", "", http.Header{"X-Delete-Token": {"secret"}})
+
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Equal(t, "secret", pastes.token)
+}
+
+func TestDeletePasteRejectsTokenInQuery(t *testing.T) {
+	pastes := &stubPastes{}
+	mux := newTestMux(t, pastes)
+
+	for _, target := range []string{"/api/v1/paste/42?delete_token=secret", "/api/v1/paste/42?deleteToken=secret"} {
+		rec := serve(mux, http.MethodDelete, target, "", nil)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code, target)
+	}
+	rec := serve(mux, http.MethodPatch, "/api/v1/paste/42?delete_token=secret", `{"content":"x"}`, nil)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Nil(t, pastes.update)
+}
+
+type stubShorts struct {
+	pb.UnimplementedShortURLServiceServer
+}
+
+func (stubShorts) GetShortURL(_ context.Context, req *pb.GetShortURLRequest) (*pb.GetShortURLResponse, error) {
+	if req.Id == "gone" {
+		return nil, grpcimpl.GoneError(errors.New("short url is gone"))
+	}
+	return nil, status.Error(codes.NotFound, "short url not found")
+}
+
+func TestGoneShortURLAnswers410(t *testing.T) {
+	local := grpcserver.NewLocal(grpcserver.DefaultConfig(), ratelimit.NewMiddleware(ratelimit.NewMemoryLimiter(), ratelimit.Policy{}))
+	pb.RegisterShortURLServiceServer(local, stubShorts{})
+	mux := NewServeMux()
+	require.NoError(t, Register(context.Background(), mux, local))
+
+	rec := serve(mux, http.MethodGet, "/api/v1/shorturl/gone", "", nil)
+	assert.Equal(t, http.StatusGone, rec.Code, rec.Body.String())
+	assert.Contains(t, rec.Body.String(), `"code":5`)
+
+	rec = serve(mux, http.MethodGet, "/api/v1/shorturl/missing", "", nil)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+// shortURLs отдаёт любую ссылку по коду, чтобы проверить адрес, который строит grpcimpl.
+type shortURLs struct {
+	service.ShortURLService
+}
+
+func (shortURLs) GetShortURLByID(_ context.Context, id string) (model.ShortURL, error) {
+	return model.ShortURL{ID: id, Original: "https://example.com", TargetType: model.TargetURL}, nil
+}
+
+func TestShortURLLinkUsesForwardedHostFromTrustedProxy(t *testing.T) {
+	builder, err := links.NewBuilder(links.Config{BaseURL: links.DefaultBaseURL, TrustForwarded: true, TrustedProxies: []string{"10.0.0.0/8"}})
+	require.NoError(t, err)
+	local := grpcserver.NewLocal(grpcserver.DefaultConfig(), ratelimit.NewMiddleware(ratelimit.NewMemoryLimiter(), ratelimit.Policy{}))
+	pb.RegisterShortURLServiceServer(local, grpcimpl.NewServer(nil, nil, nil, shortURLs{}, builder, nil, 0))
+	mux := NewServeMux()
+	require.NoError(t, Register(context.Background(), mux, local))
+
+	link := func(peer string, header http.Header) string {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/shorturl/abc", nil)
+		req.RemoteAddr = peer
+		for k, v := range header {
+			req.Header[k] = v
+		}
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		var short struct {
+			Link string `json:"link"`
+		}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &short))
+		return short.Link
+	}
+	forwarded := http.Header{"X-Forwarded-Host": {"pst.example.com"}, "X-Forwarded-Proto": {"https"}}
+
+	assert.Equal(t, "https://pst.example.com/s/abc", link("10.0.0.5:41000", forwarded))
+	assert.Equal(t, "http://localhost:8080/s/abc", link("203.0.113.9:41000", forwarded), "заголовки не от прокси игнорируются")
+	assert.Equal(t, "http://localhost:8080/s/abc", link("10.0.0.5:41000", http.Header{
+		"Grpc-Metadata-" + grpcimpl.ForwardedHostKey: {"evil.example.com"},
+	}), "ключ метаданных нельзя передать заголовком Grpc-Metadata-*")
+}
+
+func TestInterceptorsApplyToREST(t *testing.T) {
+	cfg := grpcserver.DefaultConfig()
+	cfg.APIKeys = []string{"secret"}
+	pastes := &stubPastes{}
+	mux := newLocalMux(t, cfg, ratelimit.Policy{}, pastes)
+
+	rec := serve(mux, http.MethodPost, "/api/v1/paste", `{"content":"hello"}`, nil)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code, "без ключа")
+	rec = serve(mux, http.MethodPost, "/api/v1/paste", `{"content":""}`, http.Header{"X-Api-Key": {"secret"}})
+	assert.Equal(t, http.StatusBadRequest, rec.Code, "запрос проверяется до сервиса")
+	assert.Nil(t, pastes.created)
+
+	rec = serve(mux, http.MethodPost, "/api/v1/paste", `{"content":"hello"}`, http.Header{"Authorization": {"Bearer secret"}})
+	assert.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+}
+
+func TestRateLimitHeadersOverREST(t *testing.T) {
+	mux := newLocalMux(t, grpcserver.DefaultConfig(), ratelimit.Policy{Write: ratelimit.PerMinute(1, 1)}, &stubPastes{})
+
+	rec := serve(mux, http.MethodPost, "/api/v1/paste", `{"content":"hello"}`, nil)
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+	assert.Equal(t, "1", rec.Header().Get("X-RateLimit-Limit"))
+
+	rec = serve(mux, http.MethodPost, "/api/v1/paste", `{"content":"hello"}`, nil)
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.NotEmpty(t, rec.Header().Get("Retry-After"))
+}
+
+func TestErrorCodesMapToHTTPStatus(t *testing.T) {
+	mux := newTestMux(t, &stubPastes{})
+
+	rec := serve(mux, http.MethodGet, "/api/v1/paste/42", "", nil)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Contains(t, rec.Body.String(), "paste not found")
+}
+
+// Маршруты mux берутся из спецификации: каждый должен дойти до цепочки вызова (проверка запроса
+// или сервис), а не получить 404 шлюза.
+func TestSpecRoutesAreServed(t *testing.T) {
+	mux := newTestMux(t, pb.UnimplementedPasteServiceServer{})
+	routes, err := docs.Routes()
+	require.NoError(t, err)
+	require.NotEmpty(t, routes)
+
+	for _, route := range routes {
+		path := route.Path
+		for strings.Contains(path, "{") {
+			start, end := strings.Index(path, "{"), strings.Index(path, "}")
+			path = path[:start] + "1" + path[end+1:]
+		}
+		rec := serve(mux, route.Method, path, "{}", nil)
+		assert.Contains(t, []int{http.StatusNotImplemented, http.StatusBadRequest}, rec.Code, "%s %s: %s", route.Method, route.Path, rec.Body.String())
+	}
+}
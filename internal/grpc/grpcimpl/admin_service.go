@@ -0,0 +1,220 @@
+package grpcimpl
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/GritsyukLeonid/pastebin-go/internal/model"
+	pb "github.com/GritsyukLeonid/pastebin-go/internal/pb/pastebin/v1"
+	"github.com/GritsyukLeonid/pastebin-go/internal/service"
+)
+
+// Сервисы, которым нужен PostgreSQL: аналитика переходов, вебхуки и журнал аудита.
+
+// analyticsPeriod — период аналитики, если since не задан.
+const analyticsPeriod = 30 * 24 * time.Hour
+
+type AnalyticsServer struct {
+	pb.UnimplementedAnalyticsServiceServer
+
+	clicks service.ClickService
+}
+
+func NewAnalyticsServer(clicks service.ClickService) *AnalyticsServer {
+	return &AnalyticsServer{clicks: clicks}
+}
+
+func (s *AnalyticsServer) GetShortURLAnalytics(ctx context.Context, req *pb.GetShortURLAnalyticsRequest) (*pb.GetShortURLAnalyticsResponse, error) {
+	bucket := req.Bucket
+	if bucket == "" {
+		bucket = service.BucketDay
+	}
+	since := time.Now().Add(-analyticsPeriod)
+	if req.Since != nil {
+		since = req.Since.AsTime()
+	}
+
+	a, err := s.clicks.GetAnalytics(ctx, req.Id, since, bucket)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	out := &pb.ClickAnalytics{
+		ShortId:        a.ShortID,
+		Since:          timestamppb.New(a.Since),
+		Bucket:         a.Bucket,
+		TotalClicks:    a.TotalClicks,
+		UniqueVisitors: a.UniqueVisitors,
+		Countries:      a.Countries,
+		AgentClasses:   a.AgentClasses,
+	}
+	for _, b := range a.Buckets {
+		out.Buckets = append(out.Buckets, &pb.ClickBucket{Start: timestamppb.New(b.Start), Clicks: b.Clicks})
+	}
+	for _, r := range a.TopReferrers {
+		out.TopReferrers = append(out.TopReferrers, &pb.ReferrerCount{Host: r.Host, Clicks: r.Clicks})
+	}
+	return &pb.GetShortURLAnalyticsResponse{Analytics: out}, nil
+}
+
+// WebhookServer управляет вебхуками; кроме создания, вызовы требуют секрет вебхука в WebhookSecretKey.
+type WebhookServer struct {
+	pb.UnimplementedWebhookServiceServer
+
+	webhooks service.WebhookService
+}
+
+func NewWebhookServer(webhooks service.WebhookService) *WebhookServer {
+	return &WebhookServer{webhooks: webhooks}
+}
+
+func (s *WebhookServer) CreateWebhook(ctx context.Context, req *pb.CreateWebhookRequest) (*pb.CreateWebhookResponse, error) {
+	created, err := s.webhooks.CreateWebhook(ctx, model.Webhook{
+		URL:    req.Url,
+		Events: req.Events,
+		UserID: req.UserId,
+		Secret: req.Secret,
+	})
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return &pb.CreateWebhookResponse{Webhook: toPBWebhook(created)}, nil
+}
+
+func (s *WebhookServer) GetWebhook(ctx context.Context, req *pb.GetWebhookRequest) (*pb.GetWebhookResponse, error) {
+	hook, err := s.webhooks.GetWebhook(ctx, req.Id, fromMetadata(ctx, WebhookSecretKey, ""))
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return &pb.GetWebhookResponse{Webhook: toPBWebhook(hook)}, nil
+}
+
+func (s *WebhookServer) DeleteWebhook(ctx context.Context, req *pb.DeleteWebhookRequest) (*pb.DeleteWebhookResponse, error) {
+	if err := s.webhooks.DeleteWebhook(ctx, req.Id, fromMetadata(ctx, WebhookSecretKey, "")); err != nil {
+		return nil, grpcError(err)
+	}
+	return &pb.DeleteWebhookResponse{}, nil
+}
+
+func (s *WebhookServer) ListWebhookDeliveries(ctx context.Context, req *pb.ListWebhookDeliveriesRequest) (*pb.ListWebhookDeliveriesResponse, error) {
+	deliveries, err := s.webhooks.ListDeliveries(ctx, req.Id, fromMetadata(ctx, WebhookSecretKey, ""), req.Status, int(req.Limit))
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	resp := &pb.ListWebhookDeliveriesResponse{Deliveries: make([]*pb.WebhookDelivery, 0, len(deliveries))}
+	for _, d := range deliveries {
+		out := &pb.WebhookDelivery{
+			Id:           d.ID,
+			WebhookId:    d.WebhookID,
+			EventId:      d.EventID,
+			Event:        d.Event,
+			Payload:      toStruct(d.Payload),
+			Status:       d.Status,
+			AttemptCount: int32(d.AttemptCount),
+			CreatedAt:    timestamppb.New(d.CreatedAt),
+		}
+		if d.NextAttemptAt != nil {
+			out.NextAttemptAt = timestamppb.New(*d.NextAttemptAt)
+		}
+		for _, a := range d.Attempts {
+			out.Attempts = append(out.Attempts, &pb.WebhookAttempt{
+				Attempt:    int32(a.Attempt),
+				At:         timestamppb.New(a.At),
+				StatusCode: int32(a.StatusCode),
+				Error:      a.Error,
+				DurationMs: a.DurationMS,
+			})
+		}
+		resp.Deliveries = append(resp.Deliveries, out)
+	}
+	return resp, nil
+}
+
+func toPBWebhook(w model.Webhook) *pb.Webhook {
+	return &pb.Webhook{
+		Id:        w.ID,
+		Url:       w.URL,
+		Events:    w.Events,
+		UserId:    w.UserID,
+		Secret:    w.Secret,
+		CreatedAt: timestamppb.New(w.CreatedAt),
+	}
+}
+
+// AuditServer отдаёт журнал аудита по токену администратора в AdminTokenKey.
+type AuditServer struct {
+	pb.UnimplementedAuditServiceServer
+
+	audit      service.AuditService
+	adminToken string
+}
+
+// NewAuditServer создаёт сервис журнала аудита; пустой adminToken отключает доступ к журналу.
+func NewAuditServer(audit service.AuditService, adminToken string) *AuditServer {
+	return &AuditServer{audit: audit, adminToken: adminToken}
+}
+
+func (s *AuditServer) ListAudit(ctx context.Context, req *pb.ListAuditRequest) (*pb.ListAuditResponse, error) {
+	if !s.authorized(ctx) {
+		return nil, status.Error(codes.PermissionDenied, "forbidden")
+	}
+
+	filter := model.AuditFilter{Entity: req.Entity, Actor: req.Actor, Limit: int(req.Limit)}
+	if req.From != nil {
+		filter.From = req.From.AsTime()
+	}
+	if req.To != nil {
+		filter.To = req.To.AsTime()
+	}
+	entries, err := s.audit.ListAudit(ctx, filter)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	resp := &pb.ListAuditResponse{Entries: make([]*pb.AuditEntry, 0, len(entries))}
+	for _, e := range entries {
+		resp.Entries = append(resp.Entries, &pb.AuditEntry{
+			Id:        e.ID,
+			Entity:    e.Entity,
+			EntityId:  e.EntityID,
+			Action:    e.Action,
+			Actor:     e.Actor,
+			RequestId: e.RequestID,
+			At:        timestamppb.New(e.At),
+			Before:    toStruct(e.Before),
+			After:     toStruct(e.After),
+		})
+	}
+	return resp, nil
+}
+
+func (s *AuditServer) authorized(ctx context.Context) bool {
+	return adminAuthorized(ctx, s.adminToken)
+}
+
+// adminAuthorized сравнивает токен из AdminTokenKey с adminToken; пустой adminToken не подходит ни к чему.
+func adminAuthorized(ctx context.Context, adminToken string) bool {
+	if adminToken == "" {
+		return false
+	}
+	token := fromMetadata(ctx, AdminTokenKey, "")
+	return subtle.ConstantTimeCompare([]byte(token), []byte(adminToken)) == 1
+}
+
+// toStruct переводит JSON-объект в Struct; пустое значение или не объект — nil.
+func toStruct(raw json.RawMessage) *structpb.Struct {
+	if len(raw) == 0 {
+		return nil
+	}
+	var st structpb.Struct
+	if err := protojson.Unmarshal(raw, &st); err != nil {
+		return nil
+	}
+	return &st
+}
@@ -2,275 +2,539 @@ package grpcimpl
 
 import (
 	"context"
+	"errors"
+	"log/slog"
+	"net"
+	"net/http"
+	"strconv"
 	"time"
 
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/GritsyukLeonid/pastebin-go/internal/feed"
+	"github.com/GritsyukLeonid/pastebin-go/internal/links"
 	"github.com/GritsyukLeonid/pastebin-go/internal/model"
-	"github.com/GritsyukLeonid/pastebin-go/internal/pb"
-	"github.com/GritsyukLeonid/pastebin-go/internal/repository"
-	"github.com/google/uuid"
+	pb "github.com/GritsyukLeonid/pastebin-go/internal/pb/pastebin/v1"
+	"github.com/GritsyukLeonid/pastebin-go/internal/reqctx"
+	"github.com/GritsyukLeonid/pastebin-go/internal/service"
 )
 
+// defaultPasteTTL применяется, если в CreatePasteRequest не задан срок жизни.
+const defaultPasteTTL = 24 * time.Hour
+
 type Server struct {
 	pb.UnimplementedPasteServiceServer
 	pb.UnimplementedUserServiceServer
 	pb.UnimplementedStatsServiceServer
 	pb.UnimplementedShortURLServiceServer
+
+	pastes service.PasteService
+	users  service.UserService
+	stats  service.StatsService
+	shorts service.ShortURLService
+	links  *links.Builder
+	feed   *feed.Hub
+	// popularLimit — число популярных паст, если limit не задан.
+	popularLimit int
+	// adminToken в AdminTokenKey позволяет удалить пасту без токена удаления.
+	adminToken string
+}
+
+func NewServer(pastes service.PasteService, users service.UserService, stats service.StatsService, shorts service.ShortURLService, lb *links.Builder, hub *feed.Hub, popularLimit int) *Server {
+	return &Server{pastes: pastes, users: users, stats: stats, shorts: shorts, links: lb, feed: hub, popularLimit: popularLimit}
+}
+
+// WithAdminToken разрешает администратору удалять пасты без токена удаления; пустой token — запрещает.
+func (s *Server) WithAdminToken(token string) *Server {
+	s.adminToken = token
+	return s
 }
 
 // --- User ---
 
-func (s *Server) CreateUser(ctx context.Context, user *pb.User) (*pb.User, error) {
-	u := &model.User{
-		ID:       user.Id,
-		Username: user.Username,
-	}
-	err := repository.AddUser(u)
+func (s *Server) CreateUser(ctx context.Context, req *pb.CreateUserRequest) (*pb.CreateUserResponse, error) {
+	u, err := s.users.CreateUser(ctx, model.User{Username: req.Username})
 	if err != nil {
-		return nil, err
+		return nil, grpcError(err)
 	}
-	return &pb.User{
-		Id:       u.ID,
-		Username: u.Username,
-	}, nil
+	return &pb.CreateUserResponse{User: toPBUser(u)}, nil
 }
 
-func (s *Server) GetUser(ctx context.Context, req *pb.IDRequestInt) (*pb.User, error) {
-	u, err := repository.GetUserByID(req.Id)
+func (s *Server) GetUser(ctx context.Context, req *pb.GetUserRequest) (*pb.GetUserResponse, error) {
+	u, err := s.users.GetUserByID(ctx, strconv.FormatInt(req.Id, 10))
 	if err != nil {
-		return nil, err
+		return nil, status.Error(codes.NotFound, err.Error())
 	}
-	return &pb.User{
-		Id:       u.ID,
-		Username: u.Username,
-	}, nil
+	return &pb.GetUserResponse{User: toPBUser(u)}, nil
 }
 
-func (s *Server) ListUsers(req *pb.Empty, stream pb.UserService_ListUsersServer) error {
-	users := repository.GetAllUsers()
+func (s *Server) ListUsers(ctx context.Context, _ *pb.ListUsersRequest) (*pb.ListUsersResponse, error) {
+	users, err := s.users.ListUsers(ctx)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	resp := &pb.ListUsersResponse{Users: make([]*pb.User, 0, len(users))}
 	for _, u := range users {
-		err := stream.Send(&pb.User{
-			Id:       u.ID,
-			Username: u.Username,
-		})
-		if err != nil {
-			return err
-		}
+		resp.Users = append(resp.Users, toPBUser(u))
 	}
-	return nil
+	return resp, nil
 }
 
-func (s *Server) UpdateUser(ctx context.Context, user *pb.User) (*pb.User, error) {
-	u := &model.User{
-		ID:       user.Id,
-		Username: user.Username,
+func (s *Server) DeleteUser(ctx context.Context, req *pb.DeleteUserRequest) (*pb.DeleteUserResponse, error) {
+	if err := s.users.DeleteUser(ctx, strconv.FormatInt(req.Id, 10)); err != nil {
+		return nil, grpcError(err)
 	}
-	err := repository.UpdateUser(u.ID, u)
-	if err != nil {
-		return nil, err
-	}
-	return user, nil
+	return &pb.DeleteUserResponse{}, nil
 }
 
-func (s *Server) DeleteUser(ctx context.Context, req *pb.IDRequestInt) (*pb.Status, error) {
-	err := repository.DeleteUser(req.Id)
+func (s *Server) GetUserUsage(ctx context.Context, req *pb.GetUserUsageRequest) (*pb.GetUserUsageResponse, error) {
+	usage, err := s.users.GetUsage(ctx, strconv.FormatInt(req.Id, 10))
 	if err != nil {
-		return nil, err
+		return nil, status.Error(codes.NotFound, err.Error())
 	}
-	return &pb.Status{Message: "User deleted"}, nil
+	return &pb.GetUserUsageResponse{Usage: &pb.Usage{
+		LivePastes:  int64(usage.LivePastes),
+		StoredBytes: usage.StoredBytes,
+		PastesToday: int64(usage.PastesToday),
+		Limits: &pb.Quota{
+			MaxPasteBytes:   usage.Limits.MaxPasteBytes,
+			MaxStoredBytes:  usage.Limits.MaxStoredBytes,
+			MaxLivePastes:   int64(usage.Limits.MaxLivePastes),
+			MaxPastesPerDay: int64(usage.Limits.MaxPastesPerDay),
+		},
+	}}, nil
+}
+
+func toPBUser(u model.User) *pb.User {
+	return &pb.User{Id: u.ID, Username: u.Username, Posts: u.Posts}
 }
 
 // --- Paste ---
 
-func (s *Server) CreatePaste(ctx context.Context, req *pb.Paste) (*pb.Paste, error) {
-	paste := &model.Paste{
-		ID:        uuid.New().String(),
-		Content:   req.Content,
-		CreatedAt: time.Now(),
+func (s *Server) CreatePaste(ctx context.Context, req *pb.CreatePasteRequest) (*pb.CreatePasteResponse, error) {
+	paste := model.Paste{Content: req.Content, UserID: req.UserId, ShortCode: req.Alias}
+	switch {
+	case req.GetExpiresAt() != nil:
+		paste.ExpiresAt = req.GetExpiresAt().AsTime()
+	case req.GetTtl() != nil:
+		paste.ExpiresAt = time.Now().Add(req.GetTtl().AsDuration())
+	default:
+		paste.ExpiresAt = time.Now().Add(defaultPasteTTL)
 	}
 
-	err := repository.StoreObject(paste)
-	if err != nil {
-		return nil, err
+	// Шлюз REST передаёт IP клиента HTTP-запроса; у вызова gRPC берётся адрес соединения.
+	if p, ok := peer.FromContext(ctx); ok && reqctx.ClientIP(ctx) == "" {
+		if host, _, err := net.SplitHostPort(p.Addr.String()); err == nil {
+			ctx = reqctx.WithClientIP(ctx, host)
+		}
 	}
 
-	return &pb.Paste{
-		Id:        paste.ID,
-		Content:   paste.Content,
-		CreatedAt: paste.CreatedAt.Format(time.RFC3339),
+	created, err := s.pastes.CreatePaste(ctx, paste)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return &pb.CreatePasteResponse{
+		Paste:       toPBPaste(created),
+		DeleteToken: created.DeleteToken,
+		ShortCode:   created.ShortCode,
+		ShortUrl:    s.links.ShortURL(linkRequest(ctx), created.ShortCode),
 	}, nil
 }
 
-func (s *Server) GetPaste(ctx context.Context, req *pb.IDRequest) (*pb.Paste, error) {
-	paste, err := repository.GetPasteByID(req.Id)
+func (s *Server) GetPaste(ctx context.Context, req *pb.GetPasteRequest) (*pb.GetPasteResponse, error) {
+	paste, err := s.pastes.GetPasteByID(ctx, req.Id)
 	if err != nil {
-		return nil, err
+		return nil, status.Error(codes.NotFound, err.Error())
 	}
-	return &pb.Paste{
-		Id:        paste.ID,
-		Title:     "",
-		Content:   paste.Content,
-		CreatedAt: paste.CreatedAt.Format(time.RFC3339),
-	}, nil
+	return &pb.GetPasteResponse{Paste: toPBPaste(paste)}, nil
 }
 
-func (s *Server) ListPastes(req *pb.Empty, stream pb.PasteService_ListPastesServer) error {
-	pastes := repository.GetAllPastes()
-	for _, p := range pastes {
-		err := stream.Send(&pb.Paste{
-			Id:        p.ID,
-			Title:     "",
-			Content:   p.Content,
-			CreatedAt: p.CreatedAt.Format(time.RFC3339),
-		})
-		if err != nil {
-			return err
-		}
+func (s *Server) GetPasteByHash(ctx context.Context, req *pb.GetPasteByHashRequest) (*pb.GetPasteByHashResponse, error) {
+	paste, err := s.pastes.GetPasteByHash(ctx, req.Hash)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
 	}
-	return nil
+	return &pb.GetPasteByHashResponse{Paste: toPBPaste(paste)}, nil
 }
 
-func (s *Server) UpdatePaste(ctx context.Context, req *pb.Paste) (*pb.Paste, error) {
-	paste := &model.Paste{
-		ID:      req.Id,
-		Content: req.Content,
+func (s *Server) ListPastes(ctx context.Context, _ *pb.ListPastesRequest) (*pb.ListPastesResponse, error) {
+	pastes, err := s.pastes.ListPastes(ctx)
+	if err != nil {
+		return nil, grpcError(err)
 	}
+	return &pb.ListPastesResponse{Pastes: toPBPastes(pastes)}, nil
+}
 
-	err := repository.UpdatePaste(paste.ID, paste)
+// ListPopularPastes возвращает самые просматриваемые пасты; пасты, удалённые после подсчёта, пропускаются.
+func (s *Server) ListPopularPastes(ctx context.Context, req *pb.ListPopularPastesRequest) (*pb.ListPopularPastesResponse, error) {
+	limit := int(req.Limit)
+	if limit <= 0 {
+		limit = s.popularLimit
+	}
+	top, err := s.stats.ListTopStats(ctx, limit)
 	if err != nil {
-		return nil, err
+		return nil, grpcError(err)
 	}
-
-	return req, nil
+	popular := make([]model.Paste, 0, len(top))
+	for _, st := range top {
+		paste, err := s.pastes.GetPasteByID(ctx, st.ID)
+		if err != nil {
+			slog.WarnContext(ctx, "popular paste not found", "paste_id", st.ID, "error", err)
+			continue
+		}
+		paste.Views = st.Views
+		popular = append(popular, paste)
+	}
+	return &pb.ListPopularPastesResponse{Pastes: toPBPastes(popular)}, nil
 }
 
-func (s *Server) DeletePaste(ctx context.Context, req *pb.IDRequest) (*pb.Status, error) {
-	err := repository.DeletePaste(req.Id)
+func (s *Server) UpdatePaste(ctx context.Context, req *pb.UpdatePasteRequest) (*pb.UpdatePasteResponse, error) {
+	upd, err := pasteUpdate(req.GetPaste(), req.GetUpdateMask())
 	if err != nil {
 		return nil, err
 	}
-	return &pb.Status{Message: "Paste deleted successfully"}, nil
+	paste, err := s.pastes.UpdatePaste(ctx, req.GetPaste().GetId(), upd, fromMetadata(ctx, DeleteTokenKey, req.DeleteToken))
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return &pb.UpdatePasteResponse{Paste: toPBPaste(paste)}, nil
 }
 
-// --- Stats ---
+// pasteUpdate собирает изменение по маске; пустая маска — все заполненные изменяемые поля.
+func pasteUpdate(p *pb.Paste, mask *fieldmaskpb.FieldMask) (model.PasteUpdate, error) {
+	var upd model.PasteUpdate
+	if !mask.IsValid(p) {
+		return upd, status.Errorf(codes.InvalidArgument, "invalid update_mask %v", mask.GetPaths())
+	}
+	paths := mask.GetPaths()
+	if len(paths) == 0 {
+		if p.GetContent() != "" {
+			paths = append(paths, "content")
+		}
+		if p.GetExpiresAt() != nil {
+			paths = append(paths, "expires_at")
+		}
+	}
+	if len(paths) == 0 {
+		return upd, status.Error(codes.InvalidArgument, "nothing to update")
+	}
+
+	for _, path := range paths {
+		switch path {
+		case "content":
+			content := p.GetContent()
+			upd.Content = &content
+		case "expires_at":
+			if p.GetExpiresAt() == nil {
+				return upd, status.Error(codes.InvalidArgument, "expires_at is in update_mask but not set")
+			}
+			expires := p.GetExpiresAt().AsTime()
+			upd.ExpiresAt = &expires
+		default:
+			return upd, status.Errorf(codes.InvalidArgument, "field %q cannot be updated", path)
+		}
+	}
+	return upd, nil
+}
 
-func (s *Server) CreateStats(ctx context.Context, req *pb.Stats) (*pb.Stats, error) {
-	stats := &model.Stats{
-		ID:    req.Id,
-		Views: int(req.Views),
+// DeletePaste с токеном администратора удаляет пасту без токена удаления: так удаляются пасты,
+// созданные до токенов (их delete_token_hash пуст). Исполнителем в журнале аудита записывается admin.
+func (s *Server) DeletePaste(ctx context.Context, req *pb.DeletePasteRequest) (*pb.DeletePasteResponse, error) {
+	var err error
+	if adminAuthorized(ctx, s.adminToken) {
+		reqctx.SetUser(ctx, "admin")
+		err = s.pastes.ForceDeletePaste(ctx, req.Id)
+	} else {
+		err = s.pastes.DeletePaste(ctx, req.Id, fromMetadata(ctx, DeleteTokenKey, req.DeleteToken))
 	}
-	err := repository.StoreObject(stats)
 	if err != nil {
-		return nil, err
+		return nil, grpcError(err)
 	}
-	return &pb.Stats{
-		Id:    stats.ID,
-		Views: int64(stats.Views),
-	}, nil
+	return &pb.DeletePasteResponse{}, nil
 }
 
-func (s *Server) GetStats(ctx context.Context, req *pb.IDRequest) (*pb.Stats, error) {
-	stats, err := repository.GetStatsByID(req.Id)
+// WatchPastes отправляет события живой ленты. Клиент, который не успевает их читать, получает
+// codes.ResourceExhausted и может переподключиться с last_event_id последнего события.
+func (s *Server) WatchPastes(req *pb.WatchPastesRequest, stream pb.PasteService_WatchPastesServer) error {
+	sub, err := s.feed.Subscribe(req.LastEventId)
 	if err != nil {
-		return nil, err
+		return status.Error(codes.Unavailable, err.Error())
 	}
-	return &pb.Stats{
-		Id:    stats.ID,
-		Views: int64(stats.Views),
-	}, nil
-}
+	defer sub.Close()
 
-func (s *Server) ListStats(req *pb.Empty, stream pb.StatsService_ListStatsServer) error {
-	allStats := repository.GetAllStats()
-	for _, st := range allStats {
-		err := stream.Send(&pb.Stats{
-			Id:    st.ID,
-			Views: int64(st.Views),
-		})
-		if err != nil {
+	for _, item := range sub.Replay {
+		if err := stream.Send(toPBPasteEvent(item)); err != nil {
 			return err
 		}
 	}
-	return nil
+	for {
+		select {
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		case item, ok := <-sub.C():
+			if !ok {
+				if errors.Is(sub.Err(), feed.ErrSlowConsumer) {
+					return status.Error(codes.ResourceExhausted, sub.Err().Error())
+				}
+				return status.Error(codes.Unavailable, feed.ErrClosed.Error())
+			}
+			if err := stream.Send(toPBPasteEvent(item)); err != nil {
+				return err
+			}
+		}
+	}
 }
 
-func (s *Server) UpdateStats(ctx context.Context, req *pb.Stats) (*pb.Status, error) {
-	stats := &model.Stats{
-		ID:    req.Id,
-		Views: int(req.Views),
+func toPBPasteEvent(item feed.Item) *pb.WatchPastesResponse {
+	out := &pb.WatchPastesResponse{Id: item.ID, Type: item.Type, At: timestamppb.New(item.At)}
+	if p := item.Paste; p != nil {
+		out.Url = p.URL
+		out.Paste = &pb.Paste{Id: p.ID, Hash: p.Hash, UserId: p.UserID, Size: int64(p.Size)}
+		if !p.CreatedAt.IsZero() {
+			out.Paste.CreatedAt = timestamppb.New(p.CreatedAt)
+			out.Paste.ExpiresAt = timestamppb.New(p.ExpiresAt)
+		}
+	}
+	return out
+}
+
+func toPBPastes(pastes []model.Paste) []*pb.Paste {
+	out := make([]*pb.Paste, 0, len(pastes))
+	for _, p := range pastes {
+		out = append(out, toPBPaste(p))
+	}
+	return out
+}
+
+func toPBPaste(p model.Paste) *pb.Paste {
+	return &pb.Paste{
+		Id:        p.ID,
+		Hash:      p.Hash,
+		Content:   p.Content,
+		CreatedAt: timestamppb.New(p.CreatedAt),
+		ExpiresAt: timestamppb.New(p.ExpiresAt),
+		Views:     int64(p.Views),
+		UserId:    p.UserID,
+		Size:      int64(p.ContentSize()),
 	}
-	err := repository.UpdateStats(stats.ID, stats)
+}
+
+// --- Stats ---
+
+func (s *Server) CreateStats(ctx context.Context, _ *pb.CreateStatsRequest) (*pb.CreateStatsResponse, error) {
+	st, err := s.stats.CreateStats(ctx, model.Stats{})
 	if err != nil {
-		return nil, err
+		return nil, grpcError(err)
 	}
-	return &pb.Status{Message: "Update successful"}, nil
+	return &pb.CreateStatsResponse{Stats: toPBStats(st)}, nil
 }
 
-func (s *Server) DeleteStats(ctx context.Context, req *pb.IDRequest) (*pb.Status, error) {
-	err := repository.DeleteStats(req.Id)
+func (s *Server) GetStats(ctx context.Context, req *pb.GetStatsRequest) (*pb.GetStatsResponse, error) {
+	st, err := s.stats.GetStatsByID(ctx, req.Id)
 	if err != nil {
-		return nil, err
+		return nil, status.Error(codes.NotFound, err.Error())
 	}
-	return &pb.Status{Message: "Delete successful"}, nil
+	return &pb.GetStatsResponse{Stats: toPBStats(st)}, nil
+}
+
+func (s *Server) ListStats(ctx context.Context, _ *pb.ListStatsRequest) (*pb.ListStatsResponse, error) {
+	all, err := s.stats.ListStats(ctx)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	resp := &pb.ListStatsResponse{Stats: make([]*pb.Stats, 0, len(all))}
+	for _, st := range all {
+		resp.Stats = append(resp.Stats, toPBStats(st))
+	}
+	return resp, nil
+}
+
+func (s *Server) DeleteStats(ctx context.Context, req *pb.DeleteStatsRequest) (*pb.DeleteStatsResponse, error) {
+	if err := s.stats.DeleteStats(ctx, req.Id); err != nil {
+		return nil, grpcError(err)
+	}
+	return &pb.DeleteStatsResponse{}, nil
+}
+
+func toPBStats(st model.Stats) *pb.Stats {
+	return &pb.Stats{Id: st.ID, Views: int64(st.Views)}
 }
 
 // --- ShortURL ---
 
-func (s *Server) CreateShortURL(ctx context.Context, req *pb.ShortURL) (*pb.ShortURL, error) {
-	shorturl := &model.ShortURL{
-		ID:       req.Id,
-		Original: req.OriginalUrl,
+// CreateShortURL сокращает внешний адрес или создаёт ссылку на пасту: с alias нужен токен удаления пасты.
+func (s *Server) CreateShortURL(ctx context.Context, req *pb.CreateShortURLRequest) (*pb.CreateShortURLResponse, error) {
+	u := model.ShortURL{ID: req.Alias, Permanent: req.Permanent}
+	if req.GetExpiresAt() != nil {
+		expires := req.GetExpiresAt().AsTime()
+		u.ExpiresAt = &expires
+	}
+
+	var created model.ShortURL
+	var err error
+	switch {
+	case req.GetPasteHash() != "" && req.Alias != "":
+		u.Original = req.GetPasteHash()
+		created, err = s.pastes.CreateAlias(ctx, u, fromMetadata(ctx, DeleteTokenKey, req.DeleteToken))
+	case req.GetPasteHash() != "":
+		u.Original, u.TargetType = req.GetPasteHash(), model.TargetPaste
+		created, err = s.shorts.GenerateShortURL(ctx, u)
+	default:
+		u.Original = req.GetUrl()
+		created, err = s.shorts.ShortenURL(ctx, u)
 	}
-	err := repository.StoreObject(shorturl)
 	if err != nil {
-		return nil, err
+		return nil, grpcError(err)
 	}
-	return &pb.ShortURL{
-		Id:          shorturl.ID,
-		OriginalUrl: shorturl.Original,
-	}, nil
+	return &pb.CreateShortURLResponse{ShortUrl: s.toPBShortURL(ctx, created)}, nil
 }
 
-func (s *Server) GetShortURL(ctx context.Context, req *pb.IDRequest) (*pb.ShortURL, error) {
-	shorturl, err := repository.GetShortURLByID(req.Id)
+func (s *Server) GetShortURL(ctx context.Context, req *pb.GetShortURLRequest) (*pb.GetShortURLResponse, error) {
+	u, err := s.shorts.GetShortURLByID(ctx, req.Id)
 	if err != nil {
-		return nil, err
+		return nil, grpcError(err)
 	}
-	return &pb.ShortURL{
-		Id:          shorturl.ID,
-		OriginalUrl: shorturl.Original,
-	}, nil
+	return &pb.GetShortURLResponse{ShortUrl: s.toPBShortURL(ctx, u)}, nil
 }
 
-func (s *Server) ListShortURLs(req *pb.Empty, stream pb.ShortURLService_ListShortURLsServer) error {
-	allShortURLs := repository.GetAllShortURLs()
-	for _, su := range allShortURLs {
-		err := stream.Send(&pb.ShortURL{
-			Id:          su.ID,
-			OriginalUrl: su.Original,
-		})
-		if err != nil {
-			return err
-		}
+func (s *Server) ListShortURLs(ctx context.Context, _ *pb.ListShortURLsRequest) (*pb.ListShortURLsResponse, error) {
+	urls, err := s.shorts.ListShortURLs(ctx)
+	if err != nil {
+		return nil, grpcError(err)
 	}
-	return nil
+	resp := &pb.ListShortURLsResponse{ShortUrls: make([]*pb.ShortURL, 0, len(urls))}
+	for _, u := range urls {
+		resp.ShortUrls = append(resp.ShortUrls, s.toPBShortURL(ctx, u))
+	}
+	return resp, nil
 }
 
-func (s *Server) UpdateShortURL(ctx context.Context, req *pb.ShortURL) (*pb.Status, error) {
-	shorturl := &model.ShortURL{
-		ID:       req.Id,
-		Original: req.OriginalUrl,
+// DeleteShortURL с токеном администратора удаляет ссылку без токена удаления; исполнителем
+// в журнале аудита записывается admin.
+func (s *Server) DeleteShortURL(ctx context.Context, req *pb.DeleteShortURLRequest) (*pb.DeleteShortURLResponse, error) {
+	var err error
+	if adminAuthorized(ctx, s.adminToken) {
+		reqctx.SetUser(ctx, "admin")
+		err = s.shorts.ForceDeleteShortURL(ctx, req.Id)
+	} else {
+		err = s.shorts.DeleteShortURL(ctx, req.Id, fromMetadata(ctx, DeleteTokenKey, req.DeleteToken))
 	}
-	err := repository.UpdateShortURL(shorturl.ID, shorturl)
 	if err != nil {
-		return nil, err
+		return nil, grpcError(err)
 	}
-	return &pb.Status{Message: "Update successful"}, nil
+	return &pb.DeleteShortURLResponse{}, nil
 }
 
-func (s *Server) DeleteShortURL(ctx context.Context, req *pb.IDRequest) (*pb.Status, error) {
-	err := repository.DeleteShortURL(req.Id)
-	if err != nil {
-		return nil, err
+func (s *Server) toPBShortURL(ctx context.Context, u model.ShortURL) *pb.ShortURL {
+	out := &pb.ShortURL{
+		Id:          u.ID,
+		Original:    u.Original,
+		TargetType:  u.TargetType,
+		Permanent:   u.Permanent,
+		Link:        s.links.ShortURL(linkRequest(ctx), u.ID),
+		DeleteToken: u.DeleteToken,
+	}
+	if u.ExpiresAt != nil {
+		out.ExpiresAt = timestamppb.New(*u.ExpiresAt)
+	}
+	if u.DeletedAt != nil {
+		out.DeletedAt = timestamppb.New(*u.DeletedAt)
+	}
+	return out
+}
+
+// Ключи метаданных с секретами. Шлюз REST передаёт в них одноимённые заголовки HTTP (X-Delete-Token и т. д.).
+const (
+	DeleteTokenKey   = "x-delete-token"
+	WebhookSecretKey = "x-webhook-secret"
+	AdminTokenKey    = "x-admin-token"
+)
+
+// Ключи метаданных с заголовками X-Forwarded-Host и X-Forwarded-Proto запроса к шлюзу REST.
+// Шлюз не берёт их из заголовков Grpc-Metadata-*, поэтому клиент не может подставить их в обход прокси.
+const (
+	ForwardedHostKey  = "x-pastebin-forwarded-host"
+	ForwardedProtoKey = "x-pastebin-forwarded-proto"
+)
+
+// linkRequest восстанавливает для links.Builder запрос, из которого пришёл вызов: адрес клиента
+// и X-Forwarded-Host/Proto из метаданных. Принимать ли заголовки, решает Builder по адресу прокси.
+func linkRequest(ctx context.Context) *http.Request {
+	r := &http.Request{Header: http.Header{}}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		r.RemoteAddr = p.Addr.String()
+	}
+	if host := fromMetadata(ctx, ForwardedHostKey, ""); host != "" {
+		r.Header.Set("X-Forwarded-Host", host)
+	}
+	if proto := fromMetadata(ctx, ForwardedProtoKey, ""); proto != "" {
+		r.Header.Set("X-Forwarded-Proto", proto)
+	}
+	return r
+}
+
+// fromMetadata возвращает value, а если оно пусто — значение ключа key из метаданных вызова.
+func fromMetadata(ctx context.Context, key, value string) string {
+	if value != "" {
+		return value
+	}
+	if vals := metadata.ValueFromIncomingContext(ctx, key); len(vals) > 0 {
+		return vals[0]
+	}
+	return ""
+}
+
+// ReasonGone — причина в google.rpc.ErrorInfo у NotFound для удалённых и истёкших сущностей.
+// В gRPC нет отдельного кода для 410, поэтому шлюз REST отвечает 410 по этой причине.
+const ReasonGone = "GONE"
+
+// GoneError — NotFound с причиной ReasonGone.
+func GoneError(err error) error {
+	st, detailErr := status.New(codes.NotFound, err.Error()).
+		WithDetails(&errdetails.ErrorInfo{Reason: ReasonGone, Domain: "pastebin.v1"})
+	if detailErr != nil {
+		return status.Error(codes.NotFound, err.Error())
+	}
+	return st.Err()
+}
+
+// IsGone сообщает, что ошибка вызова означает удалённую или истёкшую сущность.
+func IsGone(err error) bool {
+	for _, d := range status.Convert(err).Details() {
+		if info, ok := d.(*errdetails.ErrorInfo); ok && info.Reason == ReasonGone {
+			return true
+		}
+	}
+	return false
+}
+
+// grpcError переводит ошибки сервисов в коды gRPC; шлюз REST переводит коды в статусы HTTP.
+func grpcError(err error) error {
+	switch {
+	case errors.Is(err, service.ErrInvalidAlias), errors.Is(err, service.ErrInvalidURL),
+		errors.Is(err, service.ErrInvalidExpiration), errors.Is(err, service.ErrInvalidBucket),
+		errors.Is(err, service.ErrInvalidAuditFilter), errors.Is(err, service.ErrInvalidWebhook):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, service.ErrInvalidDeleteToken), errors.Is(err, service.ErrInvalidWebhookSecret):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, service.ErrOwnerUnauthenticated):
+		return status.Error(codes.Unauthenticated, err.Error())
+	case errors.Is(err, service.ErrPasteNotFound), errors.Is(err, service.ErrShortURLNotFound),
+		errors.Is(err, service.ErrWebhookNotFound), errors.Is(err, service.ErrUserNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, service.ErrShortURLGone):
+		return GoneError(err)
+	case errors.Is(err, service.ErrShortCodeTaken):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case service.IsQuotaError(err):
+		return status.Error(codes.ResourceExhausted, err.Error())
+	default:
+		return err
 	}
-	return &pb.Status{Message: "Delete successful"}, nil
 }
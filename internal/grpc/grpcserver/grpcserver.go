@@ -0,0 +1,117 @@
+// Package grpcserver собирает gRPC-сервер с общей цепочкой перехватчиков для монолита
+// и тестового сервера internal/cmd/server.
+package grpcserver
+
+import (
+	"crypto/tls"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/keepalive"
+
+	"github.com/GritsyukLeonid/pastebin-go/internal/config"
+	"github.com/GritsyukLeonid/pastebin-go/internal/logging"
+	"github.com/GritsyukLeonid/pastebin-go/internal/metrics"
+	"github.com/GritsyukLeonid/pastebin-go/internal/ratelimit"
+	"github.com/GritsyukLeonid/pastebin-go/internal/tracing"
+)
+
+type Config struct {
+	// MaxRecvMsgBytes и MaxSendMsgBytes — предельный размер входящего и исходящего сообщения.
+	MaxRecvMsgBytes int
+	MaxSendMsgBytes int
+	// MaxDeadline — наибольший срок унарного вызова: вызов без срока или с более долгим получает его.
+	// Потоки (WatchPastes) не ограничиваются. 0 — не ограничивать.
+	MaxDeadline time.Duration
+	// KeepaliveTime — через сколько простоя сервер проверяет соединение пингом,
+	// KeepaliveTimeout — сколько ждёт ответа на пинг.
+	KeepaliveTime    time.Duration
+	KeepaliveTimeout time.Duration
+	// KeepaliveMinTime — как часто клиентам можно присылать пинги; чаще — соединение закрывается.
+	KeepaliveMinTime time.Duration
+	// APIKeys — ключи клиентов в x-api-key или authorization: Bearer; пусто — аутентификация не требуется.
+	APIKeys []string
+	// TLS — конфигурация листенера (internal/tlsconfig); nil — без шифрования.
+	TLS *tls.Config
+	// ClientIdentities сопоставляет субъект клиентского сертификата (полностью, "CN=billing,O=Acme",
+	// или только CN) имени сервиса. Вызов с таким сертификатом не требует API-ключа.
+	ClientIdentities map[string]string
+}
+
+func DefaultConfig() Config {
+	return Config{
+		MaxRecvMsgBytes:  4 << 20,
+		MaxSendMsgBytes:  16 << 20,
+		MaxDeadline:      30 * time.Second,
+		KeepaliveTime:    2 * time.Minute,
+		KeepaliveTimeout: 20 * time.Second,
+		KeepaliveMinTime: 30 * time.Second,
+	}
+}
+
+// ConfigFrom переводит настройки из config; TLS задаётся отдельно.
+func ConfigFrom(c config.GRPCConfig) Config {
+	return Config{
+		MaxRecvMsgBytes:  c.MaxRecvMsgBytes,
+		MaxSendMsgBytes:  c.MaxSendMsgBytes,
+		MaxDeadline:      time.Duration(c.MaxDeadline),
+		KeepaliveTime:    time.Duration(c.KeepaliveTime),
+		KeepaliveTimeout: time.Duration(c.KeepaliveTimeout),
+		KeepaliveMinTime: time.Duration(c.KeepaliveMinTime),
+		APIKeys:          c.APIKeys,
+		ClientIdentities: c.ClientIdentities,
+	}
+}
+
+// New создаёт сервер с трассировкой, ограничениями размера сообщений, keepalive и цепочкой
+// перехватчиков: журнал и метрики видят итоговый код вызова, в том числе после паники;
+// затем аутентификация, лимиты запросов, срок вызова и проверка запроса.
+func New(cfg Config, limiter *ratelimit.Middleware) *grpc.Server {
+	unary, stream := interceptors(cfg, limiter)
+	opts := []grpc.ServerOption{
+		tracing.GRPCServerOption(),
+		grpc.MaxRecvMsgSize(cfg.MaxRecvMsgBytes),
+		grpc.MaxSendMsgSize(cfg.MaxSendMsgBytes),
+		grpc.KeepaliveParams(keepalive.ServerParameters{
+			Time:    cfg.KeepaliveTime,
+			Timeout: cfg.KeepaliveTimeout,
+		}),
+		grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+			MinTime:             cfg.KeepaliveMinTime,
+			PermitWithoutStream: true,
+		}),
+		grpc.ChainUnaryInterceptor(append([]grpc.UnaryServerInterceptor{
+			logging.UnaryServerInterceptor(),
+			metrics.UnaryServerInterceptor(),
+		}, unary...)...),
+		grpc.ChainStreamInterceptor(append([]grpc.StreamServerInterceptor{
+			logging.StreamServerInterceptor(),
+			metrics.StreamServerInterceptor(),
+		}, stream...)...),
+	}
+	if cfg.TLS != nil {
+		opts = append(opts, grpc.Creds(credentials.NewTLS(cfg.TLS)))
+	}
+	return grpc.NewServer(opts...)
+}
+
+// interceptors — общая часть цепочки сервера и Local: восстановление после паники,
+// аутентификация, лимиты запросов, срок вызова и проверка запроса.
+func interceptors(cfg Config, limiter *ratelimit.Middleware) ([]grpc.UnaryServerInterceptor, []grpc.StreamServerInterceptor) {
+	auth := NewAuthenticator(cfg.APIKeys, cfg.ClientIdentities)
+	unary := []grpc.UnaryServerInterceptor{
+		RecoveryUnaryInterceptor(),
+		auth.UnaryServerInterceptor(),
+		limiter.UnaryServerInterceptor(),
+		DeadlineUnaryInterceptor(cfg.MaxDeadline),
+		ValidationUnaryInterceptor(),
+	}
+	stream := []grpc.StreamServerInterceptor{
+		RecoveryStreamInterceptor(),
+		auth.StreamServerInterceptor(),
+		limiter.StreamServerInterceptor(),
+		ValidationStreamInterceptor(),
+	}
+	return unary, stream
+}
@@ -0,0 +1,199 @@
+package grpcserver
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	pb "github.com/GritsyukLeonid/pastebin-go/internal/pb/pastebin/v1"
+	"github.com/GritsyukLeonid/pastebin-go/internal/ratelimit"
+	"github.com/GritsyukLeonid/pastebin-go/internal/reqctx"
+)
+
+type stubPastes struct {
+	pb.UnimplementedPasteServiceServer
+
+	created  int
+	user     string
+	deadline time.Duration
+}
+
+func (s *stubPastes) CreatePaste(ctx context.Context, _ *pb.CreatePasteRequest) (*pb.CreatePasteResponse, error) {
+	s.created++
+	s.user = reqctx.User(ctx)
+	return &pb.CreatePasteResponse{Paste: &pb.Paste{Id: "1"}}, nil
+}
+
+func (s *stubPastes) GetPaste(ctx context.Context, req *pb.GetPasteRequest) (*pb.GetPasteResponse, error) {
+	if req.Id == "panic" {
+		panic("boom")
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		s.deadline = time.Until(deadline)
+	}
+	return &pb.GetPasteResponse{Paste: &pb.Paste{Id: req.Id}}, nil
+}
+
+func startServer(t *testing.T, cfg Config, pastes *stubPastes) *grpc.ClientConn {
+	t.Helper()
+	srv := New(cfg, ratelimit.NewMiddleware(ratelimit.NewMemoryLimiter(), ratelimit.Policy{}))
+	pb.RegisterPasteServiceServer(srv, pastes)
+	healthpb.RegisterHealthServer(srv, grpchealth.NewServer())
+
+	lis := bufconn.Listen(1 << 20)
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func TestValidationRejectsBeforeHandler(t *testing.T) {
+	pastes := &stubPastes{}
+	client := pb.NewPasteServiceClient(startServer(t, DefaultConfig(), pastes))
+
+	_, err := client.CreatePaste(context.Background(), &pb.CreatePasteRequest{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = client.GetPaste(context.Background(), &pb.GetPasteRequest{Id: "../etc"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Zero(t, pastes.created)
+
+	_, err = client.CreatePaste(context.Background(), &pb.CreatePasteRequest{Content: "hello"})
+	assert.NoError(t, err)
+}
+
+func TestPanicBecomesInternal(t *testing.T) {
+	client := pb.NewPasteServiceClient(startServer(t, DefaultConfig(), &stubPastes{}))
+
+	_, err := client.GetPaste(context.Background(), &pb.GetPasteRequest{Id: "panic"})
+	assert.Equal(t, codes.Internal, status.Code(err))
+
+	_, err = client.GetPaste(context.Background(), &pb.GetPasteRequest{Id: "1"})
+	assert.NoError(t, err, "сервер продолжает работать после паники")
+}
+
+func TestAuthRequiresAPIKey(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.APIKeys = []string{"secret"}
+	pastes := &stubPastes{}
+	conn := startServer(t, cfg, pastes)
+	client := pb.NewPasteServiceClient(conn)
+	req := &pb.CreatePasteRequest{Content: "hello"}
+
+	_, err := client.CreatePaste(context.Background(), req)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	_, err = client.CreatePaste(metadata.AppendToOutgoingContext(context.Background(), "x-api-key", "wrong"), req)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	assert.Zero(t, pastes.created)
+
+	_, err = client.CreatePaste(metadata.AppendToOutgoingContext(context.Background(), "x-api-key", "secret"), req)
+	require.NoError(t, err)
+	assert.Equal(t, reqctx.APIKeyID("secret"), pastes.user)
+	_, err = client.CreatePaste(metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer secret"), req)
+	assert.NoError(t, err)
+
+	_, err = healthpb.NewHealthClient(conn).Check(context.Background(), &healthpb.HealthCheckRequest{})
+	assert.NoError(t, err, "пробы не требуют ключа")
+}
+
+// withClientCert — контекст вызова по mTLS с проверенным сертификатом subject.
+func withClientCert(subject pkix.Name) context.Context {
+	ctx := reqctx.WithUser(context.Background(), "")
+	state := tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{{Subject: subject}}}}
+	return peer.NewContext(ctx, &peer.Peer{AuthInfo: credentials.TLSInfo{State: state}})
+}
+
+func TestClientCertificateIdentity(t *testing.T) {
+	auth := NewAuthenticator([]string{"secret"}, map[string]string{
+		"CN=billing,O=Acme": "billing",
+		"reports":           "reports",
+	})
+	const method = "/pastebin.v1.PasteService/CreatePaste"
+
+	ctx := withClientCert(pkix.Name{CommonName: "billing", Organization: []string{"Acme"}})
+	require.NoError(t, auth.authenticate(ctx, method))
+	assert.Equal(t, "service:billing", reqctx.User(ctx))
+
+	ctx = withClientCert(pkix.Name{CommonName: "reports", Organization: []string{"Other"}})
+	require.NoError(t, auth.authenticate(ctx, method), "достаточно совпадения CN")
+	assert.Equal(t, "service:reports", reqctx.User(ctx))
+
+	ctx = withClientCert(pkix.Name{CommonName: "unknown"})
+	assert.Equal(t, codes.Unauthenticated, status.Code(auth.authenticate(ctx, method)), "неизвестный сертификат требует ключа")
+}
+
+func TestDeadlineIsCapped(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.MaxDeadline = time.Second
+	pastes := &stubPastes{}
+	client := pb.NewPasteServiceClient(startServer(t, cfg, pastes))
+
+	_, err := client.GetPaste(context.Background(), &pb.GetPasteRequest{Id: "1"})
+	require.NoError(t, err)
+	assert.InDelta(t, time.Second, pastes.deadline, float64(100*time.Millisecond), "вызов без срока получает max_deadline")
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Hour)
+	defer cancel()
+	_, err = client.GetPaste(ctx, &pb.GetPasteRequest{Id: "1"})
+	require.NoError(t, err)
+	assert.LessOrEqual(t, pastes.deadline, time.Second)
+}
+
+func TestMessageSizeLimit(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.MaxRecvMsgBytes = 1024
+	pastes := &stubPastes{}
+	client := pb.NewPasteServiceClient(startServer(t, cfg, pastes))
+
+	_, err := client.CreatePaste(context.Background(), &pb.CreatePasteRequest{Content: strings.Repeat("a", 2048)})
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	assert.Zero(t, pastes.created)
+}
+
+func TestLocalRunsChain(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.APIKeys = []string{"secret"}
+	pastes := &stubPastes{}
+	local := NewLocal(cfg, ratelimit.NewMiddleware(ratelimit.NewMemoryLimiter(), ratelimit.Policy{Write: ratelimit.PerMinute(60, 5)}))
+	pb.RegisterPasteServiceServer(local, pastes)
+	client := pb.NewPasteServiceClient(local)
+
+	_, err := client.CreatePaste(reqctx.WithUser(context.Background(), ""), &pb.CreatePasteRequest{Content: "hello"})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	ctx := metadata.AppendToOutgoingContext(reqctx.WithUser(context.Background(), ""), "x-api-key", "secret")
+	_, err = client.CreatePaste(ctx, &pb.CreatePasteRequest{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Zero(t, pastes.created)
+
+	var header metadata.MD
+	_, err = client.CreatePaste(ctx, &pb.CreatePasteRequest{Content: "hello"}, grpc.Header(&header))
+	require.NoError(t, err)
+	assert.Equal(t, reqctx.APIKeyID("secret"), pastes.user)
+	assert.Equal(t, []string{"5"}, header.Get("x-ratelimit-limit"))
+
+	_, err = pb.NewUserServiceClient(local).GetUser(ctx, &pb.GetUserRequest{Id: 1})
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+}
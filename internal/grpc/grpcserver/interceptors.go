@@ -0,0 +1,202 @@
+package grpcserver
+
+import (
+	"context"
+	"crypto/subtle"
+	"log/slog"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/GritsyukLeonid/pastebin-go/internal/reqctx"
+)
+
+// healthPrefix — методы проб оркестратора: им не нужны ключи клиентов.
+const healthPrefix = "/grpc.health.v1.Health/"
+
+// RecoveryUnaryInterceptor превращает панику обработчика в codes.Internal и пишет стек в журнал,
+// чтобы одна ошибка в обработчике не останавливала весь сервер.
+func RecoveryUnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+		defer recoverPanic(ctx, info.FullMethod, &err)
+		return handler(ctx, req)
+	}
+}
+
+func RecoveryStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		defer recoverPanic(ss.Context(), info.FullMethod, &err)
+		return handler(srv, ss)
+	}
+}
+
+func recoverPanic(ctx context.Context, method string, err *error) {
+	if r := recover(); r != nil {
+		slog.ErrorContext(ctx, "grpc handler panicked", "method", method, "panic", r, "stack", string(debug.Stack()))
+		*err = status.Error(codes.Internal, "internal error")
+	}
+}
+
+// Authenticator определяет клиента по сертификату mTLS из identities или по ключу
+// из метаданных x-api-key или authorization: Bearer.
+type Authenticator struct {
+	keys       [][]byte
+	identities map[string]string
+}
+
+// NewAuthenticator создаёт проверку по списку ключей и сертификатов сервисов; без ключей вызовы
+// без известного сертификата пропускаются анонимно.
+func NewAuthenticator(keys []string, identities map[string]string) *Authenticator {
+	a := &Authenticator{identities: identities}
+	for _, k := range keys {
+		if k != "" {
+			a.keys = append(a.keys, []byte(k))
+		}
+	}
+	return a
+}
+
+func (a *Authenticator) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if err := a.authenticate(ctx, info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+func (a *Authenticator) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := a.authenticate(ss.Context(), info.FullMethod); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
+
+// authenticate записывает исполнителем вызова сервис (service:<имя>) или обезличенный ключ:
+// их видят журнал аудита и журнал запросов.
+func (a *Authenticator) authenticate(ctx context.Context, fullMethod string) error {
+	if service := a.serviceIdentity(ctx); service != "" {
+		reqctx.SetUser(ctx, "service:"+service)
+		return nil
+	}
+	if len(a.keys) == 0 || strings.HasPrefix(fullMethod, healthPrefix) {
+		return nil
+	}
+	key := apiKey(ctx)
+	if key == "" {
+		return status.Error(codes.Unauthenticated, "api key is required")
+	}
+	if !a.valid(key) {
+		return status.Error(codes.Unauthenticated, "invalid api key")
+	}
+	reqctx.SetUser(ctx, reqctx.APIKeyID(key))
+	return nil
+}
+
+// valid сравнивает ключ со всеми известными за постоянное время.
+func (a *Authenticator) valid(key string) bool {
+	ok := 0
+	for _, k := range a.keys {
+		ok |= subtle.ConstantTimeCompare([]byte(key), k)
+	}
+	return ok == 1
+}
+
+// serviceIdentity ищет субъект проверенного клиентского сертификата в identities.
+func (a *Authenticator) serviceIdentity(ctx context.Context) string {
+	if len(a.identities) == 0 {
+		return ""
+	}
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return ""
+	}
+	info, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(info.State.VerifiedChains) == 0 || len(info.State.VerifiedChains[0]) == 0 {
+		return ""
+	}
+	subject := info.State.VerifiedChains[0][0].Subject
+	if id, ok := a.identities[subject.String()]; ok {
+		return id
+	}
+	return a.identities[subject.CommonName]
+}
+
+func apiKey(ctx context.Context) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if keys := md.Get("x-api-key"); len(keys) > 0 && keys[0] != "" {
+		return keys[0]
+	}
+	for _, v := range md.Get("authorization") {
+		if token, ok := strings.CutPrefix(v, "Bearer "); ok {
+			return strings.TrimSpace(token)
+		}
+	}
+	return ""
+}
+
+// DeadlineUnaryInterceptor ограничивает срок унарного вызова значением limit; 0 — без ограничения.
+func DeadlineUnaryInterceptor(limit time.Duration) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if limit <= 0 {
+			return handler(ctx, req)
+		}
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) <= limit {
+			return handler(ctx, req)
+		}
+		ctx, cancel := context.WithTimeout(ctx, limit)
+		defer cancel()
+		return handler(ctx, req)
+	}
+}
+
+// validator реализуют запросы pastebin.v1 (internal/pb/pastebin/v1/validate.go).
+type validator interface {
+	Validate() error
+}
+
+// ValidationUnaryInterceptor отклоняет запрос с codes.InvalidArgument, если его Validate вернул ошибку.
+func ValidationUnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if err := validate(req); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// ValidationStreamInterceptor проверяет каждое сообщение, которое обработчик потока получает от клиента.
+func ValidationStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &validatingStream{ServerStream: ss})
+	}
+}
+
+type validatingStream struct {
+	grpc.ServerStream
+}
+
+func (s *validatingStream) RecvMsg(m any) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	return validate(m)
+}
+
+func validate(req any) error {
+	if v, ok := req.(validator); ok {
+		if err := v.Validate(); err != nil {
+			return status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+	return nil
+}
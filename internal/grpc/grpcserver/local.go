@@ -0,0 +1,132 @@
+package grpcserver
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/GritsyukLeonid/pastebin-go/internal/ratelimit"
+)
+
+// Local вызывает сервисы в том же процессе через ту же цепочку, что и сервер из New, но без журнала
+// и метрик gRPC: для шлюза REST их ведут middleware HTTP. Контекст вызова не теряется, поэтому
+// исполнитель, request ID и IP клиента остаются общими с HTTP-запросом.
+//
+// Сервисы регистрируются функциями pb.Register<Service>Server, клиенты создаются pb.New<Service>Client.
+// Поддерживаются только унарные методы.
+type Local struct {
+	unary    grpc.UnaryServerInterceptor
+	services map[string]localService
+}
+
+type localService struct {
+	impl    any
+	methods map[string]grpc.MethodDesc
+}
+
+var (
+	_ grpc.ServiceRegistrar    = (*Local)(nil)
+	_ grpc.ClientConnInterface = (*Local)(nil)
+)
+
+func NewLocal(cfg Config, limiter *ratelimit.Middleware) *Local {
+	unary, _ := interceptors(cfg, limiter)
+	return &Local{unary: chainUnary(unary), services: map[string]localService{}}
+}
+
+func (l *Local) RegisterService(desc *grpc.ServiceDesc, impl any) {
+	methods := make(map[string]grpc.MethodDesc, len(desc.Methods))
+	for _, m := range desc.Methods {
+		methods[m.MethodName] = m
+	}
+	l.services[desc.ServiceName] = localService{impl: impl, methods: methods}
+}
+
+// Invoke передаёт исходящие метаданные обработчику как входящие, а заголовки, которые выставили
+// перехватчики (X-RateLimit-*), возвращает через grpc.Header.
+func (l *Local) Invoke(ctx context.Context, method string, args, reply any, opts ...grpc.CallOption) error {
+	service, name, _ := strings.Cut(strings.TrimPrefix(method, "/"), "/")
+	svc, ok := l.services[service]
+	desc, found := svc.methods[name]
+	if !ok || !found {
+		return status.Errorf(codes.Unimplemented, "unknown method %s", method)
+	}
+
+	md, _ := metadata.FromOutgoingContext(ctx)
+	ctx = metadata.NewIncomingContext(ctx, md)
+	stream := &localStream{method: method}
+	ctx = grpc.NewContextWithServerTransportStream(ctx, stream)
+
+	resp, err := desc.Handler(svc.impl, ctx, func(in any) error {
+		proto.Merge(in.(proto.Message), args.(proto.Message))
+		return nil
+	}, l.unary)
+	for _, o := range opts {
+		switch o := o.(type) {
+		case grpc.HeaderCallOption:
+			*o.HeaderAddr = stream.header
+		case grpc.TrailerCallOption:
+			*o.TrailerAddr = stream.trailer
+		}
+	}
+	if err != nil {
+		return err
+	}
+	proto.Merge(reply.(proto.Message), resp.(proto.Message))
+	return nil
+}
+
+func (l *Local) NewStream(_ context.Context, _ *grpc.StreamDesc, method string, _ ...grpc.CallOption) (grpc.ClientStream, error) {
+	return nil, status.Errorf(codes.Unimplemented, "streaming method %s is not available in-process", method)
+}
+
+// chainUnary собирает перехватчики в один: первый в списке вызывается первым.
+func chainUnary(chain []grpc.UnaryServerInterceptor) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		next := handler
+		for i := len(chain) - 1; i >= 0; i-- {
+			interceptor, h := chain[i], next
+			next = func(ctx context.Context, req any) (any, error) {
+				return interceptor(ctx, req, info, h)
+			}
+		}
+		return next(ctx, req)
+	}
+}
+
+// localStream принимает заголовки и трейлеры, которые обработчик задаёт через grpc.SetHeader и grpc.SetTrailer.
+type localStream struct {
+	method string
+
+	mu      sync.Mutex
+	header  metadata.MD
+	trailer metadata.MD
+}
+
+func (s *localStream) Method() string {
+	return s.method
+}
+
+func (s *localStream) SetHeader(md metadata.MD) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.header = metadata.Join(s.header, md)
+	return nil
+}
+
+func (s *localStream) SendHeader(md metadata.MD) error {
+	return s.SetHeader(md)
+}
+
+func (s *localStream) SetTrailer(md metadata.MD) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.trailer = metadata.Join(s.trailer, md)
+	return nil
+}
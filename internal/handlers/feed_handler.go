@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/GritsyukLeonid/pastebin-go/internal/feed"
+)
+
+type FeedHandler struct {
+	hub       *feed.Hub
+	heartbeat time.Duration
+}
+
+// NewFeedHandler создаёт обработчик живой ленты; раз в heartbeat в поток пишется комментарий,
+// чтобы прокси не закрывали простаивающее соединение.
+func NewFeedHandler(hub *feed.Hub, heartbeat time.Duration) *FeedHandler {
+	return &FeedHandler{hub: hub, heartbeat: heartbeat}
+}
+
+// StreamPastesHandler отдаёт живую ленту паст потоком Server-Sent Events (GET /api/v1/paste/stream).
+// ID последнего полученного события передаётся в Last-Event-ID или ?lastEventId=; если продолжить
+// с него нельзя, первым приходит feed.reset.
+func (h *FeedHandler) StreamPastesHandler(w http.ResponseWriter, r *http.Request) {
+	lastID := r.Header.Get("Last-Event-ID")
+	if lastID == "" {
+		lastID = r.URL.Query().Get("lastEventId")
+	}
+	sub, err := h.hub.Subscribe(lastID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+	defer sub.Close()
+
+	rc := http.NewResponseController(w)
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	for _, item := range sub.Replay {
+		if err := writeSSE(w, item); err != nil {
+			return
+		}
+	}
+	if err := rc.Flush(); err != nil {
+		return
+	}
+
+	heartbeat := time.NewTicker(h.heartbeat)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": ping\n\n"); err != nil {
+				return
+			}
+		case item, ok := <-sub.C():
+			if !ok {
+				if err := sub.Err(); errors.Is(err, feed.ErrSlowConsumer) {
+					slog.WarnContext(r.Context(), "feed subscriber disconnected", "error", err)
+				}
+				return
+			}
+			if err := writeSSE(w, item); err != nil {
+				return
+			}
+		}
+		if err := rc.Flush(); err != nil {
+			return
+		}
+	}
+}
+
+func writeSSE(w http.ResponseWriter, item feed.Item) error {
+	data, err := json.Marshal(item)
+	if err != nil {
+		return err
+	}
+	if item.ID != "" {
+		if _, err := fmt.Fprintf(w, "id: %s\n", item.ID); err != nil {
+			return err
+		}
+	}
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", item.Type, data)
+	return err
+}
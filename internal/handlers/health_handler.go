@@ -0,0 +1,35 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/GritsyukLeonid/pastebin-go/internal/health"
+)
+
+type HealthHandler struct {
+	checker *health.Checker
+}
+
+func NewHealthHandler(checker *health.Checker) *HealthHandler {
+	return &HealthHandler{checker: checker}
+}
+
+// LivenessHandler отвечает 200, пока процесс работает; зависимости не проверяются.
+func (h *HealthHandler) LivenessHandler(w http.ResponseWriter, r *http.Request) {
+	writeHealth(w, health.Report{Status: health.StatusUp, Checks: map[string]health.Result{}})
+}
+
+// ReadinessHandler проверяет зависимости и возвращает статус каждой; 503 — хотя бы одна недоступна.
+func (h *HealthHandler) ReadinessHandler(w http.ResponseWriter, r *http.Request) {
+	writeHealth(w, h.checker.Run(r.Context()))
+}
+
+func writeHealth(w http.ResponseWriter, report health.Report) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	if !report.Healthy() {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	json.NewEncoder(w).Encode(report)
+}
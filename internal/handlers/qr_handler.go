@@ -0,0 +1,126 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/GritsyukLeonid/pastebin-go/internal/model"
+	"github.com/GritsyukLeonid/pastebin-go/internal/qr"
+	"github.com/GritsyukLeonid/pastebin-go/internal/service"
+)
+
+const qrCacheSize = 1024
+
+// Срок кэширования QR-кода клиентом: бессрочные ссылки на внешний адрес кэшируются публично на сутки,
+// остальные — только в браузере и не дольше qrShortMaxAge и оставшегося срока жизни ссылки.
+const (
+	qrMaxAge      = 24 * time.Hour
+	qrShortMaxAge = 5 * time.Minute
+)
+
+// ShortURLQRHandler отдаёт QR-код полного адреса короткой ссылки в PNG или SVG (GET /s/{code}/qr).
+// Параметры: format — png или svg, size — сторона в пикселях (64–2048), margin — тихая зона в модулях (0–16),
+// level — уровень коррекции ошибок L, M, Q или H. Изображения кэшируются.
+func (h *ShortURLHandler) ShortURLQRHandler(w http.ResponseWriter, r *http.Request) {
+	code := mux.Vars(r)["code"]
+
+	short, err := h.service.GetShortURLByID(r.Context(), code)
+	if errors.Is(err, service.ErrShortURLGone) {
+		http.Error(w, "Ссылка больше не действует", http.StatusGone)
+		return
+	}
+	if err != nil {
+		http.Error(w, "ShortURL не найден", http.StatusNotFound)
+		return
+	}
+
+	h.writeQR(w, r, h.links.ShortURL(r, short.ID), qrCacheControl(short, nil, time.Now()))
+}
+
+// PasteQRHandler отдаёт QR-код короткой ссылки пасты (GET /api/v1/paste/{id}/qr); параметры те же,
+// что у ShortURLQRHandler.
+func (h *ShortURLHandler) PasteQRHandler(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	paste, err := h.pasteService.GetPasteByID(r.Context(), id)
+	if err != nil {
+		http.Error(w, "Паста не найдена", http.StatusNotFound)
+		return
+	}
+	short, err := h.service.GetShortURLForTarget(r.Context(), paste.Hash)
+	if err != nil {
+		http.Error(w, "У пасты нет короткой ссылки", http.StatusNotFound)
+		return
+	}
+
+	h.writeQR(w, r, h.links.ShortURL(r, short.ID), qrCacheControl(short, &paste.ExpiresAt, time.Now()))
+}
+
+func (h *ShortURLHandler) writeQR(w http.ResponseWriter, r *http.Request, content, cacheControl string) {
+	opts, err := qrOptionsFromRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	img, err := h.qrCache.Get(content, opts)
+	if errors.Is(err, qr.ErrInvalidOptions) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", opts.ContentType())
+	w.Header().Set("Cache-Control", cacheControl)
+	w.Write(img)
+}
+
+// qrCacheControl не даёт кэшу пережить ссылку: ссылка на пасту исчезает вместе с ней, поэтому
+// targetExpiresAt — срок пасты, если он известен.
+func qrCacheControl(short model.ShortURL, targetExpiresAt *time.Time, now time.Time) string {
+	if short.IsURL() && short.ExpiresAt == nil {
+		return fmt.Sprintf("public, max-age=%d", int(qrMaxAge.Seconds()))
+	}
+	maxAge := qrShortMaxAge
+	for _, expiresAt := range []*time.Time{short.ExpiresAt, targetExpiresAt} {
+		if expiresAt != nil && expiresAt.Sub(now) < maxAge {
+			maxAge = max(expiresAt.Sub(now), 0)
+		}
+	}
+	return fmt.Sprintf("private, max-age=%d", int(maxAge.Seconds()))
+}
+
+func qrOptionsFromRequest(r *http.Request) (qr.Options, error) {
+	opts := qr.DefaultOptions()
+	q := r.URL.Query()
+
+	if v := q.Get("format"); v != "" {
+		opts.Format = v
+	}
+	if v := q.Get("level"); v != "" {
+		opts.Level = v
+	}
+	if v := q.Get("size"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return qr.Options{}, errors.New("некорректный size")
+		}
+		opts.Size = n
+	}
+	if v := q.Get("margin"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return qr.Options{}, errors.New("некорректный margin")
+		}
+		opts.Margin = n
+	}
+	return opts, opts.Validate()
+}
@@ -1,76 +1,100 @@
 package handlers
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"log/slog"
 	"net/http"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/gorilla/mux"
 
-	"github.com/GritsyukLeonid/pastebin-go/internal/model"
+	"github.com/GritsyukLeonid/pastebin-go/internal/links"
+	"github.com/GritsyukLeonid/pastebin-go/internal/metrics"
+	"github.com/GritsyukLeonid/pastebin-go/internal/qr"
+	"github.com/GritsyukLeonid/pastebin-go/internal/reqctx"
 	"github.com/GritsyukLeonid/pastebin-go/internal/service"
 )
 
+const (
+	// clickRecordTimeout ограничивает запись перехода, которая идёт уже после ответа клиенту.
+	clickRecordTimeout = 5 * time.Second
+	// clickWorkers горутин сохраняют переходы из очереди на clickQueueSize записей.
+	clickWorkers   = 4
+	clickQueueSize = 1024
+)
+
 type ShortURLHandler struct {
 	service      service.ShortURLService
 	pasteService service.PasteService
 	statsService service.StatsService
+	clickService service.ClickService
+	qrCache      *qr.Cache
+	links        *links.Builder
+	clicks       chan click
+	closeOnce    sync.Once
+	wg           sync.WaitGroup
+}
+
+// click — переход, ожидающий записи в аналитику.
+type click struct {
+	ctx       context.Context
+	shortID   string
+	ip        string
+	referrer  string
+	userAgent string
+}
+
+type ShortURLPreview struct {
+	ID          string `json:"id"`
+	TargetType  string `json:"targetType"`
+	Destination string `json:"destination"`
 }
 
 type ContentResponse struct {
 	Content string `json:"content"`
 }
 
-func NewShortURLHandler(s service.ShortURLService, ps service.PasteService, ss service.StatsService) *ShortURLHandler {
-	return &ShortURLHandler{
+func NewShortURLHandler(s service.ShortURLService, ps service.PasteService, ss service.StatsService, cs service.ClickService, lb *links.Builder) *ShortURLHandler {
+	h := &ShortURLHandler{
 		service:      s,
 		pasteService: ps,
 		statsService: ss, //
+		clickService: cs,
+		qrCache:      qr.NewCache(qrCacheSize),
+		links:        lb,
+		clicks:       make(chan click, clickQueueSize),
 	}
-}
-
-// @Summary Создать короткий URL
-// @Description Создает короткую ссылку по переданному hash пасты. Используется первые 6 символов хэша.
-// @Tags shorturls
-// @Param hash path string true "Hash пасты"
-// @Success 201 {object} model.ShortURL
-// @Failure 400 {string} string "Хэш слишком короткий или отсутствует"
-// @Failure 500 {string} string "Ошибка сервера"
-// @Router /api/shorturl/{hash} [post]
-func (h *ShortURLHandler) CreateShortURLHandler(w http.ResponseWriter, r *http.Request) {
-	vars := mux.Vars(r)
-	hash, ok := vars["hash"]
-	if !ok {
-		http.Error(w, "missing hash", http.StatusBadRequest)
-		return
+	h.wg.Add(clickWorkers)
+	for range clickWorkers {
+		go h.saveClicks()
 	}
+	return h
+}
 
-	if len(hash) < 6 {
-		http.Error(w, "Хэш слишком короткий для сокращения", http.StatusBadRequest)
-		return
-	}
-
-	short := model.NewShortURL(hash, hash[:6])
-
-	created, err := h.service.CreateShortURL(r.Context(), *short)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
+// Close перестаёт принимать переходы и ждёт, пока очередь будет записана, или отмены ctx.
+// Вызывается после остановки HTTP-сервера.
+func (h *ShortURLHandler) Close(ctx context.Context) error {
+	h.closeOnce.Do(func() { close(h.clicks) })
+	done := make(chan struct{})
+	go func() {
+		h.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
 	}
-
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusCreated)
-	json.NewEncoder(w).Encode(created)
 }
 
-// @Summary Получить пасту по короткой ссылке
-// @Description Возвращает содержимое пасты по короткому коду (короткому URL). Также увеличивает счётчик просмотров.
-// @Tags shorturls
-// @Produce json
-// @Param code path string true "Короткий код"
-// @Success 200 {object} handlers.ContentResponse "Контент пасты"
-// @Failure 400 {string} string "Код отсутствует"
-// @Failure 404 {string} string "ShortURL или паста не найдена"
-// @Router /s/{code} [get]
+// ResolveShortURLHandler выполняет переход по короткой ссылке (GET /s/{code}): внешний адрес —
+// редирект 302 (301 для постоянных ссылок), паста — её содержимое. Код с суффиксом "+" возвращает
+// адрес назначения без перехода. Переходы, кроме предпросмотра, учитываются в аналитике.
 func (h *ShortURLHandler) ResolveShortURLHandler(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	code, ok := vars["code"]
@@ -79,90 +103,92 @@ func (h *ShortURLHandler) ResolveShortURLHandler(w http.ResponseWriter, r *http.
 		return
 	}
 
+	preview := strings.HasSuffix(code, "+")
+	code = strings.TrimSuffix(code, "+")
+
 	short, err := h.service.GetShortURLByID(r.Context(), code)
+	if errors.Is(err, service.ErrShortURLGone) {
+		metrics.ShortLinkResolved(metrics.ResolveGone)
+		http.Error(w, "Ссылка больше не действует", http.StatusGone)
+		return
+	}
 	if err != nil {
+		metrics.ShortLinkResolved(metrics.ResolveNotFound)
 		http.Error(w, "ShortURL не найден", http.StatusNotFound)
 		return
 	}
 
-	paste, err := h.pasteService.GetPasteByHash(r.Context(), short.Original)
-	if err != nil {
-		http.Error(w, "Паста не найдена", http.StatusNotFound)
+	if preview {
+		destination := short.Original
+		if !short.IsURL() {
+			destination = h.links.URL(r, "/api/v1/paste/hash/"+short.Original)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(ShortURLPreview{
+			ID:          short.ID,
+			TargetType:  short.TargetType,
+			Destination: destination,
+		})
 		return
 	}
 
-	_ = h.statsService.IncrementViews(r.Context(), paste.ID)
-
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(struct {
-		Content string `json:"content"`
-	}{
-		Content: paste.Content,
-	})
-}
+	h.recordClick(r, short.ID)
 
-// @Summary Получить короткий URL по ID
-// @Description Возвращает объект короткой ссылки по её ID
-// @Tags shorturls
-// @Produce json
-// @Param id path string true "ID ShortURL"
-// @Success 200 {object} model.ShortURL
-// @Failure 400 {string} string "ID отсутствует"
-// @Failure 404 {string} string "ShortURL не найден"
-// @Router /api/shorturl/{id} [get]
-func (h *ShortURLHandler) GetShortURLByIDHandler(w http.ResponseWriter, r *http.Request) {
-	vars := mux.Vars(r)
-	id, ok := vars["id"]
-	if !ok {
-		http.Error(w, "missing id", http.StatusBadRequest)
+	if short.IsURL() {
+		metrics.ShortLinkResolved(metrics.ResolveRedirect)
+		status := http.StatusFound
+		if short.Permanent {
+			status = http.StatusMovedPermanently
+		}
+		http.Redirect(w, r, short.Original, status)
 		return
 	}
 
-	url, err := h.service.GetShortURLByID(r.Context(), id)
+	paste, err := h.pasteService.GetPasteByHash(r.Context(), short.Original)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusNotFound)
+		metrics.ShortLinkResolved(metrics.ResolveNotFound)
+		http.Error(w, "Паста не найдена", http.StatusNotFound)
 		return
 	}
+	metrics.ShortLinkResolved(metrics.ResolvePaste)
+
+	_ = h.statsService.IncrementViews(r.Context(), paste.ID)
+
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(url)
+	json.NewEncoder(w).Encode(ContentResponse{
+		Content: paste.Content,
+	})
 }
 
-// @Summary Удалить короткий URL
-// @Description Удаляет короткий URL по его ID
-// @Tags shorturls
-// @Param id path string true "ID ShortURL"
-// @Success 200 {string} string "ShortURL удалён"
-// @Failure 400 {string} string "ID отсутствует"
-// @Failure 404 {string} string "ShortURL не найден"
-// @Router /api/shorturl/{id} [delete]
-func (h *ShortURLHandler) DeleteShortURLHandler(w http.ResponseWriter, r *http.Request) {
-	vars := mux.Vars(r)
-	id, ok := vars["id"]
-	if !ok {
-		http.Error(w, "missing id", http.StatusBadRequest)
-		return
+// recordClick ставит переход в очередь, чтобы медленное или недоступное хранилище аналитики
+// не задерживало переход. При заполненной очереди переход отбрасывается
+// и учитывается в pastebin_clicks_dropped_total.
+func (h *ShortURLHandler) recordClick(r *http.Request, shortID string) {
+	c := click{
+		ctx:       context.WithoutCancel(r.Context()),
+		shortID:   shortID,
+		ip:        reqctx.RemoteIP(r),
+		referrer:  r.Referer(),
+		userAgent: r.UserAgent(),
 	}
-
-	if err := h.service.DeleteShortURL(r.Context(), id); err != nil {
-		http.Error(w, err.Error(), http.StatusNotFound)
-		return
+	select {
+	case h.clicks <- c:
+	default:
+		metrics.ClickDropped()
+		slog.WarnContext(r.Context(), "click dropped, queue is full", "short_id", shortID)
 	}
-	w.WriteHeader(http.StatusOK)
 }
 
-// @Summary Получить все короткие URL
-// @Description Возвращает список всех коротких ссылок
-// @Tags shorturls
-// @Produce json
-// @Success 200 {array} model.ShortURL
-// @Failure 500 {string} string "Ошибка при получении данных"
-// @Router /api/shorturls [get]
-func (h *ShortURLHandler) GetAllShortURLsHandler(w http.ResponseWriter, r *http.Request) {
-	urls, err := h.service.ListShortURLs(r.Context())
-	if err != nil {
-		http.Error(w, "Ошибка при получении URL", http.StatusInternalServerError)
-		return
+// saveClicks записывает переходы из очереди. Ошибки пишутся в журнал
+// и в pastebin_click_record_failures_total.
+func (h *ShortURLHandler) saveClicks() {
+	defer h.wg.Done()
+	for c := range h.clicks {
+		ctx, cancel := context.WithTimeout(c.ctx, clickRecordTimeout)
+		if err := h.clickService.RecordClick(ctx, c.shortID, c.ip, c.referrer, c.userAgent); err != nil {
+			metrics.ClickRecordFailed()
+			slog.WarnContext(ctx, "failed to record click", "short_id", c.shortID, "error", err)
+		}
+		cancel()
 	}
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(urls)
 }
@@ -0,0 +1,195 @@
+// Package health проверяет зависимости сервиса для /readyz и gRPC-сервиса grpc.health.v1.
+package health
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/golang-migrate/migrate/v4/source"
+	_ "github.com/golang-migrate/migrate/v4/source/file"
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+const (
+	StatusUp   = "up"
+	StatusDown = "down"
+
+	DefaultTimeout = 2 * time.Second
+)
+
+// Check проверяет одну зависимость; nil — зависимость доступна.
+type Check func(ctx context.Context) error
+
+type Result struct {
+	Status     string `json:"status"`
+	Error      string `json:"error,omitempty"`
+	DurationMs int64  `json:"duration_ms"`
+}
+
+type Report struct {
+	Status string            `json:"status"`
+	Checks map[string]Result `json:"checks"`
+}
+
+func (r Report) Healthy() bool {
+	return r.Status == StatusUp
+}
+
+// Checker запускает именованные проверки параллельно, каждую со своим таймаутом.
+type Checker struct {
+	timeout time.Duration
+	mu      sync.RWMutex
+	checks  map[string]Check
+}
+
+func NewChecker(timeout time.Duration) *Checker {
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	return &Checker{timeout: timeout, checks: map[string]Check{}}
+}
+
+func (c *Checker) Add(name string, check Check) *Checker {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.checks[name] = check
+	return c
+}
+
+// Run выполняет все проверки. Отчёт в статусе up, только если прошли все.
+func (c *Checker) Run(ctx context.Context) Report {
+	c.mu.RLock()
+	checks := make(map[string]Check, len(c.checks))
+	for name, check := range c.checks {
+		checks[name] = check
+	}
+	c.mu.RUnlock()
+
+	report := Report{Status: StatusUp, Checks: make(map[string]Result, len(checks))}
+	var (
+		mu sync.Mutex
+		wg sync.WaitGroup
+	)
+	for name, check := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			res := c.run(ctx, check)
+			mu.Lock()
+			defer mu.Unlock()
+			report.Checks[name] = res
+			if res.Status != StatusUp {
+				report.Status = StatusDown
+			}
+		}()
+	}
+	wg.Wait()
+	return report
+}
+
+func (c *Checker) run(ctx context.Context, check Check) Result {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	start := time.Now()
+	done := make(chan error, 1)
+	go func() { done <- check(ctx) }()
+
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+	res := Result{Status: StatusUp, DurationMs: time.Since(start).Milliseconds()}
+	if err != nil {
+		res.Status = StatusDown
+		res.Error = err.Error()
+	}
+	return res
+}
+
+// Ping проверяет соединение, например *sql.DB.
+func Ping(p interface{ PingContext(context.Context) error }) Check {
+	return p.PingContext
+}
+
+var ErrMigrationsOutdated = errors.New("migrations are not at the expected version")
+
+// Migrations сверяет версию схемы в schema_migrations (golang-migrate) с ожидаемой.
+func Migrations(db *sql.DB, expected uint) Check {
+	return func(ctx context.Context) error {
+		var (
+			version uint
+			dirty   bool
+		)
+		err := db.QueryRowContext(ctx, `SELECT version, dirty FROM schema_migrations LIMIT 1`).Scan(&version, &dirty)
+		if err != nil {
+			return err
+		}
+		if dirty {
+			return fmt.Errorf("%w: version %d is dirty", ErrMigrationsOutdated, version)
+		}
+		if version != expected {
+			return fmt.Errorf("%w: have %d, want %d", ErrMigrationsOutdated, version, expected)
+		}
+		return nil
+	}
+}
+
+// LatestMigration возвращает номер последней миграции в источнике, например file://internal/migrations.
+func LatestMigration(sourceURL string) (uint, error) {
+	src, err := source.Open(sourceURL)
+	if err != nil {
+		return 0, err
+	}
+	defer src.Close()
+
+	version, err := src.First()
+	if err != nil {
+		return 0, err
+	}
+	for {
+		next, err := src.Next(version)
+		if errors.Is(err, os.ErrNotExist) {
+			return version, nil
+		}
+		if err != nil {
+			return 0, err
+		}
+		version = next
+	}
+}
+
+// Serve каждые interval прогоняет проверки и выставляет общий статус сервера ("")
+// и перечисленных сервисов в grpc.health.v1. Завершается вместе с ctx.
+func (c *Checker) Serve(ctx context.Context, srv *grpchealth.Server, interval time.Duration, services ...string) {
+	update := func() {
+		status := healthpb.HealthCheckResponse_SERVING
+		if !c.Run(ctx).Healthy() {
+			status = healthpb.HealthCheckResponse_NOT_SERVING
+		}
+		srv.SetServingStatus("", status)
+		for _, name := range services {
+			srv.SetServingStatus(name, status)
+		}
+	}
+
+	update()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			update()
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func TestCheckerRun(t *testing.T) {
+	ok := NewChecker(time.Second).Add("postgres", func(ctx context.Context) error { return nil })
+	report := ok.Run(context.Background())
+	assert.True(t, report.Healthy())
+	assert.Equal(t, StatusUp, report.Checks["postgres"].Status)
+
+	failing := NewChecker(time.Second).
+		Add("postgres", func(ctx context.Context) error { return nil }).
+		Add("redis", func(ctx context.Context) error { return errors.New("connection refused") })
+	report = failing.Run(context.Background())
+	assert.False(t, report.Healthy())
+	assert.Equal(t, StatusUp, report.Checks["postgres"].Status)
+	assert.Equal(t, Result{Status: StatusDown, Error: "connection refused", DurationMs: report.Checks["redis"].DurationMs}, report.Checks["redis"])
+}
+
+func TestCheckerTimeout(t *testing.T) {
+	hanging := make(chan struct{})
+	defer close(hanging)
+
+	c := NewChecker(20*time.Millisecond).Add("redis", func(ctx context.Context) error {
+		<-hanging // проверка, которая игнорирует ctx
+		return nil
+	})
+	report := c.Run(context.Background())
+	assert.False(t, report.Healthy())
+	assert.Equal(t, context.DeadlineExceeded.Error(), report.Checks["redis"].Error)
+}
+
+func TestLatestMigration(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"001_init.up.sql", "002_tokens.up.sql", "010_clicks.up.sql"} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte("SELECT 1;"), 0o600))
+	}
+	version, err := LatestMigration("file://" + dir)
+	require.NoError(t, err)
+	assert.Equal(t, uint(10), version)
+
+	version, err = LatestMigration("file://../migrations")
+	require.NoError(t, err)
+	assert.NotZero(t, version)
+
+	_, err = LatestMigration("file://" + t.TempDir())
+	assert.Error(t, err)
+}
+
+func TestServeUpdatesGRPCStatus(t *testing.T) {
+	srv := grpchealth.NewServer()
+	healthy := make(chan bool, 1)
+	healthy <- false
+	c := NewChecker(time.Second).Add("storage", func(ctx context.Context) error {
+		ok := <-healthy
+		healthy <- ok
+		if !ok {
+			return errors.New("read-only")
+		}
+		return nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go c.Serve(ctx, srv, 10*time.Millisecond, "pastebin.PasteService")
+
+	status := func(service string) healthpb.HealthCheckResponse_ServingStatus {
+		resp, err := srv.Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+		if err != nil {
+			return healthpb.HealthCheckResponse_UNKNOWN
+		}
+		return resp.Status
+	}
+	assert.Eventually(t, func() bool {
+		return status("pastebin.PasteService") == healthpb.HealthCheckResponse_NOT_SERVING
+	}, time.Second, 5*time.Millisecond)
+
+	<-healthy
+	healthy <- true
+	assert.Eventually(t, func() bool {
+		return status("") == healthpb.HealthCheckResponse_SERVING &&
+			status("pastebin.PasteService") == healthpb.HealthCheckResponse_SERVING
+	}, time.Second, 5*time.Millisecond)
+}
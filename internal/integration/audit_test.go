@@ -0,0 +1,65 @@
+package integration
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditLogRecordsPasteCreation(t *testing.T) {
+	skipIfNotIntegration(t)
+	token := os.Getenv("ADMIN_TOKEN")
+	if token == "" {
+		t.Skip("ADMIN_TOKEN не задан")
+	}
+
+	body, _ := json.Marshal(map[string]any{
+		"content":   "audited content",
+		"expiresAt": time.Now().Add(time.Hour),
+	})
+	resp, err := http.Post("http://localhost:8080/api/v1/paste", "application/json", bytes.NewReader(body))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	requestID := resp.Header.Get("X-Request-ID")
+	var created struct {
+		Paste map[string]any `json:"paste"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&created))
+	resp.Body.Close()
+
+	resp, err = http.Get("http://localhost:8080/api/v1/admin/audit?entity=paste")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	resp.Body.Close()
+
+	// Журнал изменений пишется асинхронно: запись появляется с небольшой задержкой.
+	var found map[string]any
+	require.Eventually(t, func() bool {
+		req, _ := http.NewRequest(http.MethodGet, "http://localhost:8080/api/v1/admin/audit?entity=paste&actor=anonymous&limit=50", nil)
+		req.Header.Set("X-Admin-Token", token)
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil || resp.StatusCode != http.StatusOK {
+			return false
+		}
+		defer resp.Body.Close()
+		var entries []map[string]any
+		if json.NewDecoder(resp.Body).Decode(&entries) != nil {
+			return false
+		}
+		for _, e := range entries {
+			if e["entity_id"] == created.Paste["id"] {
+				found = e
+			}
+		}
+		return found != nil
+	}, 5*time.Second, 100*time.Millisecond, "запись о создании пасты должна быть в журнале")
+	assert.Equal(t, "created", found["action"])
+	assert.Equal(t, requestID, found["request_id"])
+	assert.NotContains(t, found["after"], "content", "содержимое пасты в журнал не пишется")
+}
@@ -0,0 +1,63 @@
+package integration
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Лента получает пасты через уведомления PostgreSQL, поэтому тест проверяет и триггеры, и LISTEN.
+func TestPasteStreamReceivesCreatedPaste(t *testing.T) {
+	skipIfNotIntegration(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://localhost:8080/api/v1/paste/stream", nil)
+	require.NoError(t, err)
+	stream, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer stream.Body.Close()
+	require.Equal(t, http.StatusOK, stream.StatusCode)
+	assert.Equal(t, "text/event-stream", stream.Header.Get("Content-Type"))
+
+	body, _ := json.Marshal(map[string]interface{}{"content": "live feed", "ttl": "600s"})
+	resp, err := http.Post("http://localhost:8080/api/v1/paste", "application/json", bytes.NewReader(body))
+	require.NoError(t, err)
+	var created struct {
+		Paste struct {
+			ID string `json:"id"`
+		} `json:"paste"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&created))
+	resp.Body.Close()
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	scanner := bufio.NewScanner(stream.Body)
+	for scanner.Scan() {
+		data, ok := strings.CutPrefix(scanner.Text(), "data: ")
+		if !ok {
+			continue
+		}
+		var item struct {
+			Type  string `json:"type"`
+			Paste struct {
+				ID   string `json:"id"`
+				Size int    `json:"size"`
+			} `json:"paste"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(data), &item))
+		if item.Type == "paste.created" && item.Paste.ID == created.Paste.ID {
+			assert.Equal(t, len("live feed"), item.Paste.Size)
+			return
+		}
+	}
+	t.Fatalf("paste %v not received from stream: %v", created.Paste.ID, scanner.Err())
+}
@@ -0,0 +1,34 @@
+package integration
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHealthEndpoints(t *testing.T) {
+	skipIfNotIntegration(t)
+
+	resp, err := http.Get("http://localhost:8080/healthz")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	resp.Body.Close()
+
+	resp, err = http.Get("http://localhost:8080/readyz")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var report struct {
+		Status string                    `json:"status"`
+		Checks map[string]map[string]any `json:"checks"`
+	}
+	err = json.NewDecoder(resp.Body).Decode(&report)
+	resp.Body.Close()
+	assert.NoError(t, err)
+	assert.Equal(t, "up", report.Status)
+	for _, name := range []string{"postgres", "redis", "migrations"} {
+		assert.Contains(t, report.Checks, name)
+	}
+}
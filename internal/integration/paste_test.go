@@ -3,6 +3,7 @@ package integration
 import (
 	"bytes"
 	"encoding/json"
+	"io"
 	"net/http"
 	"testing"
 
@@ -13,25 +14,29 @@ func TestPasteLifecycle(t *testing.T) {
 	skipIfNotIntegration(t)
 
 	paste := map[string]interface{}{
-		"user_id":            "test-user",
-		"content":            "integration test content",
-		"expiration_minutes": 10,
+		"content": "integration test content",
+		"ttl":     "600s",
 	}
 	body, _ := json.Marshal(paste)
 
-	resp, err := http.Post("http://localhost:8080/api/paste", "application/json", bytes.NewReader(body))
+	resp, err := http.Post("http://localhost:8080/api/v1/paste", "application/json", bytes.NewReader(body))
 	assert.NoError(t, err)
 	assert.Equal(t, http.StatusCreated, resp.StatusCode)
 
-	var created map[string]interface{}
+	var created struct {
+		Paste struct {
+			ID string `json:"id"`
+		} `json:"paste"`
+		DeleteToken string `json:"delete_token"`
+	}
 	err = json.NewDecoder(resp.Body).Decode(&created)
 	resp.Body.Close()
 	assert.NoError(t, err)
+	assert.NotEmpty(t, created.DeleteToken)
 
-	pasteID, ok := created["id"].(string)
-	assert.True(t, ok, "ID должен быть строкой")
+	pasteID := created.Paste.ID
 
-	resp, err = http.Get("http://localhost:8080/api/paste/" + pasteID)
+	resp, err = http.Get("http://localhost:8080/api/v1/paste/" + pasteID)
 	assert.NoError(t, err)
 	assert.Equal(t, http.StatusOK, resp.StatusCode)
 
@@ -41,11 +46,50 @@ func TestPasteLifecycle(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, paste["content"], fetched["content"])
 
-	req, err := http.NewRequest(http.MethodDelete, "http://localhost:8080/api/paste/"+pasteID, nil)
+	req, err := http.NewRequest(http.MethodDelete, "http://localhost:8080/api/v1/paste/"+pasteID, nil)
 	assert.NoError(t, err)
 
+	resp, err = http.DefaultClient.Do(req)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	resp.Body.Close()
+
+	req, err = http.NewRequest(http.MethodDelete, "http://localhost:8080/api/v1/paste/"+pasteID, nil)
+	assert.NoError(t, err)
+	req.Header.Set("X-Delete-Token", created.DeleteToken)
+
 	resp, err = http.DefaultClient.Do(req)
 	assert.NoError(t, err)
 	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
 	resp.Body.Close()
 }
+
+// TestLegacyAPIPathsAnswerGone фиксирует переход на /api/v1 (см. README): прежние пути не отвечают
+// в новом формате, а явно сообщают о переезде.
+func TestLegacyAPIPathsAnswerGone(t *testing.T) {
+	skipIfNotIntegration(t)
+
+	body, _ := json.Marshal(map[string]interface{}{"content": "legacy", "expiration_minutes": 10})
+	resp, err := http.Post("http://localhost:8080/api/paste", "application/json", bytes.NewReader(body))
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusGone, resp.StatusCode)
+	message, _ := io.ReadAll(resp.Body)
+	assert.Contains(t, string(message), "/api/v1")
+}
+
+func TestCreatePasteKeepsSnakeCaseFields(t *testing.T) {
+	skipIfNotIntegration(t)
+
+	body, _ := json.Marshal(map[string]interface{}{"content": "field names", "ttl": "600s"})
+	resp, err := http.Post("http://localhost:8080/api/v1/paste", "application/json", bytes.NewReader(body))
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	var created map[string]json.RawMessage
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&created))
+	for _, field := range []string{"paste", "delete_token", "short_url"} {
+		assert.Contains(t, created, field)
+	}
+	assert.NotContains(t, created, "deleteToken")
+}
@@ -1,17 +1,178 @@
 package integration
 
 import (
+	"bytes"
+	"encoding/json"
+	"fmt"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestGetNonexistentShortURL(t *testing.T) {
 	skipIfNotIntegration(t)
 
-	resp, err := http.Get("http://localhost:8080/api/shorturl/fake123")
+	resp, err := http.Get("http://localhost:8080/api/v1/shorturl/fake123")
 	assert.NoError(t, err)
 	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
 	resp.Body.Close()
 }
+
+func TestShortenURLRedirectAndPreview(t *testing.T) {
+	skipIfNotIntegration(t)
+
+	body, _ := json.Marshal(map[string]interface{}{"url": "https://example.com/docs"})
+	resp, err := http.Post("http://localhost:8080/api/v1/shorturl", "application/json", bytes.NewReader(body))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	var created struct {
+		ID string `json:"id"`
+	}
+	err = json.NewDecoder(resp.Body).Decode(&created)
+	resp.Body.Close()
+	assert.NoError(t, err)
+
+	client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }}
+	resp, err = client.Get("http://localhost:8080/s/" + created.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusFound, resp.StatusCode)
+	assert.Equal(t, "https://example.com/docs", resp.Header.Get("Location"))
+	resp.Body.Close()
+
+	resp, err = client.Get("http://localhost:8080/s/" + created.ID + "+")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	resp.Body.Close()
+
+	body, _ = json.Marshal(map[string]interface{}{"url": "javascript:alert(1)"})
+	resp, err = http.Post("http://localhost:8080/api/v1/shorturl", "application/json", bytes.NewReader(body))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	resp.Body.Close()
+}
+
+func TestDeleteShortURLRequiresTokenAndKeepsCode(t *testing.T) {
+	skipIfNotIntegration(t)
+
+	alias := fmt.Sprintf("gone-%d", time.Now().UnixNano())
+	body, _ := json.Marshal(map[string]interface{}{"url": "https://example.com/owned", "alias": alias})
+	resp, err := http.Post("http://localhost:8080/api/v1/shorturl", "application/json", bytes.NewReader(body))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var created struct {
+		DeleteToken string `json:"delete_token"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&created))
+	resp.Body.Close()
+	require.NotEmpty(t, created.DeleteToken)
+
+	del := func(token string) int {
+		req, _ := http.NewRequest(http.MethodDelete, "http://localhost:8080/api/v1/shorturl/"+alias, nil)
+		if token != "" {
+			req.Header.Set("X-Delete-Token", token)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+	assert.Equal(t, http.StatusForbidden, del(""))
+	assert.Equal(t, http.StatusNoContent, del(created.DeleteToken))
+
+	resp, err = http.Get("http://localhost:8080/s/" + alias)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusGone, resp.StatusCode)
+	resp.Body.Close()
+
+	body, _ = json.Marshal(map[string]interface{}{"url": "https://example.com/hijack", "alias": alias})
+	resp, err = http.Post("http://localhost:8080/api/v1/shorturl", "application/json", bytes.NewReader(body))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusConflict, resp.StatusCode, "удалённый код нельзя занять заново")
+	resp.Body.Close()
+}
+
+func TestShortURLAnalytics(t *testing.T) {
+	skipIfNotIntegration(t)
+
+	body, _ := json.Marshal(map[string]interface{}{"url": "https://example.com/analytics"})
+	resp, err := http.Post("http://localhost:8080/api/v1/shorturl", "application/json", bytes.NewReader(body))
+	assert.NoError(t, err)
+	var created struct {
+		ID string `json:"id"`
+	}
+	err = json.NewDecoder(resp.Body).Decode(&created)
+	resp.Body.Close()
+	assert.NoError(t, err)
+
+	client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }}
+	for i := 0; i < 2; i++ {
+		req, _ := http.NewRequest(http.MethodGet, "http://localhost:8080/s/"+created.ID, nil)
+		req.Header.Set("Referer", "https://news.ycombinator.com/item?id=1")
+		resp, err = client.Do(req)
+		assert.NoError(t, err)
+		resp.Body.Close()
+	}
+
+	var stats struct {
+		TotalClicks    int64 `json:"total_clicks,string"`
+		UniqueVisitors int64 `json:"unique_visitors,string"`
+		TopReferrers   []struct {
+			Host   string `json:"host"`
+			Clicks int64  `json:"clicks,string"`
+		} `json:"top_referrers"`
+	}
+	// Переходы записываются в фоне после ответа.
+	require.Eventually(t, func() bool {
+		resp, err := http.Get("http://localhost:8080/api/v1/shorturl/" + created.ID + "/analytics?bucket=hour")
+		if err != nil || resp.StatusCode != http.StatusOK {
+			return false
+		}
+		defer resp.Body.Close()
+		return json.NewDecoder(resp.Body).Decode(&stats) == nil && stats.TotalClicks == 2
+	}, 5*time.Second, 100*time.Millisecond)
+	assert.Equal(t, int64(2), stats.TotalClicks)
+	assert.Equal(t, int64(1), stats.UniqueVisitors)
+	if assert.Len(t, stats.TopReferrers, 1) {
+		assert.Equal(t, "news.ycombinator.com", stats.TopReferrers[0].Host)
+	}
+
+	resp, err = http.Get("http://localhost:8080/api/v1/shorturl/" + created.ID + "/analytics?bucket=week")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	resp.Body.Close()
+}
+
+func TestShortURLQRCode(t *testing.T) {
+	skipIfNotIntegration(t)
+
+	body, _ := json.Marshal(map[string]interface{}{"url": "https://example.com/qr"})
+	resp, err := http.Post("http://localhost:8080/api/v1/shorturl", "application/json", bytes.NewReader(body))
+	assert.NoError(t, err)
+	var created struct {
+		ID string `json:"id"`
+	}
+	err = json.NewDecoder(resp.Body).Decode(&created)
+	resp.Body.Close()
+	assert.NoError(t, err)
+
+	resp, err = http.Get("http://localhost:8080/s/" + created.ID + "/qr")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "image/png", resp.Header.Get("Content-Type"))
+	resp.Body.Close()
+
+	resp, err = http.Get("http://localhost:8080/s/" + created.ID + "/qr?format=svg&size=512&level=H")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "image/svg+xml", resp.Header.Get("Content-Type"))
+	resp.Body.Close()
+
+	resp, err = http.Get("http://localhost:8080/s/" + created.ID + "/qr?size=5")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	resp.Body.Close()
+}
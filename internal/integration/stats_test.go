@@ -11,7 +11,7 @@ import (
 func TestGetAllStats(t *testing.T) {
 	skipIfNotIntegration(t)
 
-	resp, err := http.Get("http://localhost:8080/api/stats")
+	resp, err := http.Get("http://localhost:8080/api/v1/stats")
 	assert.NoError(t, err)
 	assert.Equal(t, http.StatusOK, resp.StatusCode)
 
@@ -18,12 +18,13 @@ func TestUserCreateAndGet(t *testing.T) {
 	}
 	body, _ := json.Marshal(user)
 
-	resp, err := http.Post("http://localhost:8080/api/user", "application/json", bytes.NewReader(body))
+	resp, err := http.Post("http://localhost:8080/api/v1/user", "application/json", bytes.NewReader(body))
 	assert.NoError(t, err)
 	assert.Equal(t, http.StatusCreated, resp.StatusCode)
 
+	// int64 в JSON передаётся строкой.
 	var created struct {
-		ID       int64    `json:"id"`
+		ID       int64    `json:"id,string"`
 		Username string   `json:"username"`
 		Posts    []string `json:"posts"`
 	}
@@ -34,12 +35,12 @@ func TestUserCreateAndGet(t *testing.T) {
 	assert.Equal(t, "integration_user", created.Username)
 	assert.Greater(t, created.ID, int64(0))
 
-	resp, err = http.Get("http://localhost:8080/api/user/" + fmt.Sprintf("%d", created.ID))
+	resp, err = http.Get("http://localhost:8080/api/v1/user/" + fmt.Sprintf("%d", created.ID))
 	assert.NoError(t, err)
 	assert.Equal(t, http.StatusOK, resp.StatusCode)
 
 	var fetched struct {
-		ID       int64    `json:"id"`
+		ID       int64    `json:"id,string"`
 		Username string   `json:"username"`
 		Posts    []string `json:"posts"`
 	}
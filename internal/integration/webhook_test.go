@@ -0,0 +1,112 @@
+package integration
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/GritsyukLeonid/pastebin-go/internal/service"
+)
+
+type receivedWebhook struct {
+	header http.Header
+	body   []byte
+}
+
+// TestWebhookDeliveredToLocalReceiver поднимает получатель на этой машине. Сервис должен видеть его
+// по адресу WEBHOOK_RECEIVER_HOST: localhost при локальном запуске, host.docker.internal — из Docker Compose.
+func TestWebhookDeliveredToLocalReceiver(t *testing.T) {
+	skipIfNotIntegration(t)
+	host := os.Getenv("WEBHOOK_RECEIVER_HOST")
+	if host == "" {
+		t.Skip("WEBHOOK_RECEIVER_HOST не задан")
+	}
+
+	received := make(chan receivedWebhook, 10)
+	listener, err := net.Listen("tcp", ":0")
+	require.NoError(t, err)
+	receiver := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received <- receivedWebhook{header: r.Header, body: body}
+	})}
+	go receiver.Serve(listener)
+	defer receiver.Close()
+	port := listener.Addr().(*net.TCPAddr).Port
+
+	body, _ := json.Marshal(map[string]any{
+		"url":    fmt.Sprintf("http://%s:%d/hook", host, port),
+		"events": []string{"paste.created"},
+	})
+	resp, err := http.Post("http://localhost:8080/api/v1/webhooks", "application/json", bytes.NewReader(body))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var hook struct {
+		ID     int64  `json:"id,string"`
+		Secret string `json:"secret"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&hook))
+	resp.Body.Close()
+	require.NotEmpty(t, hook.Secret)
+
+	body, _ = json.Marshal(map[string]any{
+		"content":   "webhook content",
+		"expiresAt": time.Now().Add(time.Hour),
+	})
+	resp, err = http.Post("http://localhost:8080/api/v1/paste", "application/json", bytes.NewReader(body))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var created struct {
+		Paste map[string]any `json:"paste"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&created))
+	resp.Body.Close()
+
+	var got receivedWebhook
+	select {
+	case got = <-received:
+	case <-time.After(10 * time.Second):
+		t.Fatal("вебхук не доставлен")
+	}
+	timestamp, err := strconv.ParseInt(got.header.Get(service.WebhookTimestampHeader), 10, 64)
+	require.NoError(t, err)
+	assert.Equal(t, service.SignWebhook(hook.Secret, timestamp, got.body), got.header.Get(service.WebhookSignatureHeader))
+	var payload map[string]any
+	require.NoError(t, json.Unmarshal(got.body, &payload))
+	assert.Equal(t, "paste.created", payload["type"])
+	assert.Equal(t, created.Paste["id"], payload["entityId"])
+	assert.NotContains(t, string(got.body), "webhook content")
+
+	// Попытка записывается после ответа получателя.
+	require.Eventually(t, func() bool {
+		req, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("http://localhost:8080/api/v1/webhooks/%d/deliveries?status=succeeded", hook.ID), nil)
+		req.Header.Set("X-Webhook-Secret", hook.Secret)
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil || resp.StatusCode != http.StatusOK {
+			return false
+		}
+		defer resp.Body.Close()
+		var deliveries []map[string]any
+		return json.NewDecoder(resp.Body).Decode(&deliveries) == nil && len(deliveries) == 1
+	}, 5*time.Second, 100*time.Millisecond)
+
+	req, _ := http.NewRequest(http.MethodDelete, fmt.Sprintf("http://localhost:8080/api/v1/webhooks/%d", hook.ID), nil)
+	resp, err = http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode, "без секрета вебхук не удаляется")
+	resp.Body.Close()
+	req.Header.Set("X-Webhook-Secret", hook.Secret)
+	resp, err = http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	resp.Body.Close()
+}
@@ -0,0 +1,227 @@
+// Package links строит публичные адреса сервиса: базовый URL API и короткие ссылки.
+// Адреса берутся из конфигурации, а за доверенным прокси — из X-Forwarded-Host/Proto.
+// От тех же прокси принимается и X-Forwarded-For с адресом клиента.
+package links
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const (
+	DefaultBaseURL     = "http://localhost:8080"
+	DefaultShortPrefix = "/s/"
+)
+
+var ErrInvalidConfig = errors.New("invalid links config")
+
+// Config — публичные адреса.
+//
+// ShortDomains — необязательные отдельные домены коротких ссылок (https://pst.io):
+// на них код открывается прямо из корня, а первый домен используется в генерируемых ссылках.
+// TrustForwarded разрешает X-Forwarded-Host/Proto; если TrustedProxies не пуст,
+// заголовки принимаются только от адресов из этих подсетей. X-Forwarded-For определяет
+// IP клиента для лимитов и квот, поэтому его принимают только от явно перечисленных прокси.
+type Config struct {
+	BaseURL        string
+	ShortPrefix    string
+	ShortDomains   []string
+	TrustForwarded bool
+	TrustedProxies []string
+}
+
+func DefaultConfig() Config {
+	return Config{BaseURL: DefaultBaseURL, ShortPrefix: DefaultShortPrefix}
+}
+
+type Builder struct {
+	base         *url.URL
+	prefix       string
+	shortDomains []*url.URL
+	trust        bool
+	proxies      []*net.IPNet
+}
+
+func NewBuilder(cfg Config) (*Builder, error) {
+	base, err := parseBase(cfg.BaseURL)
+	if err != nil {
+		return nil, fmt.Errorf("%w: base url: %v", ErrInvalidConfig, err)
+	}
+
+	prefix := cfg.ShortPrefix
+	if prefix == "" {
+		prefix = DefaultShortPrefix
+	}
+	if !strings.HasPrefix(prefix, "/") || !strings.HasSuffix(prefix, "/") || prefix == "/" {
+		return nil, fmt.Errorf("%w: short prefix must look like /s/", ErrInvalidConfig)
+	}
+
+	b := &Builder{base: base, prefix: prefix, trust: cfg.TrustForwarded}
+	for _, d := range cfg.ShortDomains {
+		u, err := parseBase(d)
+		if err != nil {
+			return nil, fmt.Errorf("%w: short domain %q: %v", ErrInvalidConfig, d, err)
+		}
+		b.shortDomains = append(b.shortDomains, u)
+	}
+	for _, p := range cfg.TrustedProxies {
+		_, n, err := net.ParseCIDR(p)
+		if err != nil {
+			ip := net.ParseIP(p)
+			if ip == nil {
+				return nil, fmt.Errorf("%w: trusted proxy %q", ErrInvalidConfig, p)
+			}
+			n = &net.IPNet{IP: ip, Mask: net.CIDRMask(len(ip)*8, len(ip)*8)}
+		}
+		b.proxies = append(b.proxies, n)
+	}
+	return b, nil
+}
+
+func parseBase(raw string) (*url.URL, error) {
+	u, err := url.Parse(strings.TrimSuffix(raw, "/"))
+	if err != nil {
+		return nil, err
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, errors.New("must be an absolute http(s) URL")
+	}
+	if u.RawQuery != "" || u.Fragment != "" || u.User != nil {
+		return nil, errors.New("must not contain query, fragment or credentials")
+	}
+	return u, nil
+}
+
+// ShortPrefix — путь, под которым открываются короткие ссылки на основном домене.
+func (b *Builder) ShortPrefix() string {
+	return b.prefix
+}
+
+// Host — хост базового URL, например для Swagger.
+func (b *Builder) Host() string {
+	return b.base.Host
+}
+
+func (b *Builder) Scheme() string {
+	return b.base.Scheme
+}
+
+// ShortDomainHosts возвращает хосты отдельных доменов коротких ссылок.
+func (b *Builder) ShortDomainHosts() []string {
+	hosts := make([]string, 0, len(b.shortDomains))
+	for _, d := range b.shortDomains {
+		hosts = append(hosts, d.Host)
+	}
+	return hosts
+}
+
+// BaseURL возвращает публичный адрес сервиса; r может быть nil (например, в gRPC).
+func (b *Builder) BaseURL(r *http.Request) string {
+	u := *b.base
+	if r != nil && b.trustsForwarded(r) {
+		if proto := firstValue(r.Header.Get("X-Forwarded-Proto")); proto == "http" || proto == "https" {
+			u.Scheme = proto
+		}
+		if host := firstValue(r.Header.Get("X-Forwarded-Host")); validHost(host) {
+			u.Host = host
+		}
+	}
+	return u.String()
+}
+
+// URL возвращает абсолютный адрес для пути path API.
+func (b *Builder) URL(r *http.Request, path string) string {
+	return b.BaseURL(r) + "/" + strings.TrimPrefix(path, "/")
+}
+
+// ShortURL возвращает полный адрес короткой ссылки. При заданных ShortDomains
+// используется первый из них, иначе — базовый URL с ShortPrefix.
+func (b *Builder) ShortURL(r *http.Request, code string) string {
+	if len(b.shortDomains) > 0 {
+		return b.shortDomains[0].String() + "/" + url.PathEscape(code)
+	}
+	return b.BaseURL(r) + b.prefix + url.PathEscape(code)
+}
+
+// IsShortDomain сообщает, пришёл ли запрос на один из доменов коротких ссылок.
+func (b *Builder) IsShortDomain(r *http.Request) bool {
+	for _, d := range b.shortDomains {
+		if strings.EqualFold(r.Host, d.Host) {
+			return true
+		}
+	}
+	return false
+}
+
+// ClientIP возвращает IP клиента. Если соединение пришло от прокси из TrustedProxies,
+// X-Forwarded-For просматривается справа налево и берётся первый адрес не из TrustedProxies:
+// левее него значения мог подставить сам клиент. Иначе — адрес TCP-соединения.
+func (b *Builder) ClientIP(r *http.Request) string {
+	peer := remoteHost(r)
+	if !b.trust || len(b.proxies) == 0 || !b.isProxy(net.ParseIP(peer)) {
+		return peer
+	}
+	hops := strings.Split(strings.Join(r.Header.Values("X-Forwarded-For"), ","), ",")
+	client := peer
+	for i := len(hops) - 1; i >= 0; i-- {
+		ip := net.ParseIP(strings.TrimSpace(hops[i]))
+		if ip == nil {
+			break
+		}
+		client = ip.String()
+		if !b.isProxy(ip) {
+			break
+		}
+	}
+	return client
+}
+
+func (b *Builder) trustsForwarded(r *http.Request) bool {
+	if !b.trust {
+		return false
+	}
+	if len(b.proxies) == 0 {
+		return true
+	}
+	return b.isProxy(net.ParseIP(remoteHost(r)))
+}
+
+func (b *Builder) isProxy(ip net.IP) bool {
+	if ip == nil {
+		return false
+	}
+	for _, n := range b.proxies {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+func remoteHost(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// firstValue берёт первое значение из списка через запятую, который добавляют цепочки прокси.
+func firstValue(v string) string {
+	if i := strings.IndexByte(v, ','); i >= 0 {
+		v = v[:i]
+	}
+	return strings.TrimSpace(v)
+}
+
+func validHost(host string) bool {
+	if host == "" || strings.ContainsAny(host, "/\\@ ?#") {
+		return false
+	}
+	u, err := url.Parse("http://" + host)
+	return err == nil && u.Host == host
+}
@@ -0,0 +1,93 @@
+package links
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShortURLDefaults(t *testing.T) {
+	b, err := NewBuilder(DefaultConfig())
+	assert.NoError(t, err)
+
+	assert.Equal(t, "http://localhost:8080/s/abc123", b.ShortURL(nil, "abc123"))
+	assert.Equal(t, "http://localhost:8080/api/v1/paste/hash/h1", b.URL(nil, "/api/v1/paste/hash/h1"))
+}
+
+func TestShortURLConfigured(t *testing.T) {
+	b, err := NewBuilder(Config{BaseURL: "https://paste.example.com/bin/", ShortPrefix: "/go/"})
+	assert.NoError(t, err)
+	assert.Equal(t, "https://paste.example.com/bin/go/abc123", b.ShortURL(nil, "abc123"))
+	assert.Equal(t, "paste.example.com", b.Host())
+
+	b, err = NewBuilder(Config{BaseURL: "https://paste.example.com", ShortDomains: []string{"https://pst.io"}})
+	assert.NoError(t, err)
+	assert.Equal(t, "https://pst.io/abc123", b.ShortURL(nil, "abc123"))
+
+	r := httptest.NewRequest("GET", "/abc123", nil)
+	r.Host = "PST.io"
+	assert.True(t, b.IsShortDomain(r))
+	r.Host = "paste.example.com"
+	assert.False(t, b.IsShortDomain(r))
+}
+
+func TestForwardedHeaders(t *testing.T) {
+	r := httptest.NewRequest("GET", "/api/v1/paste", nil)
+	r.RemoteAddr = "10.0.0.5:41000"
+	r.Header.Set("X-Forwarded-Proto", "https")
+	r.Header.Set("X-Forwarded-Host", "paste.example.com, internal.lan")
+
+	ignoring, _ := NewBuilder(DefaultConfig())
+	assert.Equal(t, "http://localhost:8080/s/x", ignoring.ShortURL(r, "x"))
+
+	trusting, _ := NewBuilder(Config{BaseURL: DefaultBaseURL, TrustForwarded: true, TrustedProxies: []string{"10.0.0.0/8"}})
+	assert.Equal(t, "https://paste.example.com/s/x", trusting.ShortURL(r, "x"))
+
+	r.RemoteAddr = "203.0.113.9:41000"
+	assert.Equal(t, "http://localhost:8080/s/x", trusting.ShortURL(r, "x"))
+
+	anyProxy, _ := NewBuilder(Config{BaseURL: DefaultBaseURL, TrustForwarded: true})
+	r.Header.Set("X-Forwarded-Host", "evil.com/path")
+	r.Header.Set("X-Forwarded-Proto", "javascript")
+	assert.Equal(t, "http://localhost:8080/s/x", anyProxy.ShortURL(r, "x"))
+}
+
+func TestClientIP(t *testing.T) {
+	b, _ := NewBuilder(Config{BaseURL: DefaultBaseURL, TrustForwarded: true, TrustedProxies: []string{"10.0.0.0/8"}})
+	r := httptest.NewRequest("GET", "/api/v1/paste", nil)
+	r.Header.Add("X-Forwarded-For", "192.0.2.66, 198.51.100.7")
+	r.Header.Add("X-Forwarded-For", "10.0.0.9")
+
+	r.RemoteAddr = "10.0.0.5:41000"
+	assert.Equal(t, "198.51.100.7", b.ClientIP(r), "первый адрес справа не из доверенных прокси")
+
+	r.RemoteAddr = "203.0.113.9:41000"
+	assert.Equal(t, "203.0.113.9", b.ClientIP(r), "заголовок от недоверенного адреса не учитывается")
+
+	r.RemoteAddr = "10.0.0.5:41000"
+	r.Header.Set("X-Forwarded-For", "not-an-ip")
+	assert.Equal(t, "10.0.0.5", b.ClientIP(r))
+
+	anyProxy, _ := NewBuilder(Config{BaseURL: DefaultBaseURL, TrustForwarded: true})
+	r.Header.Set("X-Forwarded-For", "192.0.2.66")
+	assert.Equal(t, "10.0.0.5", anyProxy.ClientIP(r), "без списка прокси X-Forwarded-For не принимается")
+}
+
+func TestNewBuilderValidates(t *testing.T) {
+	for _, cfg := range []Config{
+		{BaseURL: "localhost:8080"},
+		{BaseURL: "ftp://example.com"},
+		{BaseURL: "https://user:pw@example.com"},
+		{BaseURL: DefaultBaseURL, ShortPrefix: "s"},
+		{BaseURL: DefaultBaseURL, ShortPrefix: "/"},
+		{BaseURL: DefaultBaseURL, ShortDomains: []string{"pst.io"}},
+		{BaseURL: DefaultBaseURL, TrustedProxies: []string{"not-an-ip"}},
+	} {
+		_, err := NewBuilder(cfg)
+		assert.ErrorIs(t, err, ErrInvalidConfig, cfg)
+	}
+
+	_, err := NewBuilder(Config{BaseURL: DefaultBaseURL, TrustedProxies: []string{"127.0.0.1", "::1"}})
+	assert.NoError(t, err)
+}
@@ -0,0 +1,25 @@
+package logging
+
+import (
+	"context"
+
+	"github.com/GritsyukLeonid/pastebin-go/internal/repository"
+)
+
+// AuditLogger пишет изменения в постоянный журнал аудита. Исполнитель и request ID
+// берутся из контекста запроса; изменения вне запроса (фоновые задачи) записываются от system.
+type AuditLogger struct {
+	store repository.AuditStorage
+}
+
+func NewAuditLogger(store repository.AuditStorage) *AuditLogger {
+	return &AuditLogger{store: store}
+}
+
+func (l *AuditLogger) LogChange(ctx context.Context, entity, id, action string, before, after any) error {
+	entry, err := newEntry(ctx, entity, id, action, before, after)
+	if err != nil {
+		return err
+	}
+	return l.store.AppendAudit(ctx, entry)
+}
@@ -0,0 +1,204 @@
+package logging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/GritsyukLeonid/pastebin-go/internal/metrics"
+)
+
+// Приёмники журнала изменений для конфигурации change_log.sinks.
+const (
+	SinkAudit  = "audit"
+	SinkRedis  = "redis"
+	SinkStdout = "stdout"
+	SinkFile   = "file"
+	SinkNone   = "none"
+)
+
+var (
+	ErrChangeDropped = errors.New("change log queue is full, event dropped")
+	ErrFanOutClosed  = errors.New("change log is closed")
+)
+
+// sinkTimeout ограничивает одну запись в приёмник, чтобы зависший приёмник не останавливал очередь.
+const sinkTimeout = 5 * time.Second
+
+// FanOut рассылает изменения в несколько приёмников. Приёмники из Add асинхронные: у каждого
+// своя очередь на buffer событий и своя горутина, поэтому медленный или недоступный приёмник
+// (например, Redis) не задерживает запрос и не мешает остальным. Если очередь заполнена,
+// событие для этого приёмника отбрасывается, а LogChange возвращает ErrChangeDropped.
+// Приёмники из AddSync (журнал аудита) пишутся в LogChange и событий не теряют.
+type FanOut struct {
+	buffer int
+	sinks  []*sink
+	wg     sync.WaitGroup
+
+	mu     sync.RWMutex
+	closed bool
+}
+
+type sink struct {
+	name    string
+	logger  Logger
+	queue   chan change
+	dropped atomic.Uint64
+	failing atomic.Bool
+	// sync — запись в LogChange, без очереди.
+	sync bool
+}
+
+type change struct {
+	ctx                context.Context
+	entity, id, action string
+	before, after      any
+}
+
+func NewFanOut(buffer int) *FanOut {
+	return &FanOut{buffer: buffer}
+}
+
+// Add подключает приёмник name и запускает его обработчик. Вызывается до первого LogChange.
+func (f *FanOut) Add(name string, logger Logger) *FanOut {
+	s := &sink{name: name, logger: logger, queue: make(chan change, f.buffer)}
+	f.sinks = append(f.sinks, s)
+	f.wg.Add(1)
+	go func() {
+		defer f.wg.Done()
+		s.run()
+	}()
+	return f
+}
+
+// AddSync подключает приёмник name, в который LogChange пишет сам, дожидаясь записи.
+// Вызывается до первого LogChange.
+func (f *FanOut) AddSync(name string, logger Logger) *FanOut {
+	f.sinks = append(f.sinks, &sink{name: name, logger: logger, sync: true})
+	return f
+}
+
+// LogChange ставит изменение в очереди асинхронных приёмников, затем пишет его в синхронные
+// и возвращает их ошибки. Запрос может завершиться раньше записи в очереди: контекст теряет
+// отмену, но сохраняет request ID, пользователя и спан.
+func (f *FanOut) LogChange(ctx context.Context, entity, id, action string, before, after any) error {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	if f.closed {
+		return ErrFanOutClosed
+	}
+
+	c := change{
+		ctx:    context.WithValue(context.WithoutCancel(ctx), changedAtKey{}, time.Now().UTC()),
+		entity: entity, id: id, action: action,
+		before: before, after: after,
+	}
+	var dropped []string
+	for _, s := range f.sinks {
+		if s.sync {
+			continue
+		}
+		select {
+		case s.queue <- c:
+		default:
+			s.dropped.Add(1)
+			metrics.ChangeLogEvent(s.name, metrics.ChangeLogDropped)
+			dropped = append(dropped, s.name)
+		}
+	}
+	var errs []error
+	if len(dropped) > 0 {
+		errs = append(errs, fmt.Errorf("%w: %s", ErrChangeDropped, strings.Join(dropped, ", ")))
+	}
+	for _, s := range f.sinks {
+		if s.sync {
+			if err := s.write(c); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", s.name, err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Dropped возвращает число отброшенных событий по приёмникам.
+func (f *FanOut) Dropped() map[string]uint64 {
+	out := make(map[string]uint64, len(f.sinks))
+	for _, s := range f.sinks {
+		out[s.name] = s.dropped.Load()
+	}
+	return out
+}
+
+// Close перестаёт принимать изменения и ждёт, пока приёмники запишут очереди, или отмены ctx.
+// Приёмники с методом Close (FileLogger) закрываются после своей очереди, синхронные — сразу.
+func (f *FanOut) Close(ctx context.Context) error {
+	f.mu.Lock()
+	if !f.closed {
+		f.closed = true
+		for _, s := range f.sinks {
+			if s.sync {
+				s.close()
+				continue
+			}
+			close(s.queue)
+		}
+	}
+	f.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		f.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s *sink) run() {
+	for c := range s.queue {
+		_ = s.write(c)
+	}
+	s.close()
+}
+
+func (s *sink) write(c change) error {
+	ctx, cancel := context.WithTimeout(c.ctx, sinkTimeout)
+	err := s.logger.LogChange(ctx, c.entity, c.id, c.action, c.before, c.after)
+	cancel()
+	s.report(c.ctx, err)
+	return err
+}
+
+func (s *sink) close() {
+	if closer, ok := s.logger.(io.Closer); ok {
+		if err := closer.Close(); err != nil {
+			slog.Warn("failed to close change log sink", "sink", s.name, "error", err)
+		}
+	}
+}
+
+// report считает результат записи и пишет в журнал только смену состояния приёмника,
+// чтобы недоступный приёмник не порождал строку на каждое событие.
+func (s *sink) report(ctx context.Context, err error) {
+	if err != nil {
+		metrics.ChangeLogEvent(s.name, metrics.ChangeLogFailed)
+		if s.failing.CompareAndSwap(false, true) {
+			slog.WarnContext(ctx, "change log sink failing", "sink", s.name, "error", err)
+		}
+		return
+	}
+	metrics.ChangeLogEvent(s.name, metrics.ChangeLogWritten)
+	if s.failing.CompareAndSwap(true, false) {
+		slog.InfoContext(ctx, "change log sink recovered", "sink", s.name)
+	}
+}
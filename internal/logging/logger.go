@@ -0,0 +1,78 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/GritsyukLeonid/pastebin-go/internal/events"
+	"github.com/GritsyukLeonid/pastebin-go/internal/model"
+	"github.com/GritsyukLeonid/pastebin-go/internal/reqctx"
+)
+
+// Logger записывает изменение сущности. before и after — краткие описания сущности
+// до и после изменения (сериализуются в JSON), nil — сущности не было или больше нет.
+type Logger interface {
+	LogChange(ctx context.Context, entity, id, action string, before, after any) error
+}
+
+// Actor возвращает исполнителя изменения из контекста запроса.
+func Actor(ctx context.Context) string {
+	if user := reqctx.User(ctx); user != "" {
+		return user
+	}
+	if reqctx.RequestID(ctx) != "" {
+		return model.ActorAnonymous
+	}
+	return model.ActorSystem
+}
+
+// newEntry собирает запись журнала: исполнитель и request ID берутся из ctx.
+func newEntry(ctx context.Context, entity, id, action string, before, after any) (model.AuditEntry, error) {
+	entry := model.AuditEntry{
+		Entity:    entity,
+		EntityID:  id,
+		Action:    action,
+		Actor:     Actor(ctx),
+		RequestID: reqctx.RequestID(ctx),
+		At:        changedAt(ctx),
+	}
+	var err error
+	if entry.Before, err = summary(before); err != nil {
+		return model.AuditEntry{}, err
+	}
+	if entry.After, err = summary(after); err != nil {
+		return model.AuditEntry{}, err
+	}
+	return entry, nil
+}
+
+func summary(v any) (json.RawMessage, error) {
+	if v == nil {
+		return nil, nil
+	}
+	b, err := json.Marshal(v)
+	if err != nil || bytes.Equal(b, []byte("null")) {
+		return nil, err
+	}
+	return b, nil
+}
+
+type changedAtKey struct{}
+
+// changedAt — время изменения. FanOut фиксирует его при постановке в очередь,
+// чтобы задержка записи в приёмник не сдвигала время в журнале.
+func changedAt(ctx context.Context) time.Time {
+	if t, ok := ctx.Value(changedAtKey{}).(time.Time); ok {
+		return t
+	}
+	return time.Now().UTC()
+}
+
+// Subscriber передаёт события изменений в журнал: сущность и действие берутся из типа события.
+func Subscriber(l Logger) events.Handler {
+	return func(ctx context.Context, e events.Event) error {
+		return l.LogChange(ctx, e.Type.Entity(), e.EntityID, e.Type.Action(), e.Before, e.After)
+	}
+}
@@ -0,0 +1,299 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/GritsyukLeonid/pastebin-go/internal/model"
+	"github.com/GritsyukLeonid/pastebin-go/internal/reqctx"
+)
+
+func captureLogs(t *testing.T) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	logger, err := NewSlogLogger(&buf, FormatJSON, "info")
+	require.NoError(t, err)
+	prev := slog.Default()
+	slog.SetDefault(logger)
+	t.Cleanup(func() { slog.SetDefault(prev) })
+	return &buf
+}
+
+func decodeLines(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	t.Helper()
+	var lines []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var entry map[string]any
+		require.NoError(t, json.Unmarshal([]byte(line), &entry), line)
+		lines = append(lines, entry)
+	}
+	return lines
+}
+
+func TestHTTPMiddlewareLogsRequest(t *testing.T) {
+	buf := captureLogs(t)
+
+	router := mux.NewRouter()
+	router.HandleFunc("/api/v1/paste", func(w http.ResponseWriter, r *http.Request) {
+		reqctx.SetUser(r.Context(), "user:7")
+		slog.InfoContext(r.Context(), "paste created")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"id":"1"}`))
+	})
+	router.HandleFunc("/api/v1/paste/{id}", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "boom", http.StatusInternalServerError)
+	})
+	handler := HTTPMiddleware(router, router)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/paste", nil)
+	req.Header.Set(reqctx.RequestIDHeader, "client-id-1")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, "client-id-1", rec.Header().Get(reqctx.RequestIDHeader))
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/paste/abc", nil))
+	generated := rec.Header().Get(reqctx.RequestIDHeader)
+	assert.Len(t, generated, 32, "ошибочный ответ тоже несёт X-Request-ID")
+
+	lines := decodeLines(t, buf)
+	require.Len(t, lines, 3)
+	assert.Equal(t, "paste created", lines[0]["msg"])
+	assert.Equal(t, "client-id-1", lines[0]["request_id"])
+
+	access := lines[1]
+	assert.Equal(t, "INFO", access["level"])
+	assert.Equal(t, "POST", access["method"])
+	assert.Equal(t, "/api/v1/paste", access["route"])
+	assert.Equal(t, 201.0, access["status"])
+	assert.Equal(t, 10.0, access["bytes"])
+	assert.Equal(t, "user:7", access["user"])
+	assert.Equal(t, "client-id-1", access["request_id"])
+
+	assert.Equal(t, "ERROR", lines[2]["level"])
+	assert.Equal(t, "/api/v1/paste/{id}", lines[2]["route"])
+	assert.Equal(t, generated, lines[2]["request_id"])
+}
+
+func TestHTTPMiddlewareReplacesInvalidRequestID(t *testing.T) {
+	captureLogs(t)
+	handler := HTTPMiddleware(mux.NewRouter(), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(reqctx.RequestIDHeader, "bad\nid")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	assert.NotEqual(t, "bad\nid", rec.Header().Get(reqctx.RequestIDHeader))
+	assert.NotEmpty(t, rec.Header().Get(reqctx.RequestIDHeader))
+}
+
+func TestUnaryServerInterceptorPropagatesRequestID(t *testing.T) {
+	buf := captureLogs(t)
+	interceptor := UnaryServerInterceptor()
+	info := &grpc.UnaryServerInfo{FullMethod: "/pastebin.PasteService/GetPaste"}
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-request-id", "grpc-id-1", "x-api-key", "secret"))
+
+	_, err := interceptor(ctx, nil, info, func(ctx context.Context, req any) (any, error) {
+		assert.Equal(t, "grpc-id-1", reqctx.RequestID(ctx))
+		assert.Empty(t, reqctx.User(ctx), "непроверенный ключ не становится пользователем")
+		reqctx.SetUser(ctx, reqctx.APIKeyID("secret"))
+		return nil, status.Error(codes.Internal, "db down")
+	})
+	require.Error(t, err)
+
+	lines := decodeLines(t, buf)
+	require.Len(t, lines, 1)
+	assert.Equal(t, "ERROR", lines[0]["level"])
+	assert.Equal(t, info.FullMethod, lines[0]["method"])
+	assert.Equal(t, "Internal", lines[0]["code"])
+	assert.Equal(t, "grpc-id-1", lines[0]["request_id"])
+	assert.Equal(t, reqctx.APIKeyID("secret"), lines[0]["user"])
+}
+
+func TestNewSlogLoggerRejectsBadConfig(t *testing.T) {
+	_, err := NewSlogLogger(&bytes.Buffer{}, "xml", "info")
+	assert.ErrorIs(t, err, ErrInvalidFormat)
+	_, err = NewSlogLogger(&bytes.Buffer{}, FormatText, "loud")
+	assert.Error(t, err)
+}
+
+type memoryAudit struct {
+	entries []model.AuditEntry
+}
+
+func (m *memoryAudit) AppendAudit(_ context.Context, e model.AuditEntry) error {
+	m.entries = append(m.entries, e)
+	return nil
+}
+
+func (m *memoryAudit) ListAudit(context.Context, model.AuditFilter) ([]model.AuditEntry, error) {
+	return m.entries, nil
+}
+
+func (m *memoryAudit) DeleteAuditBefore(context.Context, time.Time) (int64, error) {
+	return 0, nil
+}
+
+func TestAuditLoggerRecordsActorAndRequest(t *testing.T) {
+	store := &memoryAudit{}
+	logger := NewAuditLogger(store)
+
+	ctx := reqctx.WithUser(reqctx.WithRequestID(context.Background(), "req-1"), "user:7")
+	var missing map[string]any
+	require.NoError(t, logger.LogChange(ctx, "paste", "42", "updated", map[string]any{"size": 3}, missing))
+	require.NoError(t, logger.LogChange(reqctx.WithRequestID(context.Background(), "req-2"), "paste", "43", "created", nil, nil))
+	require.NoError(t, logger.LogChange(context.Background(), "paste", "44", "expired", nil, nil))
+
+	require.Len(t, store.entries, 3)
+	first := store.entries[0]
+	assert.Equal(t, "user:7", first.Actor)
+	assert.Equal(t, "req-1", first.RequestID)
+	assert.JSONEq(t, `{"size":3}`, string(first.Before))
+	assert.Empty(t, first.After, "nil-описание не превращается в JSON null")
+	assert.WithinDuration(t, time.Now(), first.At, time.Minute)
+
+	assert.Equal(t, model.ActorAnonymous, store.entries[1].Actor)
+	assert.Equal(t, model.ActorSystem, store.entries[2].Actor)
+}
+
+// blockingLogger сообщает о начале каждой записи в started и держит её, пока не закрыт release.
+type blockingLogger struct {
+	started chan string
+	release chan struct{}
+}
+
+func (l *blockingLogger) LogChange(_ context.Context, entity, id, action string, _, _ any) error {
+	l.started <- id
+	<-l.release
+	return nil
+}
+
+// recordingLogger передаёт в seen request ID и ID каждой записанной сущности.
+type recordingLogger struct {
+	seen chan [2]string
+}
+
+func (l *recordingLogger) LogChange(ctx context.Context, entity, id, action string, _, _ any) error {
+	l.seen <- [2]string{reqctx.RequestID(ctx), id}
+	return nil
+}
+
+type failingLogger struct{}
+
+func (failingLogger) LogChange(context.Context, string, string, string, any, any) error {
+	return errors.New("redis: connection refused")
+}
+
+func TestFanOutDoesNotWaitForSlowSink(t *testing.T) {
+	slow := &blockingLogger{started: make(chan string, 10), release: make(chan struct{})}
+	fast := &recordingLogger{seen: make(chan [2]string, 10)}
+	changes := NewFanOut(1).Add(SinkRedis, slow).Add(SinkAudit, fast)
+
+	ctx, cancel := context.WithCancel(reqctx.WithRequestID(context.Background(), "req-1"))
+	require.NoError(t, changes.LogChange(ctx, "paste", "1", "created", nil, nil))
+	cancel()
+	assert.Equal(t, [2]string{"req-1", "1"}, <-fast.seen, "отмена запроса не мешает записи")
+	assert.Equal(t, "1", <-slow.started)
+
+	// Первое событие занимает медленный приёмник, второе ждёт в его очереди, третье не помещается.
+	require.NoError(t, changes.LogChange(context.Background(), "paste", "2", "created", nil, nil))
+	assert.Equal(t, "2", (<-fast.seen)[1])
+	err := changes.LogChange(context.Background(), "paste", "3", "created", nil, nil)
+	assert.ErrorIs(t, err, ErrChangeDropped)
+	assert.Equal(t, "change log queue is full, event dropped: redis", err.Error())
+	assert.Equal(t, "3", (<-fast.seen)[1])
+	assert.Equal(t, map[string]uint64{SinkRedis: 1, SinkAudit: 0}, changes.Dropped())
+
+	close(slow.release)
+	require.NoError(t, changes.Close(context.Background()))
+	assert.Equal(t, "2", <-slow.started)
+	assert.ErrorIs(t, changes.LogChange(context.Background(), "paste", "4", "created", nil, nil), ErrFanOutClosed)
+}
+
+func TestFanOutWritesSyncSinkBeforeReturning(t *testing.T) {
+	slow := &blockingLogger{started: make(chan string, 10), release: make(chan struct{})}
+	audit := &recordingLogger{seen: make(chan [2]string, 10)}
+	changes := NewFanOut(1).Add(SinkRedis, slow).AddSync(SinkAudit, audit)
+
+	for _, id := range []string{"1", "2", "3"} {
+		err := changes.LogChange(reqctx.WithRequestID(context.Background(), "req-"+id), "paste", id, "created", nil, nil)
+		select {
+		case got := <-audit.seen:
+			assert.Equal(t, [2]string{"req-" + id, id}, got, "аудит записан до возврата из LogChange")
+		default:
+			t.Fatalf("event %s is not in the audit sink", id)
+		}
+		switch id {
+		case "1":
+			require.NoError(t, err)
+			assert.Equal(t, "1", <-slow.started)
+		case "3":
+			assert.ErrorIs(t, err, ErrChangeDropped, "переполнена только очередь Redis")
+		}
+	}
+	assert.Equal(t, map[string]uint64{SinkRedis: 1, SinkAudit: 0}, changes.Dropped())
+
+	failing := NewFanOut(1).AddSync(SinkAudit, failingLogger{})
+	assert.ErrorContains(t, failing.LogChange(context.Background(), "paste", "1", "created", nil, nil), "audit: redis: connection refused")
+
+	close(slow.release)
+	require.NoError(t, changes.Close(context.Background()))
+}
+
+func TestFanOutReportsFailingSinkOnce(t *testing.T) {
+	buf := captureLogs(t)
+	changes := NewFanOut(10).Add(SinkRedis, failingLogger{})
+
+	for i := 0; i < 5; i++ {
+		require.NoError(t, changes.LogChange(context.Background(), "paste", "1", "updated", nil, nil))
+	}
+	require.NoError(t, changes.Close(context.Background()))
+
+	lines := decodeLines(t, buf)
+	require.Len(t, lines, 1, "недоступный приёмник не пишет строку на каждое событие")
+	assert.Equal(t, "change log sink failing", lines[0]["msg"])
+	assert.Equal(t, SinkRedis, lines[0]["sink"])
+}
+
+func TestFileLoggerRotates(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "changes.log")
+	logger, err := NewFileLogger(path, 300, 2)
+	require.NoError(t, err)
+
+	for i := 0; i < 8; i++ {
+		require.NoError(t, logger.LogChange(context.Background(), "paste", "id", "updated", map[string]int{"size": i}, nil))
+	}
+	require.NoError(t, logger.Close())
+
+	for _, name := range []string{path, path + ".1", path + ".2"} {
+		data, err := os.ReadFile(name)
+		require.NoError(t, err, name)
+		assert.LessOrEqual(t, len(data), 300)
+		for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+			var entry model.AuditEntry
+			require.NoError(t, json.Unmarshal([]byte(line), &entry))
+			assert.Equal(t, model.ActorSystem, entry.Actor)
+		}
+	}
+	_, err = os.Stat(path + ".3")
+	assert.True(t, os.IsNotExist(err), "хранится не больше двух старых файлов")
+}
@@ -0,0 +1,127 @@
+package logging
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/GritsyukLeonid/pastebin-go/internal/reqctx"
+)
+
+var requestIDKey = strings.ToLower(reqctx.RequestIDHeader)
+
+// HTTPMiddleware присваивает запросу X-Request-ID (или берёт присланный клиентом),
+// возвращает его в ответе и пишет по строке журнала на запрос: метод, маршрут, статус,
+// длительность, размер ответа и пользователя. Пробы и /metrics не журналируются.
+func HTTPMiddleware(router *mux.Router, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := reqctx.RequestIDOrNew(r.Header.Get(reqctx.RequestIDHeader))
+		w.Header().Set(reqctx.RequestIDHeader, id)
+
+		ctx := reqctx.WithRequestID(r.Context(), id)
+		// Пользователя записывает аутентификация после проверки ключа, а не присланный заголовок.
+		ctx = reqctx.WithUser(ctx, "")
+		r = r.WithContext(ctx)
+
+		start := time.Now()
+		rec := reqctx.NewStatusRecorder(w)
+		next.ServeHTTP(rec, r)
+
+		switch r.URL.Path {
+		case "/healthz", "/readyz", "/metrics":
+			return
+		}
+		level := slog.LevelInfo
+		if rec.Status() >= http.StatusInternalServerError {
+			level = slog.LevelError
+		}
+		slog.LogAttrs(ctx, level, "http request",
+			slog.String("method", r.Method),
+			slog.String("route", reqctx.Route(router, r)),
+			slog.Int("status", rec.Status()),
+			slog.Float64("duration_ms", float64(time.Since(start).Microseconds())/1000),
+			slog.Int64("bytes", rec.Bytes()),
+			slog.String("remote_ip", reqctx.RemoteIP(r)),
+		)
+	})
+}
+
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx = withGRPCRequest(ctx)
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		logGRPC(ctx, info.FullMethod, start, err)
+		return resp, err
+	}
+}
+
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx := withGRPCRequest(ss.Context())
+		start := time.Now()
+		err := handler(srv, &contextStream{ServerStream: ss, ctx: ctx})
+		logGRPC(ctx, info.FullMethod, start, err)
+		return err
+	}
+}
+
+// withGRPCRequest — аналог HTTPMiddleware для gRPC: x-request-id из метаданных
+// или новый, возвращается клиенту в заголовках ответа, в том числе при ошибке.
+func withGRPCRequest(ctx context.Context) context.Context {
+	md, _ := metadata.FromIncomingContext(ctx)
+	id := reqctx.RequestIDOrNew(first(md.Get(requestIDKey)))
+	_ = grpc.SetHeader(ctx, metadata.Pairs(requestIDKey, id))
+
+	ctx = reqctx.WithRequestID(ctx, id)
+	return reqctx.WithUser(ctx, "")
+}
+
+func logGRPC(ctx context.Context, method string, start time.Time, err error) {
+	code := status.Code(err)
+	level := slog.LevelInfo
+	switch code {
+	case codes.OK, codes.Canceled, codes.NotFound, codes.InvalidArgument, codes.AlreadyExists,
+		codes.PermissionDenied, codes.Unauthenticated, codes.ResourceExhausted, codes.FailedPrecondition:
+	default:
+		level = slog.LevelError
+	}
+	attrs := []slog.Attr{
+		slog.String("method", method),
+		slog.String("code", code.String()),
+		slog.Float64("duration_ms", float64(time.Since(start).Microseconds())/1000),
+	}
+	if p, ok := peer.FromContext(ctx); ok {
+		attrs = append(attrs, slog.String("peer", p.Addr.String()))
+	}
+	if err != nil {
+		attrs = append(attrs, slog.String("error", status.Convert(err).Message()))
+	}
+	slog.LogAttrs(ctx, level, "grpc request", attrs...)
+}
+
+// contextStream подменяет контекст потока, чтобы обработчик видел request ID.
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
+
+func first(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
@@ -2,10 +2,14 @@ package logging
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"time"
 
 	"github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/GritsyukLeonid/pastebin-go/internal/tracing"
 )
 
 type RedisLogger struct {
@@ -13,10 +17,6 @@ type RedisLogger struct {
 	ttl    time.Duration
 }
 
-type Logger interface {
-	LogChange(entity, id, action string) error
-}
-
 func NewRedisLogger(addr string, ttl time.Duration) *RedisLogger {
 	client := redis.NewClient(&redis.Options{
 		Addr: addr,
@@ -27,9 +27,31 @@ func NewRedisLogger(addr string, ttl time.Duration) *RedisLogger {
 	}
 }
 
-func (r *RedisLogger) LogChange(entity, id, action string) error {
-	ctx := context.Background()
-	key := fmt.Sprintf("log:%s:%s:%d", entity, id, time.Now().Unix())
-	value := action
-	return r.client.Set(ctx, key, value, r.ttl).Err()
+// LogChange хранит запись в JSON под ключом log:{entity}:{id}:{unixnano} в течение ttl.
+func (r *RedisLogger) LogChange(ctx context.Context, entity, id, action string, before, after any) error {
+	ctx, span := tracing.Start(ctx, "RedisLogger.LogChange",
+		attribute.String("db.system.name", "redis"),
+		attribute.String("pastebin.entity", entity),
+		attribute.String("pastebin.action", action),
+	)
+	defer span.End()
+
+	entry, err := newEntry(ctx, entity, id, action, before, after)
+	if err != nil {
+		return err
+	}
+	value, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	// Наносекунды в ключе: два изменения в одну секунду не перезаписывают друг друга.
+	key := fmt.Sprintf("log:%s:%s:%d", entity, id, entry.At.UnixNano())
+	err = r.client.Set(ctx, key, value, r.ttl).Err()
+	tracing.Fail(span, err)
+	return err
+}
+
+// Ping проверяет соединение с Redis.
+func (r *RedisLogger) Ping(ctx context.Context) error {
+	return r.client.Ping(ctx).Err()
 }
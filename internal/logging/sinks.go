@@ -0,0 +1,115 @@
+package logging
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+// JSONLogger пишет каждое изменение отдельной строкой JSON, например в stdout.
+type JSONLogger struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func NewJSONLogger(w io.Writer) *JSONLogger {
+	return &JSONLogger{w: w}
+}
+
+func (l *JSONLogger) LogChange(ctx context.Context, entity, id, action string, before, after any) error {
+	entry, err := newEntry(ctx, entity, id, action, before, after)
+	if err != nil {
+		return err
+	}
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	_, err = l.w.Write(append(line, '\n'))
+	return err
+}
+
+// FileLogger — JSONLogger в локальный файл с ротацией: когда файл превышает maxBytes,
+// он переименовывается в path.1 (старые копии сдвигаются до path.{backups}), и запись
+// продолжается в новый файл.
+type FileLogger struct {
+	*JSONLogger
+	file *rotatingFile
+}
+
+func NewFileLogger(path string, maxBytes int64, backups int) (*FileLogger, error) {
+	f := &rotatingFile{path: path, maxBytes: maxBytes, backups: backups}
+	if err := f.open(); err != nil {
+		return nil, err
+	}
+	return &FileLogger{JSONLogger: NewJSONLogger(f), file: f}, nil
+}
+
+func (l *FileLogger) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.file.Close()
+}
+
+type rotatingFile struct {
+	path     string
+	maxBytes int64
+	backups  int
+	f        *os.File
+	size     int64
+}
+
+func (r *rotatingFile) open() error {
+	f, err := os.OpenFile(r.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+	r.f, r.size = f, info.Size()
+	return nil
+}
+
+func (r *rotatingFile) Write(p []byte) (int, error) {
+	if r.maxBytes > 0 && r.size > 0 && r.size+int64(len(p)) > r.maxBytes {
+		if err := r.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := r.f.Write(p)
+	r.size += int64(n)
+	return n, err
+}
+
+func (r *rotatingFile) rotate() error {
+	if err := r.f.Close(); err != nil {
+		return err
+	}
+	if r.backups > 0 {
+		for i := r.backups - 1; i > 0; i-- {
+			_ = os.Rename(backupName(r.path, i), backupName(r.path, i+1))
+		}
+		if err := os.Rename(r.path, backupName(r.path, 1)); err != nil {
+			return err
+		}
+	} else if err := os.Remove(r.path); err != nil {
+		return err
+	}
+	return r.open()
+}
+
+func (r *rotatingFile) Close() error {
+	return r.f.Close()
+}
+
+func backupName(path string, n int) string {
+	return fmt.Sprintf("%s.%d", path, n)
+}
@@ -0,0 +1,68 @@
+package logging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/GritsyukLeonid/pastebin-go/internal/reqctx"
+)
+
+const (
+	FormatJSON = "json"
+	FormatText = "text"
+)
+
+var ErrInvalidFormat = errors.New("log format must be json or text")
+
+// NewSlogLogger создаёт журнал в формате json или text с уровнем level (debug, info, warn, error).
+// К каждой записи с контекстом добавляются request_id, user и trace_id текущего запроса.
+func NewSlogLogger(w io.Writer, format, level string) (*slog.Logger, error) {
+	var lvl slog.Level
+	if err := lvl.UnmarshalText([]byte(level)); err != nil {
+		return nil, fmt.Errorf("log level: %w", err)
+	}
+	opts := &slog.HandlerOptions{Level: lvl}
+
+	var h slog.Handler
+	switch strings.ToLower(format) {
+	case FormatJSON:
+		h = slog.NewJSONHandler(w, opts)
+	case FormatText:
+		h = slog.NewTextHandler(w, opts)
+	default:
+		return nil, ErrInvalidFormat
+	}
+	return slog.New(contextHandler{h}), nil
+}
+
+// contextHandler дописывает в запись поля запроса из context.Context.
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h contextHandler) Handle(ctx context.Context, r slog.Record) error {
+	if id := reqctx.RequestID(ctx); id != "" {
+		r.AddAttrs(slog.String("request_id", id))
+	}
+	if user := reqctx.User(ctx); user != "" {
+		r.AddAttrs(slog.String("user", user))
+	}
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		r.AddAttrs(slog.String("trace_id", sc.TraceID().String()))
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return contextHandler{h.Handler.WithAttrs(attrs)}
+}
+
+func (h contextHandler) WithGroup(name string) slog.Handler {
+	return contextHandler{h.Handler.WithGroup(name)}
+}
@@ -0,0 +1,26 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/GritsyukLeonid/pastebin-go/internal/changefeed"
+	"github.com/GritsyukLeonid/pastebin-go/internal/config"
+	"github.com/GritsyukLeonid/pastebin-go/internal/feed"
+	"github.com/GritsyukLeonid/pastebin-go/internal/grpc/grpcserver"
+	"github.com/GritsyukLeonid/pastebin-go/internal/ratelimit"
+	"github.com/GritsyukLeonid/pastebin-go/internal/service"
+)
+
+// config не импортирует настраиваемые пакеты, поэтому его значения по умолчанию сверяются здесь.
+func TestConfigDefaultsMatchPackages(t *testing.T) {
+	cfg := config.Default()
+
+	assert.Equal(t, grpcserver.DefaultConfig(), grpcserver.ConfigFrom(cfg.GRPC))
+	assert.Equal(t, ratelimit.DefaultPolicy(), ratelimit.PolicyFrom(cfg.RateLimit))
+	assert.Equal(t, service.DefaultQuotaConfig(), service.QuotaConfigFrom(cfg.Quota))
+	assert.Equal(t, service.DefaultWebhookConfig(), service.WebhookConfigFrom(cfg.Webhooks))
+	assert.Equal(t, feed.DefaultConfig(), feed.ConfigFrom(cfg.Feed))
+	assert.Equal(t, changefeed.DefaultConfig(), changefeed.ConfigFrom(cfg.ChangeFeed))
+}
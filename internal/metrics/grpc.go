@@ -0,0 +1,33 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		observeGRPC(info.FullMethod, start, err)
+		return resp, err
+	}
+}
+
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		err := handler(srv, ss)
+		observeGRPC(info.FullMethod, start, err)
+		return err
+	}
+}
+
+func observeGRPC(method string, start time.Time, err error) {
+	code := status.Code(err).String()
+	grpcRequests.WithLabelValues(method, code).Inc()
+	grpcDuration.WithLabelValues(method, code).Observe(time.Since(start).Seconds())
+}
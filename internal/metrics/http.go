@@ -0,0 +1,26 @@
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/GritsyukLeonid/pastebin-go/internal/reqctx"
+)
+
+// InstrumentHTTP считает запросы и их длительность по шаблону маршрута router (/api/v1/paste/{id}).
+// Оборачивает всю цепочку, поэтому учитываются и ответы middleware, например 429.
+func InstrumentHTTP(router *mux.Router, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		route := reqctx.Route(router, r)
+		start := time.Now()
+		rec := reqctx.NewStatusRecorder(w)
+		next.ServeHTTP(rec, r)
+
+		status := strconv.Itoa(rec.Status())
+		httpRequests.WithLabelValues(r.Method, route, status).Inc()
+		httpDuration.WithLabelValues(r.Method, route, status).Observe(time.Since(start).Seconds())
+	})
+}
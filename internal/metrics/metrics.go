@@ -0,0 +1,278 @@
+// Package metrics собирает метрики Prometheus: HTTP и gRPC запросы, время запросов
+// к хранилищу и бизнес-события. Все метрики регистрируются в Registry и отдаются Handler.
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/GritsyukLeonid/pastebin-go/internal/model"
+)
+
+const namespace = "pastebin"
+
+// Результаты перехода по короткой ссылке для ShortLinkResolved.
+const (
+	ResolveRedirect = "redirect"
+	ResolvePaste    = "paste"
+	ResolveNotFound = "not_found"
+	ResolveGone     = "gone"
+)
+
+var Registry = prometheus.NewRegistry()
+
+var (
+	httpRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "HTTP requests by method, route template and status code.",
+	}, []string{"method", "route", "status"})
+
+	httpDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "HTTP request latency by method, route template and status code.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	grpcRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "grpc_requests_total",
+		Help:      "gRPC calls by full method and status code.",
+	}, []string{"method", "code"})
+
+	grpcDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "grpc_request_duration_seconds",
+		Help:      "gRPC call latency by full method and status code.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "code"})
+
+	storageDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "storage_query_duration_seconds",
+		Help:      "PostgresStorage call latency by method.",
+		Buckets:   []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+	}, []string{"method"})
+
+	pastesCreated = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "pastes_created_total",
+		Help:      "Pastes created.",
+	})
+
+	pastesExpired = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "pastes_expired_total",
+		Help:      "Expired pastes removed by the cleanup sweeper.",
+	})
+
+	shortLinkResolutions = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "shortlink_resolutions_total",
+		Help:      "Short link resolutions by result: redirect, paste, not_found or gone.",
+	}, []string{"result"})
+
+	clickRecordFailures = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "click_record_failures_total",
+		Help:      "Short link clicks that could not be saved for analytics.",
+	})
+
+	clicksDropped = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "clicks_dropped_total",
+		Help:      "Short link clicks dropped because the analytics queue was full.",
+	})
+
+	changeLogWritten = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "change_log_events_total",
+		Help:      "Change log events by sink and outcome: written, failed or dropped (queue full).",
+	}, []string{"sink", "outcome"})
+
+	eventsPublished = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "events_published_total",
+		Help:      "Domain events published by type.",
+	}, []string{"type"})
+
+	eventDeliveries = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "event_deliveries_total",
+		Help:      "Domain event deliveries by subscriber and outcome: delivered, failed or dropped (queue full).",
+	}, []string{"subscriber", "outcome"})
+
+	webhookAttempts = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "webhook_attempts_total",
+		Help:      "Webhook delivery attempts by outcome: succeeded, failed (will retry) or dead (retries exhausted).",
+	}, []string{"outcome"})
+
+	feedSubscribers = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "feed_subscribers",
+		Help:      "Connected live paste feed subscribers (SSE and gRPC WatchPastes).",
+	})
+
+	feedSlowConsumers = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "feed_slow_consumer_disconnects_total",
+		Help:      "Live paste feed subscribers disconnected because their queue was full.",
+	})
+
+	changeNotifications = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "change_notifications_total",
+		Help:      "Change notifications received from PostgreSQL by type; invalid payloads are counted as type=invalid.",
+	}, []string{"type"})
+
+	changeResyncs = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "change_resyncs_total",
+		Help:      "Reconnections of the PostgreSQL change listener; notifications sent while disconnected are lost.",
+	})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		httpRequests, httpDuration,
+		grpcRequests, grpcDuration,
+		storageDuration,
+		pastesCreated, pastesExpired, shortLinkResolutions, clickRecordFailures, clicksDropped,
+		changeLogWritten,
+		eventsPublished, eventDeliveries,
+		webhookAttempts,
+		feedSubscribers, feedSlowConsumers,
+		changeNotifications, changeResyncs,
+	)
+}
+
+// Handler отдаёт метрики в формате Prometheus.
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{Registry: Registry})
+}
+
+// ObserveQuery записывает длительность вызова хранилища:
+//
+//	defer metrics.ObserveQuery("GetPasteByID", time.Now())
+func ObserveQuery(method string, start time.Time) {
+	storageDuration.WithLabelValues(method).Observe(time.Since(start).Seconds())
+}
+
+func PasteCreated() {
+	pastesCreated.Inc()
+}
+
+func PastesExpired(n int64) {
+	pastesExpired.Add(float64(n))
+}
+
+func ShortLinkResolved(result string) {
+	shortLinkResolutions.WithLabelValues(result).Inc()
+}
+
+func ClickRecordFailed() {
+	clickRecordFailures.Inc()
+}
+
+func ClickDropped() {
+	clicksDropped.Inc()
+}
+
+// Исходы записи события журнала изменений для ChangeLogEvent.
+const (
+	ChangeLogWritten = "written"
+	ChangeLogFailed  = "failed"
+	ChangeLogDropped = "dropped"
+)
+
+func ChangeLogEvent(sink, outcome string) {
+	changeLogWritten.WithLabelValues(sink, outcome).Inc()
+}
+
+// Исходы доставки доменного события подписчику для EventDelivery.
+const (
+	EventDelivered = "delivered"
+	EventFailed    = "failed"
+	EventDropped   = "dropped"
+)
+
+func EventPublished(eventType string) {
+	eventsPublished.WithLabelValues(eventType).Inc()
+}
+
+func EventDelivery(subscriber, outcome string) {
+	eventDeliveries.WithLabelValues(subscriber, outcome).Inc()
+}
+
+// Исходы попытки доставки вебхука для WebhookAttempt.
+const (
+	WebhookSucceeded = "succeeded"
+	WebhookFailed    = "failed"
+	WebhookDead      = "dead"
+)
+
+func WebhookAttempt(outcome string) {
+	webhookAttempts.WithLabelValues(outcome).Inc()
+}
+
+func FeedSubscribers(delta float64) {
+	feedSubscribers.Add(delta)
+}
+
+func FeedSlowConsumer() {
+	feedSlowConsumers.Inc()
+}
+
+func ChangeNotification(typ string) {
+	changeNotifications.WithLabelValues(typ).Inc()
+}
+
+func ChangeResync() {
+	changeResyncs.Inc()
+}
+
+// PasteTotals возвращает число живых паст и их суммарный размер (LivePastes и StoredBytes).
+type PasteTotals func(ctx context.Context) (*model.Usage, error)
+
+// RegisterPasteTotals добавляет датчики pastebin_live_pastes и pastebin_stored_bytes,
+// которые считаются при каждом опросе /metrics.
+func RegisterPasteTotals(totals PasteTotals) error {
+	return Registry.Register(&totalsCollector{totals: totals})
+}
+
+var (
+	livePastesDesc  = prometheus.NewDesc(namespace+"_live_pastes", "Pastes that have not expired yet.", nil, nil)
+	storedBytesDesc = prometheus.NewDesc(namespace+"_stored_bytes", "Total size of live paste contents in bytes.", nil, nil)
+)
+
+type totalsCollector struct {
+	totals PasteTotals
+}
+
+func (c *totalsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- livePastesDesc
+	ch <- storedBytesDesc
+}
+
+func (c *totalsCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	u, err := c.totals(ctx)
+	if err != nil {
+		ch <- prometheus.NewInvalidMetric(livePastesDesc, err)
+		ch <- prometheus.NewInvalidMetric(storedBytesDesc, err)
+		return
+	}
+	ch <- prometheus.MustNewConstMetric(livePastesDesc, prometheus.GaugeValue, float64(u.LivePastes))
+	ch <- prometheus.MustNewConstMetric(storedBytesDesc, prometheus.GaugeValue, float64(u.StoredBytes))
+}
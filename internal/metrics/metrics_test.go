@@ -0,0 +1,95 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/GritsyukLeonid/pastebin-go/internal/model"
+)
+
+func TestInstrumentHTTPUsesRouteTemplate(t *testing.T) {
+	router := mux.NewRouter()
+	router.HandleFunc("/api/v1/paste/{id}", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "not found", http.StatusNotFound)
+	}).Methods(http.MethodGet)
+	router.HandleFunc("/api/v1/paste/{id}/stream", func(w http.ResponseWriter, r *http.Request) {
+		_, ok := w.(http.Flusher)
+		assert.True(t, ok, "потоковым ответам нужен http.Flusher")
+	}).Methods(http.MethodGet)
+	handler := InstrumentHTTP(router, router)
+
+	for _, path := range []string{"/api/v1/paste/a1", "/api/v1/paste/b2", "/api/v1/paste/a1/stream", "/no/such/route"} {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	assert.Equal(t, 2.0, testutil.ToFloat64(httpRequests.WithLabelValues("GET", "/api/v1/paste/{id}", "404")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(httpRequests.WithLabelValues("GET", "/api/v1/paste/{id}/stream", "200")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(httpRequests.WithLabelValues("GET", "unmatched", "404")))
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	interceptor := UnaryServerInterceptor()
+	info := &grpc.UnaryServerInfo{FullMethod: "/pastebin.PasteService/GetPaste"}
+
+	_, err := interceptor(context.Background(), nil, info, func(ctx context.Context, req any) (any, error) {
+		return nil, status.Error(codes.NotFound, "paste not found")
+	})
+	assert.Error(t, err)
+	_, err = interceptor(context.Background(), nil, info, func(ctx context.Context, req any) (any, error) {
+		return "ok", nil
+	})
+	assert.NoError(t, err)
+
+	assert.Equal(t, 1.0, testutil.ToFloat64(grpcRequests.WithLabelValues(info.FullMethod, "NotFound")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(grpcRequests.WithLabelValues(info.FullMethod, "OK")))
+}
+
+func TestBusinessMetricsAndTotals(t *testing.T) {
+	PasteCreated()
+	PastesExpired(3)
+	ShortLinkResolved(ResolveGone)
+	ObserveQuery("SavePaste", time.Now())
+
+	assert.Equal(t, 1.0, testutil.ToFloat64(pastesCreated))
+	assert.Equal(t, 3.0, testutil.ToFloat64(pastesExpired))
+	assert.Equal(t, 1.0, testutil.ToFloat64(shortLinkResolutions.WithLabelValues(ResolveGone)))
+	assert.Equal(t, 1, testutil.CollectAndCount(storageDuration))
+
+	totals := &totalsCollector{totals: func(ctx context.Context) (*model.Usage, error) {
+		return &model.Usage{LivePastes: 4, StoredBytes: 2048}, nil
+	}}
+	expected := `
+# HELP pastebin_live_pastes Pastes that have not expired yet.
+# TYPE pastebin_live_pastes gauge
+pastebin_live_pastes 4
+# HELP pastebin_stored_bytes Total size of live paste contents in bytes.
+# TYPE pastebin_stored_bytes gauge
+pastebin_stored_bytes 2048
+`
+	require.NoError(t, testutil.CollectAndCompare(totals, strings.NewReader(expected)))
+
+	failing := &totalsCollector{totals: func(ctx context.Context) (*model.Usage, error) {
+		return nil, errors.New("db down")
+	}}
+	assert.Error(t, testutil.CollectAndCompare(failing, strings.NewReader("")))
+}
+
+func TestHandlerServesRegistry(t *testing.T) {
+	rec := httptest.NewRecorder()
+	Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "go_goroutines")
+}
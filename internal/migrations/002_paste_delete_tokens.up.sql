@@ -0,0 +1,3 @@
+-- +migrate Up
+
+ALTER TABLE pastes ADD COLUMN IF NOT EXISTS delete_token_hash TEXT NOT NULL DEFAULT '';
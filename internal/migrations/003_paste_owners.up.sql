@@ -0,0 +1,7 @@
+-- +migrate Up
+
+ALTER TABLE pastes ADD COLUMN IF NOT EXISTS user_id BIGINT;
+ALTER TABLE pastes ADD COLUMN IF NOT EXISTS client_ip TEXT NOT NULL DEFAULT '';
+
+CREATE INDEX IF NOT EXISTS pastes_user_id_idx ON pastes (user_id);
+CREATE INDEX IF NOT EXISTS pastes_client_ip_idx ON pastes (client_ip);
@@ -0,0 +1,6 @@
+-- +migrate Up
+
+ALTER TABLE shorturls ADD COLUMN IF NOT EXISTS target_type TEXT NOT NULL DEFAULT 'paste';
+ALTER TABLE shorturls ADD COLUMN IF NOT EXISTS permanent BOOLEAN NOT NULL DEFAULT FALSE;
+-- Хэш токена удаления ссылки на внешний адрес; ссылки на пасты удаляются токеном пасты.
+ALTER TABLE shorturls ADD COLUMN IF NOT EXISTS delete_token_hash TEXT NOT NULL DEFAULT '';
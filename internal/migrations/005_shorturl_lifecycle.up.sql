@@ -0,0 +1,12 @@
+-- +migrate Up
+
+ALTER TABLE shorturls ADD COLUMN IF NOT EXISTS expires_at TIMESTAMPTZ;
+ALTER TABLE shorturls ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS shorturls_original_idx ON shorturls (original);
+
+-- Ссылки, чьи пасты уже удалены, становятся «надгробиями».
+UPDATE shorturls SET deleted_at = NOW()
+WHERE target_type = 'paste'
+  AND deleted_at IS NULL
+  AND NOT EXISTS (SELECT 1 FROM pastes WHERE pastes.hash = shorturls.original);
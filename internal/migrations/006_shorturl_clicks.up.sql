@@ -0,0 +1,34 @@
+-- +migrate Up
+
+CREATE TABLE IF NOT EXISTS shorturl_clicks (
+    id BIGSERIAL PRIMARY KEY,
+    short_id TEXT NOT NULL,
+    clicked_at TIMESTAMPTZ NOT NULL,
+    referrer_host TEXT NOT NULL DEFAULT '',
+    agent_class TEXT NOT NULL DEFAULT '',
+    country TEXT NOT NULL DEFAULT '',
+    ip_hash TEXT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS shorturl_clicks_short_id_idx ON shorturl_clicks (short_id, clicked_at);
+CREATE INDEX IF NOT EXISTS shorturl_clicks_clicked_at_idx ON shorturl_clicks (clicked_at);
+
+-- Прореженные события: сырые переходы старше срока хранения сворачиваются по дням (UTC).
+CREATE TABLE IF NOT EXISTS shorturl_clicks_daily (
+    short_id TEXT NOT NULL,
+    day DATE NOT NULL,
+    referrer_host TEXT NOT NULL,
+    agent_class TEXT NOT NULL,
+    country TEXT NOT NULL,
+    clicks BIGINT NOT NULL,
+    PRIMARY KEY (short_id, day, referrer_host, agent_class, country)
+);
+
+-- Уникальные посетители по дням: хэши IP без остальных полей перехода. Пополняется при каждом
+-- переходе и хранится не дольше сырых событий, поэтому посетитель за период считается один раз.
+CREATE TABLE IF NOT EXISTS shorturl_visitor_days (
+    short_id TEXT NOT NULL,
+    day DATE NOT NULL,
+    ip_hash TEXT NOT NULL,
+    PRIMARY KEY (short_id, day, ip_hash)
+);
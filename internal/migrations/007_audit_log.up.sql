@@ -0,0 +1,17 @@
+-- +migrate Up
+
+CREATE TABLE IF NOT EXISTS audit_log (
+    id BIGSERIAL PRIMARY KEY,
+    entity TEXT NOT NULL,
+    entity_id TEXT NOT NULL,
+    action TEXT NOT NULL,
+    actor TEXT NOT NULL,
+    request_id TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL,
+    before JSONB,
+    after JSONB
+);
+
+CREATE INDEX IF NOT EXISTS audit_log_created_at_idx ON audit_log (created_at);
+CREATE INDEX IF NOT EXISTS audit_log_entity_idx ON audit_log (entity, created_at);
+CREATE INDEX IF NOT EXISTS audit_log_actor_idx ON audit_log (actor, created_at);
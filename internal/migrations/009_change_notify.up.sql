@@ -0,0 +1,135 @@
+-- +migrate Up
+
+-- Изменения паст, коротких ссылок и статистики отправляются в канал pastebin_changes (NOTIFY)
+-- при фиксации транзакции; каждый экземпляр сервиса слушает канал и получает изменения всех экземпляров.
+-- Уведомление ограничено 8000 байтами, поэтому содержимое паст в него не попадает.
+
+CREATE OR REPLACE FUNCTION notify_paste_change() RETURNS trigger AS $$
+DECLARE
+    p pastes;
+    kind TEXT;
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        p := NEW;
+        kind := 'paste.created';
+    ELSIF TG_OP = 'UPDATE' THEN
+        p := NEW;
+        kind := 'paste.updated';
+    ELSE
+        p := OLD;
+        -- Очистка удаляет пасты с expires_at < NOW() в той же транзакции.
+        kind := CASE WHEN OLD.expires_at < NOW() THEN 'paste.expired' ELSE 'paste.deleted' END;
+    END IF;
+    PERFORM pg_notify('pastebin_changes', json_build_object(
+        'type', kind,
+        'id', p.id,
+        'at', clock_timestamp(),
+        'paste', json_build_object(
+            'hash', p.hash,
+            'userId', COALESCE(p.user_id, 0),
+            'createdAt', p.created_at,
+            'expiresAt', p.expires_at,
+            'size', octet_length(p.content)
+        )
+    )::text);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION notify_shorturl_change() RETURNS trigger AS $$
+DECLARE
+    u shorturls;
+    kind TEXT;
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        u := NEW;
+        kind := 'shorturl.created';
+    ELSIF TG_OP = 'UPDATE' THEN
+        -- Ссылка стала «надгробием».
+        u := NEW;
+        kind := 'shorturl.deleted';
+    ELSE
+        u := OLD;
+        kind := 'shorturl.deleted';
+    END IF;
+    PERFORM pg_notify('pastebin_changes', json_build_object(
+        'type', kind,
+        'id', u.id,
+        'at', clock_timestamp(),
+        'shortUrl', json_build_object(
+            'id', u.id,
+            'original', u.original,
+            'targetType', u.target_type,
+            'permanent', u.permanent,
+            'expiresAt', u.expires_at,
+            'deletedAt', u.deleted_at
+        )
+    )::text);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Просмотры меняют stats на каждом открытии пасты, поэтому paste.viewed отправляется не чаще раза
+-- в 5 секунд на пасту: уведомление несёт текущее число просмотров, промежуточные значения сливаются
+-- в следующее. Время последнего уведомления хранится в views_notified_at.
+ALTER TABLE stats ADD COLUMN IF NOT EXISTS views_notified_at TIMESTAMPTZ;
+
+CREATE OR REPLACE FUNCTION notify_stats_change() RETURNS trigger AS $$
+DECLARE
+    st stats;
+    kind TEXT;
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        st := NEW;
+        kind := 'stats.created';
+    ELSIF TG_OP = 'UPDATE' THEN
+        IF OLD.views_notified_at IS NOT NULL AND OLD.views_notified_at > NOW() - INTERVAL '5 seconds' THEN
+            RETURN NEW;
+        END IF;
+        NEW.views_notified_at := NOW();
+        st := NEW;
+        kind := 'paste.viewed';
+    ELSE
+        st := OLD;
+        kind := 'stats.deleted';
+    END IF;
+    PERFORM pg_notify('pastebin_changes', json_build_object(
+        'type', kind,
+        'id', st.id,
+        'at', clock_timestamp(),
+        'stats', json_build_object('id', st.id, 'views', st.views)
+    )::text);
+    IF TG_OP = 'UPDATE' THEN
+        RETURN NEW;
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS pastes_notify_change ON pastes;
+CREATE TRIGGER pastes_notify_change
+    AFTER INSERT OR DELETE OR UPDATE OF content, expires_at ON pastes
+    FOR EACH ROW EXECUTE FUNCTION notify_paste_change();
+
+DROP TRIGGER IF EXISTS shorturls_notify_insert_delete ON shorturls;
+CREATE TRIGGER shorturls_notify_insert_delete
+    AFTER INSERT OR DELETE ON shorturls
+    FOR EACH ROW EXECUTE FUNCTION notify_shorturl_change();
+
+DROP TRIGGER IF EXISTS shorturls_notify_tombstone ON shorturls;
+CREATE TRIGGER shorturls_notify_tombstone
+    AFTER UPDATE OF deleted_at ON shorturls
+    FOR EACH ROW WHEN (OLD.deleted_at IS NULL AND NEW.deleted_at IS NOT NULL)
+    EXECUTE FUNCTION notify_shorturl_change();
+
+DROP TRIGGER IF EXISTS stats_notify_change ON stats;
+CREATE TRIGGER stats_notify_change
+    AFTER INSERT OR DELETE ON stats
+    FOR EACH ROW EXECUTE FUNCTION notify_stats_change();
+
+-- BEFORE: триггер сам отмечает views_notified_at в изменяемой строке.
+DROP TRIGGER IF EXISTS stats_notify_views ON stats;
+CREATE TRIGGER stats_notify_views
+    BEFORE UPDATE OF views ON stats
+    FOR EACH ROW WHEN (NEW.views <> OLD.views)
+    EXECUTE FUNCTION notify_stats_change();
@@ -0,0 +1,8 @@
+-- +migrate Up
+
+-- Вебхук без владельца (user_id IS NULL) получает только события без владельца: анонимные пасты
+-- и т. п. Владелец 0 в старых строках означает то же самое.
+UPDATE webhooks SET user_id = NULL WHERE user_id <= 0;
+
+ALTER TABLE webhooks DROP CONSTRAINT IF EXISTS webhooks_user_id_check;
+ALTER TABLE webhooks ADD CONSTRAINT webhooks_user_id_check CHECK (user_id > 0);
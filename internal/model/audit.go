@@ -0,0 +1,36 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Исполнители, когда у изменения нет пользователя.
+const (
+	ActorAnonymous = "anonymous"
+	ActorSystem    = "system"
+)
+
+// AuditEntry — запись журнала аудита. Before и After — краткое JSON-описание сущности
+// до и после изменения (без содержимого паст), пусто — сущности не было или её нет.
+type AuditEntry struct {
+	ID        int64           `json:"id"`
+	Entity    string          `json:"entity"`
+	EntityID  string          `json:"entityId"`
+	Action    string          `json:"action"`
+	Actor     string          `json:"actor"`
+	RequestID string          `json:"requestId,omitempty"`
+	At        time.Time       `json:"at"`
+	Before    json.RawMessage `json:"before,omitempty" swaggertype:"object"`
+	After     json.RawMessage `json:"after,omitempty" swaggertype:"object"`
+}
+
+// AuditFilter отбирает записи аудита; пустые поля не ограничивают выборку.
+// From включительно, To — нет. Записи возвращаются от новых к старым.
+type AuditFilter struct {
+	Entity string
+	Actor  string
+	From   time.Time
+	To     time.Time
+	Limit  int
+}
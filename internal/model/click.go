@@ -0,0 +1,38 @@
+package model
+
+import "time"
+
+// Click — переход по короткой ссылке. IP хранится только в виде хэша.
+type Click struct {
+	ShortID      string    `json:"shortId"`
+	At           time.Time `json:"at"`
+	ReferrerHost string    `json:"referrerHost"`
+	AgentClass   string    `json:"agentClass"`
+	Country      string    `json:"country"`
+	IPHash       string    `json:"-"`
+}
+
+type ClickBucket struct {
+	Start  time.Time `json:"start"`
+	Clicks int64     `json:"clicks"`
+}
+
+type ReferrerCount struct {
+	Host   string `json:"host"`
+	Clicks int64  `json:"clicks"`
+}
+
+// ClickAnalytics — сводка переходов по ссылке с момента Since.
+// UniqueVisitors считает каждый хэш IP один раз за период, но только в пределах срока хранения
+// сырых событий: хэши IP за прореженные дни удаляются.
+type ClickAnalytics struct {
+	ShortID        string           `json:"shortId"`
+	Since          time.Time        `json:"since"`
+	Bucket         string           `json:"bucket"`
+	TotalClicks    int64            `json:"totalClicks"`
+	UniqueVisitors int64            `json:"uniqueVisitors"`
+	Buckets        []ClickBucket    `json:"buckets"`
+	TopReferrers   []ReferrerCount  `json:"topReferrers"`
+	Countries      map[string]int64 `json:"countries"`
+	AgentClasses   map[string]int64 `json:"agentClasses"`
+}
@@ -1,6 +1,11 @@
 package model
 
 import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
 	"time"
 )
 
@@ -11,6 +16,24 @@ type Paste struct {
 	CreatedAt time.Time `json:"createdAt"`
 	ExpiresAt time.Time `json:"expiresAt"`
 	Views     int       `json:"views"`
+	UserID    int64     `json:"userId,omitempty"`
+	ClientIP  string    `json:"-"`
+
+	// Size — размер содержимого в байтах, если паста загружена без Content (сводки и изменения из PostgreSQL).
+	Size int `json:"-"`
+
+	// ShortCode при создании задаёт желаемый алиас, в ответе — выданный короткий код.
+	ShortCode string `json:"-"`
+
+	// DeleteToken заполняется только в ответе на создание пасты и нигде не хранится.
+	DeleteToken     string `json:"-"`
+	DeleteTokenHash string `json:"-"`
+}
+
+// PasteUpdate — частичное изменение пасты: поля со значением nil не меняются.
+type PasteUpdate struct {
+	Content   *string
+	ExpiresAt *time.Time
 }
 
 func NewPaste(content string, ttl time.Duration) *Paste {
@@ -23,10 +46,55 @@ func NewPaste(content string, ttl time.Duration) *Paste {
 	}
 }
 
+// ContentSize возвращает размер содержимого в байтах, даже если само содержимое не загружено.
+func (p *Paste) ContentSize() int {
+	if p.Content != "" {
+		return len(p.Content)
+	}
+	return p.Size
+}
+
 func (p *Paste) IncrementViews() {
 	p.Views++
 }
 
+// IssueDeleteToken генерирует секретный токен владельца пасты.
+// В пасте сохраняется только его хэш, сам токен возвращается один раз.
+func (p *Paste) IssueDeleteToken() (string, error) {
+	token, err := newDeleteToken()
+	if err != nil {
+		return "", err
+	}
+	p.DeleteToken = token
+	p.DeleteTokenHash = HashDeleteToken(token)
+	return token, nil
+}
+
+// CheckDeleteToken сообщает, подтверждает ли token владение пастой.
+func (p *Paste) CheckDeleteToken(token string) bool {
+	return checkDeleteToken(p.DeleteTokenHash, token)
+}
+
+func newDeleteToken() (string, error) {
+	buf := make([]byte, 24)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+func checkDeleteToken(hash, token string) bool {
+	if hash == "" || token == "" {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(hash), []byte(HashDeleteToken(token))) == 1
+}
+
+func HashDeleteToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
 func (p *Paste) GetTypeName() string {
 	return "Paste"
 }
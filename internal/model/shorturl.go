@@ -1,12 +1,56 @@
 package model
 
+import "time"
+
+const (
+	TargetPaste = "paste"
+	TargetURL   = "url"
+)
+
 type ShortURL struct {
 	Original string `json:"original"`
 	ID       string `json:"id"`
+	// TargetType: TargetPaste — Original содержит hash пасты, TargetURL — внешний адрес.
+	TargetType string `json:"targetType"`
+	// Permanent включает 301 вместо 302 при переходе на внешний адрес.
+	Permanent bool `json:"permanent,omitempty"`
+	// ExpiresAt — необязательный срок жизни ссылки, nil — бессрочно.
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+	// DeletedAt отмечает «надгробие»: ссылка или её цель удалена, а код остаётся занятым.
+	DeletedAt *time.Time `json:"deletedAt,omitempty"`
+	// DeleteToken выдаётся ссылкам на внешние адреса и заполняется только в ответе на создание;
+	// ссылки на пасты удаляются токеном пасты.
+	DeleteToken     string `json:"-"`
+	DeleteTokenHash string `json:"-"`
 }
 
 func NewShortURL(original string, hash string) *ShortURL {
-	return &ShortURL{Original: original, ID: hash}
+	return &ShortURL{Original: original, ID: hash, TargetType: TargetPaste}
+}
+
+// IssueDeleteToken генерирует токен удаления ссылки; сохраняется только его хэш.
+func (s *ShortURL) IssueDeleteToken() (string, error) {
+	token, err := newDeleteToken()
+	if err != nil {
+		return "", err
+	}
+	s.DeleteToken = token
+	s.DeleteTokenHash = HashDeleteToken(token)
+	return token, nil
+}
+
+// CheckDeleteToken сообщает, подтверждает ли token право удалить ссылку.
+func (s *ShortURL) CheckDeleteToken(token string) bool {
+	return checkDeleteToken(s.DeleteTokenHash, token)
+}
+
+func (s *ShortURL) IsURL() bool {
+	return s.TargetType == TargetURL
+}
+
+// Gone сообщает, что ссылка удалена вместе с целью или истекла.
+func (s *ShortURL) Gone(now time.Time) bool {
+	return s.DeletedAt != nil || (s.ExpiresAt != nil && !s.ExpiresAt.After(now))
 }
 
 func (s *ShortURL) GetTypeName() string {
@@ -0,0 +1,16 @@
+package model
+
+// Quota описывает лимиты владельца паст. Нулевое значение поля означает отсутствие лимита.
+type Quota struct {
+	MaxPasteBytes   int64 `json:"maxPasteBytes"`
+	MaxStoredBytes  int64 `json:"maxStoredBytes"`
+	MaxLivePastes   int   `json:"maxLivePastes"`
+	MaxPastesPerDay int   `json:"maxPastesPerDay"`
+}
+
+type Usage struct {
+	LivePastes  int   `json:"livePastes"`
+	StoredBytes int64 `json:"storedBytes"`
+	PastesToday int   `json:"pastesToday"`
+	Limits      Quota `json:"limits"`
+}
@@ -0,0 +1,49 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Состояния доставки вебхука. DeliveryDead — попытки исчерпаны, доставка осталась в списке недоставленных.
+const (
+	DeliveryPending   = "pending"
+	DeliverySucceeded = "succeeded"
+	DeliveryDead      = "dead"
+)
+
+// Webhook — подписка на события. UserID ограничивает события пастами и записью этого пользователя,
+// 0 — только события без владельца (например, анонимных паст).
+type Webhook struct {
+	ID     int64    `json:"id"`
+	URL    string   `json:"url"`
+	Events []string `json:"events"`
+	UserID int64    `json:"userId,omitempty"`
+	// Secret подписывает тела запросов; в ответах возвращается только при создании.
+	Secret    string    `json:"secret,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// WebhookDelivery — отправка одного события одному вебхуку. NextAttemptAt — время следующей попытки
+// для доставок в состоянии pending.
+type WebhookDelivery struct {
+	ID            int64            `json:"id"`
+	WebhookID     int64            `json:"webhookId"`
+	EventID       string           `json:"eventId"`
+	Event         string           `json:"event"`
+	Payload       json.RawMessage  `json:"payload" swaggertype:"object"`
+	Status        string           `json:"status"`
+	AttemptCount  int              `json:"attemptCount"`
+	NextAttemptAt *time.Time       `json:"nextAttemptAt,omitempty"`
+	CreatedAt     time.Time        `json:"createdAt"`
+	Attempts      []WebhookAttempt `json:"attempts"`
+}
+
+// WebhookAttempt — одна попытка доставки. StatusCode пуст, если ответа не было.
+type WebhookAttempt struct {
+	Attempt    int       `json:"attempt"`
+	At         time.Time `json:"at"`
+	StatusCode int       `json:"statusCode,omitempty"`
+	Error      string    `json:"error,omitempty"`
+	DurationMS int64     `json:"durationMs"`
+}
@@ -0,0 +1,446 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v3.21.12
+// source: pastebin/v1/analytics.proto
+
+package pastebinv1
+
+import (
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ClickBucket struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Start         *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=start,proto3" json:"start,omitempty"`
+	Clicks        int64                  `protobuf:"varint,2,opt,name=clicks,proto3" json:"clicks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClickBucket) Reset() {
+	*x = ClickBucket{}
+	mi := &file_pastebin_v1_analytics_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClickBucket) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClickBucket) ProtoMessage() {}
+
+func (x *ClickBucket) ProtoReflect() protoreflect.Message {
+	mi := &file_pastebin_v1_analytics_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClickBucket.ProtoReflect.Descriptor instead.
+func (*ClickBucket) Descriptor() ([]byte, []int) {
+	return file_pastebin_v1_analytics_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ClickBucket) GetStart() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Start
+	}
+	return nil
+}
+
+func (x *ClickBucket) GetClicks() int64 {
+	if x != nil {
+		return x.Clicks
+	}
+	return 0
+}
+
+type ReferrerCount struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Host          string                 `protobuf:"bytes,1,opt,name=host,proto3" json:"host,omitempty"`
+	Clicks        int64                  `protobuf:"varint,2,opt,name=clicks,proto3" json:"clicks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReferrerCount) Reset() {
+	*x = ReferrerCount{}
+	mi := &file_pastebin_v1_analytics_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReferrerCount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReferrerCount) ProtoMessage() {}
+
+func (x *ReferrerCount) ProtoReflect() protoreflect.Message {
+	mi := &file_pastebin_v1_analytics_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReferrerCount.ProtoReflect.Descriptor instead.
+func (*ReferrerCount) Descriptor() ([]byte, []int) {
+	return file_pastebin_v1_analytics_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ReferrerCount) GetHost() string {
+	if x != nil {
+		return x.Host
+	}
+	return ""
+}
+
+func (x *ReferrerCount) GetClicks() int64 {
+	if x != nil {
+		return x.Clicks
+	}
+	return 0
+}
+
+// ClickAnalytics — сводка переходов по ссылке с момента since.
+// unique_visitors считается только в пределах срока хранения сырых событий.
+type ClickAnalytics struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ShortId        string                 `protobuf:"bytes,1,opt,name=short_id,json=shortId,proto3" json:"short_id,omitempty"`
+	Since          *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=since,proto3" json:"since,omitempty"`
+	Bucket         string                 `protobuf:"bytes,3,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	TotalClicks    int64                  `protobuf:"varint,4,opt,name=total_clicks,json=totalClicks,proto3" json:"total_clicks,omitempty"`
+	UniqueVisitors int64                  `protobuf:"varint,5,opt,name=unique_visitors,json=uniqueVisitors,proto3" json:"unique_visitors,omitempty"`
+	Buckets        []*ClickBucket         `protobuf:"bytes,6,rep,name=buckets,proto3" json:"buckets,omitempty"`
+	TopReferrers   []*ReferrerCount       `protobuf:"bytes,7,rep,name=top_referrers,json=topReferrers,proto3" json:"top_referrers,omitempty"`
+	Countries      map[string]int64       `protobuf:"bytes,8,rep,name=countries,proto3" json:"countries,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	AgentClasses   map[string]int64       `protobuf:"bytes,9,rep,name=agent_classes,json=agentClasses,proto3" json:"agent_classes,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ClickAnalytics) Reset() {
+	*x = ClickAnalytics{}
+	mi := &file_pastebin_v1_analytics_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClickAnalytics) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClickAnalytics) ProtoMessage() {}
+
+func (x *ClickAnalytics) ProtoReflect() protoreflect.Message {
+	mi := &file_pastebin_v1_analytics_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClickAnalytics.ProtoReflect.Descriptor instead.
+func (*ClickAnalytics) Descriptor() ([]byte, []int) {
+	return file_pastebin_v1_analytics_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ClickAnalytics) GetShortId() string {
+	if x != nil {
+		return x.ShortId
+	}
+	return ""
+}
+
+func (x *ClickAnalytics) GetSince() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Since
+	}
+	return nil
+}
+
+func (x *ClickAnalytics) GetBucket() string {
+	if x != nil {
+		return x.Bucket
+	}
+	return ""
+}
+
+func (x *ClickAnalytics) GetTotalClicks() int64 {
+	if x != nil {
+		return x.TotalClicks
+	}
+	return 0
+}
+
+func (x *ClickAnalytics) GetUniqueVisitors() int64 {
+	if x != nil {
+		return x.UniqueVisitors
+	}
+	return 0
+}
+
+func (x *ClickAnalytics) GetBuckets() []*ClickBucket {
+	if x != nil {
+		return x.Buckets
+	}
+	return nil
+}
+
+func (x *ClickAnalytics) GetTopReferrers() []*ReferrerCount {
+	if x != nil {
+		return x.TopReferrers
+	}
+	return nil
+}
+
+func (x *ClickAnalytics) GetCountries() map[string]int64 {
+	if x != nil {
+		return x.Countries
+	}
+	return nil
+}
+
+func (x *ClickAnalytics) GetAgentClasses() map[string]int64 {
+	if x != nil {
+		return x.AgentClasses
+	}
+	return nil
+}
+
+type GetShortURLAnalyticsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// ID короткой ссылки.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Шаг временного ряда: hour или day (по умолчанию day).
+	Bucket string `protobuf:"bytes,2,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	// Начало периода (по умолчанию 30 дней назад).
+	Since         *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=since,proto3" json:"since,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetShortURLAnalyticsRequest) Reset() {
+	*x = GetShortURLAnalyticsRequest{}
+	mi := &file_pastebin_v1_analytics_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetShortURLAnalyticsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetShortURLAnalyticsRequest) ProtoMessage() {}
+
+func (x *GetShortURLAnalyticsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pastebin_v1_analytics_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetShortURLAnalyticsRequest.ProtoReflect.Descriptor instead.
+func (*GetShortURLAnalyticsRequest) Descriptor() ([]byte, []int) {
+	return file_pastebin_v1_analytics_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetShortURLAnalyticsRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *GetShortURLAnalyticsRequest) GetBucket() string {
+	if x != nil {
+		return x.Bucket
+	}
+	return ""
+}
+
+func (x *GetShortURLAnalyticsRequest) GetSince() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Since
+	}
+	return nil
+}
+
+type GetShortURLAnalyticsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Analytics     *ClickAnalytics        `protobuf:"bytes,1,opt,name=analytics,proto3" json:"analytics,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetShortURLAnalyticsResponse) Reset() {
+	*x = GetShortURLAnalyticsResponse{}
+	mi := &file_pastebin_v1_analytics_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetShortURLAnalyticsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetShortURLAnalyticsResponse) ProtoMessage() {}
+
+func (x *GetShortURLAnalyticsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pastebin_v1_analytics_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetShortURLAnalyticsResponse.ProtoReflect.Descriptor instead.
+func (*GetShortURLAnalyticsResponse) Descriptor() ([]byte, []int) {
+	return file_pastebin_v1_analytics_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetShortURLAnalyticsResponse) GetAnalytics() *ClickAnalytics {
+	if x != nil {
+		return x.Analytics
+	}
+	return nil
+}
+
+var File_pastebin_v1_analytics_proto protoreflect.FileDescriptor
+
+const file_pastebin_v1_analytics_proto_rawDesc = "" +
+	"\n" +
+	"\x1bpastebin/v1/analytics.proto\x12\vpastebin.v1\x1a\x1cgoogle/api/annotations.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"W\n" +
+	"\vClickBucket\x120\n" +
+	"\x05start\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x05start\x12\x16\n" +
+	"\x06clicks\x18\x02 \x01(\x03R\x06clicks\";\n" +
+	"\rReferrerCount\x12\x12\n" +
+	"\x04host\x18\x01 \x01(\tR\x04host\x12\x16\n" +
+	"\x06clicks\x18\x02 \x01(\x03R\x06clicks\"\xd3\x04\n" +
+	"\x0eClickAnalytics\x12\x19\n" +
+	"\bshort_id\x18\x01 \x01(\tR\ashortId\x120\n" +
+	"\x05since\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x05since\x12\x16\n" +
+	"\x06bucket\x18\x03 \x01(\tR\x06bucket\x12!\n" +
+	"\ftotal_clicks\x18\x04 \x01(\x03R\vtotalClicks\x12'\n" +
+	"\x0funique_visitors\x18\x05 \x01(\x03R\x0euniqueVisitors\x122\n" +
+	"\abuckets\x18\x06 \x03(\v2\x18.pastebin.v1.ClickBucketR\abuckets\x12?\n" +
+	"\rtop_referrers\x18\a \x03(\v2\x1a.pastebin.v1.ReferrerCountR\ftopReferrers\x12H\n" +
+	"\tcountries\x18\b \x03(\v2*.pastebin.v1.ClickAnalytics.CountriesEntryR\tcountries\x12R\n" +
+	"\ragent_classes\x18\t \x03(\v2-.pastebin.v1.ClickAnalytics.AgentClassesEntryR\fagentClasses\x1a<\n" +
+	"\x0eCountriesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x03R\x05value:\x028\x01\x1a?\n" +
+	"\x11AgentClassesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x03R\x05value:\x028\x01\"w\n" +
+	"\x1bGetShortURLAnalyticsRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06bucket\x18\x02 \x01(\tR\x06bucket\x120\n" +
+	"\x05since\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x05since\"Y\n" +
+	"\x1cGetShortURLAnalyticsResponse\x129\n" +
+	"\tanalytics\x18\x01 \x01(\v2\x1b.pastebin.v1.ClickAnalyticsR\tanalytics2\xb4\x01\n" +
+	"\x10AnalyticsService\x12\x9f\x01\n" +
+	"\x14GetShortURLAnalytics\x12(.pastebin.v1.GetShortURLAnalyticsRequest\x1a).pastebin.v1.GetShortURLAnalyticsResponse\"2\x82\xd3\xe4\x93\x02,b\tanalytics\x12\x1f/api/v1/shorturl/{id}/analyticsBJZHgithub.com/GritsyukLeonid/pastebin-go/internal/pb/pastebin/v1;pastebinv1b\x06proto3"
+
+var (
+	file_pastebin_v1_analytics_proto_rawDescOnce sync.Once
+	file_pastebin_v1_analytics_proto_rawDescData []byte
+)
+
+func file_pastebin_v1_analytics_proto_rawDescGZIP() []byte {
+	file_pastebin_v1_analytics_proto_rawDescOnce.Do(func() {
+		file_pastebin_v1_analytics_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_pastebin_v1_analytics_proto_rawDesc), len(file_pastebin_v1_analytics_proto_rawDesc)))
+	})
+	return file_pastebin_v1_analytics_proto_rawDescData
+}
+
+var file_pastebin_v1_analytics_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_pastebin_v1_analytics_proto_goTypes = []any{
+	(*ClickBucket)(nil),                  // 0: pastebin.v1.ClickBucket
+	(*ReferrerCount)(nil),                // 1: pastebin.v1.ReferrerCount
+	(*ClickAnalytics)(nil),               // 2: pastebin.v1.ClickAnalytics
+	(*GetShortURLAnalyticsRequest)(nil),  // 3: pastebin.v1.GetShortURLAnalyticsRequest
+	(*GetShortURLAnalyticsResponse)(nil), // 4: pastebin.v1.GetShortURLAnalyticsResponse
+	nil,                                  // 5: pastebin.v1.ClickAnalytics.CountriesEntry
+	nil,                                  // 6: pastebin.v1.ClickAnalytics.AgentClassesEntry
+	(*timestamppb.Timestamp)(nil),        // 7: google.protobuf.Timestamp
+}
+var file_pastebin_v1_analytics_proto_depIdxs = []int32{
+	7, // 0: pastebin.v1.ClickBucket.start:type_name -> google.protobuf.Timestamp
+	7, // 1: pastebin.v1.ClickAnalytics.since:type_name -> google.protobuf.Timestamp
+	0, // 2: pastebin.v1.ClickAnalytics.buckets:type_name -> pastebin.v1.ClickBucket
+	1, // 3: pastebin.v1.ClickAnalytics.top_referrers:type_name -> pastebin.v1.ReferrerCount
+	5, // 4: pastebin.v1.ClickAnalytics.countries:type_name -> pastebin.v1.ClickAnalytics.CountriesEntry
+	6, // 5: pastebin.v1.ClickAnalytics.agent_classes:type_name -> pastebin.v1.ClickAnalytics.AgentClassesEntry
+	7, // 6: pastebin.v1.GetShortURLAnalyticsRequest.since:type_name -> google.protobuf.Timestamp
+	2, // 7: pastebin.v1.GetShortURLAnalyticsResponse.analytics:type_name -> pastebin.v1.ClickAnalytics
+	3, // 8: pastebin.v1.AnalyticsService.GetShortURLAnalytics:input_type -> pastebin.v1.GetShortURLAnalyticsRequest
+	4, // 9: pastebin.v1.AnalyticsService.GetShortURLAnalytics:output_type -> pastebin.v1.GetShortURLAnalyticsResponse
+	9, // [9:10] is the sub-list for method output_type
+	8, // [8:9] is the sub-list for method input_type
+	8, // [8:8] is the sub-list for extension type_name
+	8, // [8:8] is the sub-list for extension extendee
+	0, // [0:8] is the sub-list for field type_name
+}
+
+func init() { file_pastebin_v1_analytics_proto_init() }
+func file_pastebin_v1_analytics_proto_init() {
+	if File_pastebin_v1_analytics_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_pastebin_v1_analytics_proto_rawDesc), len(file_pastebin_v1_analytics_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_pastebin_v1_analytics_proto_goTypes,
+		DependencyIndexes: file_pastebin_v1_analytics_proto_depIdxs,
+		MessageInfos:      file_pastebin_v1_analytics_proto_msgTypes,
+	}.Build()
+	File_pastebin_v1_analytics_proto = out.File
+	file_pastebin_v1_analytics_proto_goTypes = nil
+	file_pastebin_v1_analytics_proto_depIdxs = nil
+}
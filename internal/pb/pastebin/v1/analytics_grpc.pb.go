@@ -0,0 +1,133 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v3.21.12
+// source: pastebin/v1/analytics.proto
+
+package pastebinv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	AnalyticsService_GetShortURLAnalytics_FullMethodName = "/pastebin.v1.AnalyticsService/GetShortURLAnalytics"
+)
+
+// AnalyticsServiceClient is the client API for AnalyticsService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// AnalyticsService требует PostgreSQL: тестовый gRPC-сервер его не регистрирует.
+type AnalyticsServiceClient interface {
+	// Аналитика переходов по короткой ссылке
+	//
+	// Число переходов, уникальных посетителей (по хэшу IP), временной ряд, топ источников,
+	// разбивка по странам и классам клиентов. Сырые события старше срока хранения прорежены до дневных агрегатов.
+	GetShortURLAnalytics(ctx context.Context, in *GetShortURLAnalyticsRequest, opts ...grpc.CallOption) (*GetShortURLAnalyticsResponse, error)
+}
+
+type analyticsServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAnalyticsServiceClient(cc grpc.ClientConnInterface) AnalyticsServiceClient {
+	return &analyticsServiceClient{cc}
+}
+
+func (c *analyticsServiceClient) GetShortURLAnalytics(ctx context.Context, in *GetShortURLAnalyticsRequest, opts ...grpc.CallOption) (*GetShortURLAnalyticsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetShortURLAnalyticsResponse)
+	err := c.cc.Invoke(ctx, AnalyticsService_GetShortURLAnalytics_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AnalyticsServiceServer is the server API for AnalyticsService service.
+// All implementations must embed UnimplementedAnalyticsServiceServer
+// for forward compatibility.
+//
+// AnalyticsService требует PostgreSQL: тестовый gRPC-сервер его не регистрирует.
+type AnalyticsServiceServer interface {
+	// Аналитика переходов по короткой ссылке
+	//
+	// Число переходов, уникальных посетителей (по хэшу IP), временной ряд, топ источников,
+	// разбивка по странам и классам клиентов. Сырые события старше срока хранения прорежены до дневных агрегатов.
+	GetShortURLAnalytics(context.Context, *GetShortURLAnalyticsRequest) (*GetShortURLAnalyticsResponse, error)
+	mustEmbedUnimplementedAnalyticsServiceServer()
+}
+
+// UnimplementedAnalyticsServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedAnalyticsServiceServer struct{}
+
+func (UnimplementedAnalyticsServiceServer) GetShortURLAnalytics(context.Context, *GetShortURLAnalyticsRequest) (*GetShortURLAnalyticsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetShortURLAnalytics not implemented")
+}
+func (UnimplementedAnalyticsServiceServer) mustEmbedUnimplementedAnalyticsServiceServer() {}
+func (UnimplementedAnalyticsServiceServer) testEmbeddedByValue()                          {}
+
+// UnsafeAnalyticsServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AnalyticsServiceServer will
+// result in compilation errors.
+type UnsafeAnalyticsServiceServer interface {
+	mustEmbedUnimplementedAnalyticsServiceServer()
+}
+
+func RegisterAnalyticsServiceServer(s grpc.ServiceRegistrar, srv AnalyticsServiceServer) {
+	// If the following call pancis, it indicates UnimplementedAnalyticsServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&AnalyticsService_ServiceDesc, srv)
+}
+
+func _AnalyticsService_GetShortURLAnalytics_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetShortURLAnalyticsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalyticsServiceServer).GetShortURLAnalytics(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AnalyticsService_GetShortURLAnalytics_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalyticsServiceServer).GetShortURLAnalytics(ctx, req.(*GetShortURLAnalyticsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// AnalyticsService_ServiceDesc is the grpc.ServiceDesc for AnalyticsService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AnalyticsService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "pastebin.v1.AnalyticsService",
+	HandlerType: (*AnalyticsServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetShortURLAnalytics",
+			Handler:    _AnalyticsService_GetShortURLAnalytics_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "pastebin/v1/analytics.proto",
+}
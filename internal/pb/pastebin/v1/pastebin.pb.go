@@ -0,0 +1,2869 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v3.21.12
+// source: pastebin/v1/pastebin.proto
+
+package pastebinv1
+
+import (
+	_ "github.com/grpc-ecosystem/grpc-gateway/v2/protoc-gen-openapiv2/options"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Paste — текстовая запись.
+type Paste struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Hash  string                 `protobuf:"bytes,2,opt,name=hash,proto3" json:"hash,omitempty"`
+	// Пусто в потоках без содержимого (WatchPastes).
+	Content   string                 `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	ExpiresAt *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	Views     int64                  `protobuf:"varint,6,opt,name=views,proto3" json:"views,omitempty"`
+	// 0 — анонимная паста.
+	UserId int64 `protobuf:"varint,7,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Размер содержимого в байтах; заполнен, даже если content не передаётся.
+	Size          int64 `protobuf:"varint,8,opt,name=size,proto3" json:"size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Paste) Reset() {
+	*x = Paste{}
+	mi := &file_pastebin_v1_pastebin_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Paste) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Paste) ProtoMessage() {}
+
+func (x *Paste) ProtoReflect() protoreflect.Message {
+	mi := &file_pastebin_v1_pastebin_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Paste.ProtoReflect.Descriptor instead.
+func (*Paste) Descriptor() ([]byte, []int) {
+	return file_pastebin_v1_pastebin_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Paste) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Paste) GetHash() string {
+	if x != nil {
+		return x.Hash
+	}
+	return ""
+}
+
+func (x *Paste) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *Paste) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Paste) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *Paste) GetViews() int64 {
+	if x != nil {
+		return x.Views
+	}
+	return 0
+}
+
+func (x *Paste) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *Paste) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+type User struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Username      string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	Posts         []string               `protobuf:"bytes,3,rep,name=posts,proto3" json:"posts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *User) Reset() {
+	*x = User{}
+	mi := &file_pastebin_v1_pastebin_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *User) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*User) ProtoMessage() {}
+
+func (x *User) ProtoReflect() protoreflect.Message {
+	mi := &file_pastebin_v1_pastebin_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use User.ProtoReflect.Descriptor instead.
+func (*User) Descriptor() ([]byte, []int) {
+	return file_pastebin_v1_pastebin_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *User) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *User) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *User) GetPosts() []string {
+	if x != nil {
+		return x.Posts
+	}
+	return nil
+}
+
+type Quota struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	MaxPasteBytes   int64                  `protobuf:"varint,1,opt,name=max_paste_bytes,json=maxPasteBytes,proto3" json:"max_paste_bytes,omitempty"`
+	MaxStoredBytes  int64                  `protobuf:"varint,2,opt,name=max_stored_bytes,json=maxStoredBytes,proto3" json:"max_stored_bytes,omitempty"`
+	MaxLivePastes   int64                  `protobuf:"varint,3,opt,name=max_live_pastes,json=maxLivePastes,proto3" json:"max_live_pastes,omitempty"`
+	MaxPastesPerDay int64                  `protobuf:"varint,4,opt,name=max_pastes_per_day,json=maxPastesPerDay,proto3" json:"max_pastes_per_day,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Quota) Reset() {
+	*x = Quota{}
+	mi := &file_pastebin_v1_pastebin_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Quota) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Quota) ProtoMessage() {}
+
+func (x *Quota) ProtoReflect() protoreflect.Message {
+	mi := &file_pastebin_v1_pastebin_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Quota.ProtoReflect.Descriptor instead.
+func (*Quota) Descriptor() ([]byte, []int) {
+	return file_pastebin_v1_pastebin_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Quota) GetMaxPasteBytes() int64 {
+	if x != nil {
+		return x.MaxPasteBytes
+	}
+	return 0
+}
+
+func (x *Quota) GetMaxStoredBytes() int64 {
+	if x != nil {
+		return x.MaxStoredBytes
+	}
+	return 0
+}
+
+func (x *Quota) GetMaxLivePastes() int64 {
+	if x != nil {
+		return x.MaxLivePastes
+	}
+	return 0
+}
+
+func (x *Quota) GetMaxPastesPerDay() int64 {
+	if x != nil {
+		return x.MaxPastesPerDay
+	}
+	return 0
+}
+
+// Usage — использование квоты владельцем; 0 в limits — без лимита.
+type Usage struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	LivePastes  int64                  `protobuf:"varint,1,opt,name=live_pastes,json=livePastes,proto3" json:"live_pastes,omitempty"`
+	StoredBytes int64                  `protobuf:"varint,2,opt,name=stored_bytes,json=storedBytes,proto3" json:"stored_bytes,omitempty"`
+	// Пасты, созданные за последние 24 часа (скользящее окно, не календарные сутки).
+	PastesToday   int64  `protobuf:"varint,3,opt,name=pastes_today,json=pastesToday,proto3" json:"pastes_today,omitempty"`
+	Limits        *Quota `protobuf:"bytes,4,opt,name=limits,proto3" json:"limits,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Usage) Reset() {
+	*x = Usage{}
+	mi := &file_pastebin_v1_pastebin_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Usage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Usage) ProtoMessage() {}
+
+func (x *Usage) ProtoReflect() protoreflect.Message {
+	mi := &file_pastebin_v1_pastebin_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Usage.ProtoReflect.Descriptor instead.
+func (*Usage) Descriptor() ([]byte, []int) {
+	return file_pastebin_v1_pastebin_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Usage) GetLivePastes() int64 {
+	if x != nil {
+		return x.LivePastes
+	}
+	return 0
+}
+
+func (x *Usage) GetStoredBytes() int64 {
+	if x != nil {
+		return x.StoredBytes
+	}
+	return 0
+}
+
+func (x *Usage) GetPastesToday() int64 {
+	if x != nil {
+		return x.PastesToday
+	}
+	return 0
+}
+
+func (x *Usage) GetLimits() *Quota {
+	if x != nil {
+		return x.Limits
+	}
+	return nil
+}
+
+// Stats — счётчик просмотров пасты; id — ID пасты. Просмотры меняются только при чтении пасты.
+type Stats struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Views         int64                  `protobuf:"varint,2,opt,name=views,proto3" json:"views,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Stats) Reset() {
+	*x = Stats{}
+	mi := &file_pastebin_v1_pastebin_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Stats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Stats) ProtoMessage() {}
+
+func (x *Stats) ProtoReflect() protoreflect.Message {
+	mi := &file_pastebin_v1_pastebin_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Stats.ProtoReflect.Descriptor instead.
+func (*Stats) Descriptor() ([]byte, []int) {
+	return file_pastebin_v1_pastebin_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Stats) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Stats) GetViews() int64 {
+	if x != nil {
+		return x.Views
+	}
+	return 0
+}
+
+// ShortURL — короткая ссылка; id — короткий код.
+type ShortURL struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Hash пасты для target_type "paste" или внешний адрес для "url".
+	Original   string                 `protobuf:"bytes,2,opt,name=original,proto3" json:"original,omitempty"`
+	TargetType string                 `protobuf:"bytes,3,opt,name=target_type,json=targetType,proto3" json:"target_type,omitempty"`
+	Permanent  bool                   `protobuf:"varint,4,opt,name=permanent,proto3" json:"permanent,omitempty"`
+	ExpiresAt  *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	// Заполнено у «надгробий»: ссылка или её цель удалена, код остаётся занятым.
+	DeletedAt *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty"`
+	// Полный публичный адрес ссылки.
+	Link string `protobuf:"bytes,7,opt,name=link,proto3" json:"link,omitempty"`
+	// Токен удаления ссылки на внешний адрес; возвращается только в ответе на создание.
+	DeleteToken   string `protobuf:"bytes,8,opt,name=delete_token,json=deleteToken,proto3" json:"delete_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShortURL) Reset() {
+	*x = ShortURL{}
+	mi := &file_pastebin_v1_pastebin_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShortURL) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShortURL) ProtoMessage() {}
+
+func (x *ShortURL) ProtoReflect() protoreflect.Message {
+	mi := &file_pastebin_v1_pastebin_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShortURL.ProtoReflect.Descriptor instead.
+func (*ShortURL) Descriptor() ([]byte, []int) {
+	return file_pastebin_v1_pastebin_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ShortURL) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ShortURL) GetOriginal() string {
+	if x != nil {
+		return x.Original
+	}
+	return ""
+}
+
+func (x *ShortURL) GetTargetType() string {
+	if x != nil {
+		return x.TargetType
+	}
+	return ""
+}
+
+func (x *ShortURL) GetPermanent() bool {
+	if x != nil {
+		return x.Permanent
+	}
+	return false
+}
+
+func (x *ShortURL) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *ShortURL) GetDeletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeletedAt
+	}
+	return nil
+}
+
+func (x *ShortURL) GetLink() string {
+	if x != nil {
+		return x.Link
+	}
+	return ""
+}
+
+func (x *ShortURL) GetDeleteToken() string {
+	if x != nil {
+		return x.DeleteToken
+	}
+	return ""
+}
+
+type CreatePasteRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Content string                 `protobuf:"bytes,1,opt,name=content,proto3" json:"content,omitempty"`
+	// Срок жизни: момент истечения или длительность; без них — 24 часа.
+	//
+	// Types that are valid to be assigned to Expiration:
+	//
+	//	*CreatePasteRequest_ExpiresAt
+	//	*CreatePasteRequest_Ttl
+	Expiration isCreatePasteRequest_Expiration `protobuf_oneof:"expiration"`
+	UserId     int64                           `protobuf:"varint,4,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Желаемый короткий код вместо сгенерированного.
+	Alias         string `protobuf:"bytes,5,opt,name=alias,proto3" json:"alias,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreatePasteRequest) Reset() {
+	*x = CreatePasteRequest{}
+	mi := &file_pastebin_v1_pastebin_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePasteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePasteRequest) ProtoMessage() {}
+
+func (x *CreatePasteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pastebin_v1_pastebin_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePasteRequest.ProtoReflect.Descriptor instead.
+func (*CreatePasteRequest) Descriptor() ([]byte, []int) {
+	return file_pastebin_v1_pastebin_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *CreatePasteRequest) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *CreatePasteRequest) GetExpiration() isCreatePasteRequest_Expiration {
+	if x != nil {
+		return x.Expiration
+	}
+	return nil
+}
+
+func (x *CreatePasteRequest) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		if x, ok := x.Expiration.(*CreatePasteRequest_ExpiresAt); ok {
+			return x.ExpiresAt
+		}
+	}
+	return nil
+}
+
+func (x *CreatePasteRequest) GetTtl() *durationpb.Duration {
+	if x != nil {
+		if x, ok := x.Expiration.(*CreatePasteRequest_Ttl); ok {
+			return x.Ttl
+		}
+	}
+	return nil
+}
+
+func (x *CreatePasteRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *CreatePasteRequest) GetAlias() string {
+	if x != nil {
+		return x.Alias
+	}
+	return ""
+}
+
+type isCreatePasteRequest_Expiration interface {
+	isCreatePasteRequest_Expiration()
+}
+
+type CreatePasteRequest_ExpiresAt struct {
+	ExpiresAt *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=expires_at,json=expiresAt,proto3,oneof"`
+}
+
+type CreatePasteRequest_Ttl struct {
+	Ttl *durationpb.Duration `protobuf:"bytes,3,opt,name=ttl,proto3,oneof"`
+}
+
+func (*CreatePasteRequest_ExpiresAt) isCreatePasteRequest_Expiration() {}
+
+func (*CreatePasteRequest_Ttl) isCreatePasteRequest_Expiration() {}
+
+type CreatePasteResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Paste *Paste                 `protobuf:"bytes,1,opt,name=paste,proto3" json:"paste,omitempty"`
+	// Токен удаления и изменения пасты; возвращается только здесь.
+	DeleteToken   string `protobuf:"bytes,2,opt,name=delete_token,json=deleteToken,proto3" json:"delete_token,omitempty"`
+	ShortCode     string `protobuf:"bytes,3,opt,name=short_code,json=shortCode,proto3" json:"short_code,omitempty"`
+	ShortUrl      string `protobuf:"bytes,4,opt,name=short_url,json=shortUrl,proto3" json:"short_url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreatePasteResponse) Reset() {
+	*x = CreatePasteResponse{}
+	mi := &file_pastebin_v1_pastebin_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePasteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePasteResponse) ProtoMessage() {}
+
+func (x *CreatePasteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pastebin_v1_pastebin_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePasteResponse.ProtoReflect.Descriptor instead.
+func (*CreatePasteResponse) Descriptor() ([]byte, []int) {
+	return file_pastebin_v1_pastebin_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *CreatePasteResponse) GetPaste() *Paste {
+	if x != nil {
+		return x.Paste
+	}
+	return nil
+}
+
+func (x *CreatePasteResponse) GetDeleteToken() string {
+	if x != nil {
+		return x.DeleteToken
+	}
+	return ""
+}
+
+func (x *CreatePasteResponse) GetShortCode() string {
+	if x != nil {
+		return x.ShortCode
+	}
+	return ""
+}
+
+func (x *CreatePasteResponse) GetShortUrl() string {
+	if x != nil {
+		return x.ShortUrl
+	}
+	return ""
+}
+
+type GetPasteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPasteRequest) Reset() {
+	*x = GetPasteRequest{}
+	mi := &file_pastebin_v1_pastebin_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPasteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPasteRequest) ProtoMessage() {}
+
+func (x *GetPasteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pastebin_v1_pastebin_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPasteRequest.ProtoReflect.Descriptor instead.
+func (*GetPasteRequest) Descriptor() ([]byte, []int) {
+	return file_pastebin_v1_pastebin_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetPasteRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetPasteResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Paste         *Paste                 `protobuf:"bytes,1,opt,name=paste,proto3" json:"paste,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPasteResponse) Reset() {
+	*x = GetPasteResponse{}
+	mi := &file_pastebin_v1_pastebin_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPasteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPasteResponse) ProtoMessage() {}
+
+func (x *GetPasteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pastebin_v1_pastebin_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPasteResponse.ProtoReflect.Descriptor instead.
+func (*GetPasteResponse) Descriptor() ([]byte, []int) {
+	return file_pastebin_v1_pastebin_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetPasteResponse) GetPaste() *Paste {
+	if x != nil {
+		return x.Paste
+	}
+	return nil
+}
+
+// GetPasteByHash увеличивает счётчик просмотров.
+type GetPasteByHashRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Hash          string                 `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPasteByHashRequest) Reset() {
+	*x = GetPasteByHashRequest{}
+	mi := &file_pastebin_v1_pastebin_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPasteByHashRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPasteByHashRequest) ProtoMessage() {}
+
+func (x *GetPasteByHashRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pastebin_v1_pastebin_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPasteByHashRequest.ProtoReflect.Descriptor instead.
+func (*GetPasteByHashRequest) Descriptor() ([]byte, []int) {
+	return file_pastebin_v1_pastebin_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetPasteByHashRequest) GetHash() string {
+	if x != nil {
+		return x.Hash
+	}
+	return ""
+}
+
+type GetPasteByHashResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Paste         *Paste                 `protobuf:"bytes,1,opt,name=paste,proto3" json:"paste,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPasteByHashResponse) Reset() {
+	*x = GetPasteByHashResponse{}
+	mi := &file_pastebin_v1_pastebin_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPasteByHashResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPasteByHashResponse) ProtoMessage() {}
+
+func (x *GetPasteByHashResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pastebin_v1_pastebin_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPasteByHashResponse.ProtoReflect.Descriptor instead.
+func (*GetPasteByHashResponse) Descriptor() ([]byte, []int) {
+	return file_pastebin_v1_pastebin_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetPasteByHashResponse) GetPaste() *Paste {
+	if x != nil {
+		return x.Paste
+	}
+	return nil
+}
+
+type ListPastesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPastesRequest) Reset() {
+	*x = ListPastesRequest{}
+	mi := &file_pastebin_v1_pastebin_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPastesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPastesRequest) ProtoMessage() {}
+
+func (x *ListPastesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pastebin_v1_pastebin_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPastesRequest.ProtoReflect.Descriptor instead.
+func (*ListPastesRequest) Descriptor() ([]byte, []int) {
+	return file_pastebin_v1_pastebin_proto_rawDescGZIP(), []int{12}
+}
+
+type ListPastesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pastes        []*Paste               `protobuf:"bytes,1,rep,name=pastes,proto3" json:"pastes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPastesResponse) Reset() {
+	*x = ListPastesResponse{}
+	mi := &file_pastebin_v1_pastebin_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPastesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPastesResponse) ProtoMessage() {}
+
+func (x *ListPastesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pastebin_v1_pastebin_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPastesResponse.ProtoReflect.Descriptor instead.
+func (*ListPastesResponse) Descriptor() ([]byte, []int) {
+	return file_pastebin_v1_pastebin_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ListPastesResponse) GetPastes() []*Paste {
+	if x != nil {
+		return x.Pastes
+	}
+	return nil
+}
+
+// ListPopularPastesRequest: limit — число паст; 0 — значение stats.popular_limit.
+type ListPopularPastesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Limit         int32                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPopularPastesRequest) Reset() {
+	*x = ListPopularPastesRequest{}
+	mi := &file_pastebin_v1_pastebin_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPopularPastesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPopularPastesRequest) ProtoMessage() {}
+
+func (x *ListPopularPastesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pastebin_v1_pastebin_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPopularPastesRequest.ProtoReflect.Descriptor instead.
+func (*ListPopularPastesRequest) Descriptor() ([]byte, []int) {
+	return file_pastebin_v1_pastebin_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ListPopularPastesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+// ListPopularPastesResponse — самые просматриваемые пасты по убыванию просмотров.
+type ListPopularPastesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pastes        []*Paste               `protobuf:"bytes,1,rep,name=pastes,proto3" json:"pastes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPopularPastesResponse) Reset() {
+	*x = ListPopularPastesResponse{}
+	mi := &file_pastebin_v1_pastebin_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPopularPastesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPopularPastesResponse) ProtoMessage() {}
+
+func (x *ListPopularPastesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pastebin_v1_pastebin_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPopularPastesResponse.ProtoReflect.Descriptor instead.
+func (*ListPopularPastesResponse) Descriptor() ([]byte, []int) {
+	return file_pastebin_v1_pastebin_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ListPopularPastesResponse) GetPastes() []*Paste {
+	if x != nil {
+		return x.Pastes
+	}
+	return nil
+}
+
+// UpdatePasteRequest меняет поля paste из update_mask: content и expires_at.
+// Пустая маска — все заполненные изменяемые поля.
+type UpdatePasteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Paste         *Paste                 `protobuf:"bytes,1,opt,name=paste,proto3" json:"paste,omitempty"`
+	UpdateMask    *fieldmaskpb.FieldMask `protobuf:"bytes,2,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	DeleteToken   string                 `protobuf:"bytes,3,opt,name=delete_token,json=deleteToken,proto3" json:"delete_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdatePasteRequest) Reset() {
+	*x = UpdatePasteRequest{}
+	mi := &file_pastebin_v1_pastebin_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdatePasteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdatePasteRequest) ProtoMessage() {}
+
+func (x *UpdatePasteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pastebin_v1_pastebin_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdatePasteRequest.ProtoReflect.Descriptor instead.
+func (*UpdatePasteRequest) Descriptor() ([]byte, []int) {
+	return file_pastebin_v1_pastebin_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *UpdatePasteRequest) GetPaste() *Paste {
+	if x != nil {
+		return x.Paste
+	}
+	return nil
+}
+
+func (x *UpdatePasteRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+func (x *UpdatePasteRequest) GetDeleteToken() string {
+	if x != nil {
+		return x.DeleteToken
+	}
+	return ""
+}
+
+type UpdatePasteResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Paste         *Paste                 `protobuf:"bytes,1,opt,name=paste,proto3" json:"paste,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdatePasteResponse) Reset() {
+	*x = UpdatePasteResponse{}
+	mi := &file_pastebin_v1_pastebin_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdatePasteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdatePasteResponse) ProtoMessage() {}
+
+func (x *UpdatePasteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pastebin_v1_pastebin_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdatePasteResponse.ProtoReflect.Descriptor instead.
+func (*UpdatePasteResponse) Descriptor() ([]byte, []int) {
+	return file_pastebin_v1_pastebin_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *UpdatePasteResponse) GetPaste() *Paste {
+	if x != nil {
+		return x.Paste
+	}
+	return nil
+}
+
+type DeletePasteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	DeleteToken   string                 `protobuf:"bytes,2,opt,name=delete_token,json=deleteToken,proto3" json:"delete_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeletePasteRequest) Reset() {
+	*x = DeletePasteRequest{}
+	mi := &file_pastebin_v1_pastebin_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeletePasteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeletePasteRequest) ProtoMessage() {}
+
+func (x *DeletePasteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pastebin_v1_pastebin_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeletePasteRequest.ProtoReflect.Descriptor instead.
+func (*DeletePasteRequest) Descriptor() ([]byte, []int) {
+	return file_pastebin_v1_pastebin_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *DeletePasteRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DeletePasteRequest) GetDeleteToken() string {
+	if x != nil {
+		return x.DeleteToken
+	}
+	return ""
+}
+
+type DeletePasteResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeletePasteResponse) Reset() {
+	*x = DeletePasteResponse{}
+	mi := &file_pastebin_v1_pastebin_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeletePasteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeletePasteResponse) ProtoMessage() {}
+
+func (x *DeletePasteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pastebin_v1_pastebin_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeletePasteResponse.ProtoReflect.Descriptor instead.
+func (*DeletePasteResponse) Descriptor() ([]byte, []int) {
+	return file_pastebin_v1_pastebin_proto_rawDescGZIP(), []int{19}
+}
+
+// WatchPastesRequest: last_event_id — ID последнего полученного события; пусто — только новые события.
+type WatchPastesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	LastEventId   string                 `protobuf:"bytes,1,opt,name=last_event_id,json=lastEventId,proto3" json:"last_event_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchPastesRequest) Reset() {
+	*x = WatchPastesRequest{}
+	mi := &file_pastebin_v1_pastebin_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchPastesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchPastesRequest) ProtoMessage() {}
+
+func (x *WatchPastesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pastebin_v1_pastebin_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchPastesRequest.ProtoReflect.Descriptor instead.
+func (*WatchPastesRequest) Descriptor() ([]byte, []int) {
+	return file_pastebin_v1_pastebin_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *WatchPastesRequest) GetLastEventId() string {
+	if x != nil {
+		return x.LastEventId
+	}
+	return ""
+}
+
+// WatchPastesResponse — событие живой ленты: paste.created, paste.deleted, paste.expired
+// или feed.reset, если продолжить с last_event_id нельзя. Содержимое пасты не передаётся.
+type WatchPastesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Type  string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	At    *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=at,proto3" json:"at,omitempty"`
+	Paste *Paste                 `protobuf:"bytes,4,opt,name=paste,proto3" json:"paste,omitempty"`
+	// Публичный адрес новой пасты.
+	Url           string `protobuf:"bytes,5,opt,name=url,proto3" json:"url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchPastesResponse) Reset() {
+	*x = WatchPastesResponse{}
+	mi := &file_pastebin_v1_pastebin_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchPastesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchPastesResponse) ProtoMessage() {}
+
+func (x *WatchPastesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pastebin_v1_pastebin_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchPastesResponse.ProtoReflect.Descriptor instead.
+func (*WatchPastesResponse) Descriptor() ([]byte, []int) {
+	return file_pastebin_v1_pastebin_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *WatchPastesResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *WatchPastesResponse) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *WatchPastesResponse) GetAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.At
+	}
+	return nil
+}
+
+func (x *WatchPastesResponse) GetPaste() *Paste {
+	if x != nil {
+		return x.Paste
+	}
+	return nil
+}
+
+func (x *WatchPastesResponse) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+type CreateUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateUserRequest) Reset() {
+	*x = CreateUserRequest{}
+	mi := &file_pastebin_v1_pastebin_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateUserRequest) ProtoMessage() {}
+
+func (x *CreateUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pastebin_v1_pastebin_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateUserRequest.ProtoReflect.Descriptor instead.
+func (*CreateUserRequest) Descriptor() ([]byte, []int) {
+	return file_pastebin_v1_pastebin_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *CreateUserRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+type CreateUserResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          *User                  `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateUserResponse) Reset() {
+	*x = CreateUserResponse{}
+	mi := &file_pastebin_v1_pastebin_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateUserResponse) ProtoMessage() {}
+
+func (x *CreateUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pastebin_v1_pastebin_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateUserResponse.ProtoReflect.Descriptor instead.
+func (*CreateUserResponse) Descriptor() ([]byte, []int) {
+	return file_pastebin_v1_pastebin_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *CreateUserResponse) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+type GetUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserRequest) Reset() {
+	*x = GetUserRequest{}
+	mi := &file_pastebin_v1_pastebin_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserRequest) ProtoMessage() {}
+
+func (x *GetUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pastebin_v1_pastebin_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserRequest.ProtoReflect.Descriptor instead.
+func (*GetUserRequest) Descriptor() ([]byte, []int) {
+	return file_pastebin_v1_pastebin_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *GetUserRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type GetUserResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          *User                  `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserResponse) Reset() {
+	*x = GetUserResponse{}
+	mi := &file_pastebin_v1_pastebin_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserResponse) ProtoMessage() {}
+
+func (x *GetUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pastebin_v1_pastebin_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserResponse.ProtoReflect.Descriptor instead.
+func (*GetUserResponse) Descriptor() ([]byte, []int) {
+	return file_pastebin_v1_pastebin_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *GetUserResponse) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+type ListUsersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUsersRequest) Reset() {
+	*x = ListUsersRequest{}
+	mi := &file_pastebin_v1_pastebin_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUsersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUsersRequest) ProtoMessage() {}
+
+func (x *ListUsersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pastebin_v1_pastebin_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUsersRequest.ProtoReflect.Descriptor instead.
+func (*ListUsersRequest) Descriptor() ([]byte, []int) {
+	return file_pastebin_v1_pastebin_proto_rawDescGZIP(), []int{26}
+}
+
+type ListUsersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Users         []*User                `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUsersResponse) Reset() {
+	*x = ListUsersResponse{}
+	mi := &file_pastebin_v1_pastebin_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUsersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUsersResponse) ProtoMessage() {}
+
+func (x *ListUsersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pastebin_v1_pastebin_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUsersResponse.ProtoReflect.Descriptor instead.
+func (*ListUsersResponse) Descriptor() ([]byte, []int) {
+	return file_pastebin_v1_pastebin_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ListUsersResponse) GetUsers() []*User {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+type DeleteUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteUserRequest) Reset() {
+	*x = DeleteUserRequest{}
+	mi := &file_pastebin_v1_pastebin_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteUserRequest) ProtoMessage() {}
+
+func (x *DeleteUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pastebin_v1_pastebin_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteUserRequest.ProtoReflect.Descriptor instead.
+func (*DeleteUserRequest) Descriptor() ([]byte, []int) {
+	return file_pastebin_v1_pastebin_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *DeleteUserRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type DeleteUserResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteUserResponse) Reset() {
+	*x = DeleteUserResponse{}
+	mi := &file_pastebin_v1_pastebin_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteUserResponse) ProtoMessage() {}
+
+func (x *DeleteUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pastebin_v1_pastebin_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteUserResponse.ProtoReflect.Descriptor instead.
+func (*DeleteUserResponse) Descriptor() ([]byte, []int) {
+	return file_pastebin_v1_pastebin_proto_rawDescGZIP(), []int{29}
+}
+
+type GetUserUsageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserUsageRequest) Reset() {
+	*x = GetUserUsageRequest{}
+	mi := &file_pastebin_v1_pastebin_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserUsageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserUsageRequest) ProtoMessage() {}
+
+func (x *GetUserUsageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pastebin_v1_pastebin_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserUsageRequest.ProtoReflect.Descriptor instead.
+func (*GetUserUsageRequest) Descriptor() ([]byte, []int) {
+	return file_pastebin_v1_pastebin_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *GetUserUsageRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type GetUserUsageResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Usage         *Usage                 `protobuf:"bytes,1,opt,name=usage,proto3" json:"usage,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserUsageResponse) Reset() {
+	*x = GetUserUsageResponse{}
+	mi := &file_pastebin_v1_pastebin_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserUsageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserUsageResponse) ProtoMessage() {}
+
+func (x *GetUserUsageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pastebin_v1_pastebin_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserUsageResponse.ProtoReflect.Descriptor instead.
+func (*GetUserUsageResponse) Descriptor() ([]byte, []int) {
+	return file_pastebin_v1_pastebin_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *GetUserUsageResponse) GetUsage() *Usage {
+	if x != nil {
+		return x.Usage
+	}
+	return nil
+}
+
+// CreateStatsRequest заводит счётчик с нулём просмотров; ID назначает сервер.
+type CreateStatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateStatsRequest) Reset() {
+	*x = CreateStatsRequest{}
+	mi := &file_pastebin_v1_pastebin_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateStatsRequest) ProtoMessage() {}
+
+func (x *CreateStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pastebin_v1_pastebin_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateStatsRequest.ProtoReflect.Descriptor instead.
+func (*CreateStatsRequest) Descriptor() ([]byte, []int) {
+	return file_pastebin_v1_pastebin_proto_rawDescGZIP(), []int{32}
+}
+
+type CreateStatsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Stats         *Stats                 `protobuf:"bytes,1,opt,name=stats,proto3" json:"stats,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateStatsResponse) Reset() {
+	*x = CreateStatsResponse{}
+	mi := &file_pastebin_v1_pastebin_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateStatsResponse) ProtoMessage() {}
+
+func (x *CreateStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pastebin_v1_pastebin_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateStatsResponse.ProtoReflect.Descriptor instead.
+func (*CreateStatsResponse) Descriptor() ([]byte, []int) {
+	return file_pastebin_v1_pastebin_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *CreateStatsResponse) GetStats() *Stats {
+	if x != nil {
+		return x.Stats
+	}
+	return nil
+}
+
+type GetStatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStatsRequest) Reset() {
+	*x = GetStatsRequest{}
+	mi := &file_pastebin_v1_pastebin_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStatsRequest) ProtoMessage() {}
+
+func (x *GetStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pastebin_v1_pastebin_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetStatsRequest) Descriptor() ([]byte, []int) {
+	return file_pastebin_v1_pastebin_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *GetStatsRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetStatsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Stats         *Stats                 `protobuf:"bytes,1,opt,name=stats,proto3" json:"stats,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStatsResponse) Reset() {
+	*x = GetStatsResponse{}
+	mi := &file_pastebin_v1_pastebin_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStatsResponse) ProtoMessage() {}
+
+func (x *GetStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pastebin_v1_pastebin_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStatsResponse.ProtoReflect.Descriptor instead.
+func (*GetStatsResponse) Descriptor() ([]byte, []int) {
+	return file_pastebin_v1_pastebin_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *GetStatsResponse) GetStats() *Stats {
+	if x != nil {
+		return x.Stats
+	}
+	return nil
+}
+
+type ListStatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListStatsRequest) Reset() {
+	*x = ListStatsRequest{}
+	mi := &file_pastebin_v1_pastebin_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListStatsRequest) ProtoMessage() {}
+
+func (x *ListStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pastebin_v1_pastebin_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListStatsRequest.ProtoReflect.Descriptor instead.
+func (*ListStatsRequest) Descriptor() ([]byte, []int) {
+	return file_pastebin_v1_pastebin_proto_rawDescGZIP(), []int{36}
+}
+
+type ListStatsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Stats         []*Stats               `protobuf:"bytes,1,rep,name=stats,proto3" json:"stats,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListStatsResponse) Reset() {
+	*x = ListStatsResponse{}
+	mi := &file_pastebin_v1_pastebin_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListStatsResponse) ProtoMessage() {}
+
+func (x *ListStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pastebin_v1_pastebin_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListStatsResponse.ProtoReflect.Descriptor instead.
+func (*ListStatsResponse) Descriptor() ([]byte, []int) {
+	return file_pastebin_v1_pastebin_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *ListStatsResponse) GetStats() []*Stats {
+	if x != nil {
+		return x.Stats
+	}
+	return nil
+}
+
+type DeleteStatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteStatsRequest) Reset() {
+	*x = DeleteStatsRequest{}
+	mi := &file_pastebin_v1_pastebin_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteStatsRequest) ProtoMessage() {}
+
+func (x *DeleteStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pastebin_v1_pastebin_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteStatsRequest.ProtoReflect.Descriptor instead.
+func (*DeleteStatsRequest) Descriptor() ([]byte, []int) {
+	return file_pastebin_v1_pastebin_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *DeleteStatsRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeleteStatsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteStatsResponse) Reset() {
+	*x = DeleteStatsResponse{}
+	mi := &file_pastebin_v1_pastebin_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteStatsResponse) ProtoMessage() {}
+
+func (x *DeleteStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pastebin_v1_pastebin_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteStatsResponse.ProtoReflect.Descriptor instead.
+func (*DeleteStatsResponse) Descriptor() ([]byte, []int) {
+	return file_pastebin_v1_pastebin_proto_rawDescGZIP(), []int{39}
+}
+
+type CreateShortURLRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Target:
+	//
+	//	*CreateShortURLRequest_Url
+	//	*CreateShortURLRequest_PasteHash
+	Target isCreateShortURLRequest_Target `protobuf_oneof:"target"`
+	Alias  string                         `protobuf:"bytes,3,opt,name=alias,proto3" json:"alias,omitempty"`
+	// 301 вместо 302 при переходе на внешний адрес.
+	Permanent     bool                   `protobuf:"varint,4,opt,name=permanent,proto3" json:"permanent,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	DeleteToken   string                 `protobuf:"bytes,6,opt,name=delete_token,json=deleteToken,proto3" json:"delete_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateShortURLRequest) Reset() {
+	*x = CreateShortURLRequest{}
+	mi := &file_pastebin_v1_pastebin_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateShortURLRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateShortURLRequest) ProtoMessage() {}
+
+func (x *CreateShortURLRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pastebin_v1_pastebin_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateShortURLRequest.ProtoReflect.Descriptor instead.
+func (*CreateShortURLRequest) Descriptor() ([]byte, []int) {
+	return file_pastebin_v1_pastebin_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *CreateShortURLRequest) GetTarget() isCreateShortURLRequest_Target {
+	if x != nil {
+		return x.Target
+	}
+	return nil
+}
+
+func (x *CreateShortURLRequest) GetUrl() string {
+	if x != nil {
+		if x, ok := x.Target.(*CreateShortURLRequest_Url); ok {
+			return x.Url
+		}
+	}
+	return ""
+}
+
+func (x *CreateShortURLRequest) GetPasteHash() string {
+	if x != nil {
+		if x, ok := x.Target.(*CreateShortURLRequest_PasteHash); ok {
+			return x.PasteHash
+		}
+	}
+	return ""
+}
+
+func (x *CreateShortURLRequest) GetAlias() string {
+	if x != nil {
+		return x.Alias
+	}
+	return ""
+}
+
+func (x *CreateShortURLRequest) GetPermanent() bool {
+	if x != nil {
+		return x.Permanent
+	}
+	return false
+}
+
+func (x *CreateShortURLRequest) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *CreateShortURLRequest) GetDeleteToken() string {
+	if x != nil {
+		return x.DeleteToken
+	}
+	return ""
+}
+
+type isCreateShortURLRequest_Target interface {
+	isCreateShortURLRequest_Target()
+}
+
+type CreateShortURLRequest_Url struct {
+	// Внешний http/https-адрес.
+	Url string `protobuf:"bytes,1,opt,name=url,proto3,oneof"`
+}
+
+type CreateShortURLRequest_PasteHash struct {
+	// Hash пасты; нужен alias и delete_token пасты.
+	PasteHash string `protobuf:"bytes,2,opt,name=paste_hash,json=pasteHash,proto3,oneof"`
+}
+
+func (*CreateShortURLRequest_Url) isCreateShortURLRequest_Target() {}
+
+func (*CreateShortURLRequest_PasteHash) isCreateShortURLRequest_Target() {}
+
+type CreateShortURLResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ShortUrl      *ShortURL              `protobuf:"bytes,1,opt,name=short_url,json=shortUrl,proto3" json:"short_url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateShortURLResponse) Reset() {
+	*x = CreateShortURLResponse{}
+	mi := &file_pastebin_v1_pastebin_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateShortURLResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateShortURLResponse) ProtoMessage() {}
+
+func (x *CreateShortURLResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pastebin_v1_pastebin_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateShortURLResponse.ProtoReflect.Descriptor instead.
+func (*CreateShortURLResponse) Descriptor() ([]byte, []int) {
+	return file_pastebin_v1_pastebin_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *CreateShortURLResponse) GetShortUrl() *ShortURL {
+	if x != nil {
+		return x.ShortUrl
+	}
+	return nil
+}
+
+type GetShortURLRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetShortURLRequest) Reset() {
+	*x = GetShortURLRequest{}
+	mi := &file_pastebin_v1_pastebin_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetShortURLRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetShortURLRequest) ProtoMessage() {}
+
+func (x *GetShortURLRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pastebin_v1_pastebin_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetShortURLRequest.ProtoReflect.Descriptor instead.
+func (*GetShortURLRequest) Descriptor() ([]byte, []int) {
+	return file_pastebin_v1_pastebin_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *GetShortURLRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetShortURLResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ShortUrl      *ShortURL              `protobuf:"bytes,1,opt,name=short_url,json=shortUrl,proto3" json:"short_url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetShortURLResponse) Reset() {
+	*x = GetShortURLResponse{}
+	mi := &file_pastebin_v1_pastebin_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetShortURLResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetShortURLResponse) ProtoMessage() {}
+
+func (x *GetShortURLResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pastebin_v1_pastebin_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetShortURLResponse.ProtoReflect.Descriptor instead.
+func (*GetShortURLResponse) Descriptor() ([]byte, []int) {
+	return file_pastebin_v1_pastebin_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *GetShortURLResponse) GetShortUrl() *ShortURL {
+	if x != nil {
+		return x.ShortUrl
+	}
+	return nil
+}
+
+type ListShortURLsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListShortURLsRequest) Reset() {
+	*x = ListShortURLsRequest{}
+	mi := &file_pastebin_v1_pastebin_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListShortURLsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListShortURLsRequest) ProtoMessage() {}
+
+func (x *ListShortURLsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pastebin_v1_pastebin_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListShortURLsRequest.ProtoReflect.Descriptor instead.
+func (*ListShortURLsRequest) Descriptor() ([]byte, []int) {
+	return file_pastebin_v1_pastebin_proto_rawDescGZIP(), []int{44}
+}
+
+type ListShortURLsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ShortUrls     []*ShortURL            `protobuf:"bytes,1,rep,name=short_urls,json=shortUrls,proto3" json:"short_urls,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListShortURLsResponse) Reset() {
+	*x = ListShortURLsResponse{}
+	mi := &file_pastebin_v1_pastebin_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListShortURLsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListShortURLsResponse) ProtoMessage() {}
+
+func (x *ListShortURLsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pastebin_v1_pastebin_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListShortURLsResponse.ProtoReflect.Descriptor instead.
+func (*ListShortURLsResponse) Descriptor() ([]byte, []int) {
+	return file_pastebin_v1_pastebin_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *ListShortURLsResponse) GetShortUrls() []*ShortURL {
+	if x != nil {
+		return x.ShortUrls
+	}
+	return nil
+}
+
+// DeleteShortURLRequest: delete_token — токен ссылки на внешний адрес или токен удаления пасты
+// для ссылки на пасту.
+type DeleteShortURLRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	DeleteToken   string                 `protobuf:"bytes,2,opt,name=delete_token,json=deleteToken,proto3" json:"delete_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteShortURLRequest) Reset() {
+	*x = DeleteShortURLRequest{}
+	mi := &file_pastebin_v1_pastebin_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteShortURLRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteShortURLRequest) ProtoMessage() {}
+
+func (x *DeleteShortURLRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pastebin_v1_pastebin_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteShortURLRequest.ProtoReflect.Descriptor instead.
+func (*DeleteShortURLRequest) Descriptor() ([]byte, []int) {
+	return file_pastebin_v1_pastebin_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *DeleteShortURLRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DeleteShortURLRequest) GetDeleteToken() string {
+	if x != nil {
+		return x.DeleteToken
+	}
+	return ""
+}
+
+type DeleteShortURLResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteShortURLResponse) Reset() {
+	*x = DeleteShortURLResponse{}
+	mi := &file_pastebin_v1_pastebin_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteShortURLResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteShortURLResponse) ProtoMessage() {}
+
+func (x *DeleteShortURLResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pastebin_v1_pastebin_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteShortURLResponse.ProtoReflect.Descriptor instead.
+func (*DeleteShortURLResponse) Descriptor() ([]byte, []int) {
+	return file_pastebin_v1_pastebin_proto_rawDescGZIP(), []int{47}
+}
+
+var File_pastebin_v1_pastebin_proto protoreflect.FileDescriptor
+
+const file_pastebin_v1_pastebin_proto_rawDesc = "" +
+	"\n" +
+	"\x1apastebin/v1/pastebin.proto\x12\vpastebin.v1\x1a\x1cgoogle/api/annotations.proto\x1a\x1egoogle/protobuf/duration.proto\x1a google/protobuf/field_mask.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a.protoc-gen-openapiv2/options/annotations.proto\"\xfe\x01\n" +
+	"\x05Paste\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04hash\x18\x02 \x01(\tR\x04hash\x12\x18\n" +
+	"\acontent\x18\x03 \x01(\tR\acontent\x129\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"expires_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12\x14\n" +
+	"\x05views\x18\x06 \x01(\x03R\x05views\x12\x17\n" +
+	"\auser_id\x18\a \x01(\x03R\x06userId\x12\x12\n" +
+	"\x04size\x18\b \x01(\x03R\x04size\"H\n" +
+	"\x04User\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12\x14\n" +
+	"\x05posts\x18\x03 \x03(\tR\x05posts\"\xae\x01\n" +
+	"\x05Quota\x12&\n" +
+	"\x0fmax_paste_bytes\x18\x01 \x01(\x03R\rmaxPasteBytes\x12(\n" +
+	"\x10max_stored_bytes\x18\x02 \x01(\x03R\x0emaxStoredBytes\x12&\n" +
+	"\x0fmax_live_pastes\x18\x03 \x01(\x03R\rmaxLivePastes\x12+\n" +
+	"\x12max_pastes_per_day\x18\x04 \x01(\x03R\x0fmaxPastesPerDay\"\x9a\x01\n" +
+	"\x05Usage\x12\x1f\n" +
+	"\vlive_pastes\x18\x01 \x01(\x03R\n" +
+	"livePastes\x12!\n" +
+	"\fstored_bytes\x18\x02 \x01(\x03R\vstoredBytes\x12!\n" +
+	"\fpastes_today\x18\x03 \x01(\x03R\vpastesToday\x12*\n" +
+	"\x06limits\x18\x04 \x01(\v2\x12.pastebin.v1.QuotaR\x06limits\"-\n" +
+	"\x05Stats\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05views\x18\x02 \x01(\x03R\x05views\"\xa2\x02\n" +
+	"\bShortURL\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1a\n" +
+	"\boriginal\x18\x02 \x01(\tR\boriginal\x12\x1f\n" +
+	"\vtarget_type\x18\x03 \x01(\tR\n" +
+	"targetType\x12\x1c\n" +
+	"\tpermanent\x18\x04 \x01(\bR\tpermanent\x129\n" +
+	"\n" +
+	"expires_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x129\n" +
+	"\n" +
+	"deleted_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tdeletedAt\x12\x12\n" +
+	"\x04link\x18\a \x01(\tR\x04link\x12!\n" +
+	"\fdelete_token\x18\b \x01(\tR\vdeleteToken\"\xd7\x01\n" +
+	"\x12CreatePasteRequest\x12\x18\n" +
+	"\acontent\x18\x01 \x01(\tR\acontent\x12;\n" +
+	"\n" +
+	"expires_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampH\x00R\texpiresAt\x12-\n" +
+	"\x03ttl\x18\x03 \x01(\v2\x19.google.protobuf.DurationH\x00R\x03ttl\x12\x17\n" +
+	"\auser_id\x18\x04 \x01(\x03R\x06userId\x12\x14\n" +
+	"\x05alias\x18\x05 \x01(\tR\x05aliasB\f\n" +
+	"\n" +
+	"expiration\"\x9e\x01\n" +
+	"\x13CreatePasteResponse\x12(\n" +
+	"\x05paste\x18\x01 \x01(\v2\x12.pastebin.v1.PasteR\x05paste\x12!\n" +
+	"\fdelete_token\x18\x02 \x01(\tR\vdeleteToken\x12\x1d\n" +
+	"\n" +
+	"short_code\x18\x03 \x01(\tR\tshortCode\x12\x1b\n" +
+	"\tshort_url\x18\x04 \x01(\tR\bshortUrl\"!\n" +
+	"\x0fGetPasteRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"<\n" +
+	"\x10GetPasteResponse\x12(\n" +
+	"\x05paste\x18\x01 \x01(\v2\x12.pastebin.v1.PasteR\x05paste\"+\n" +
+	"\x15GetPasteByHashRequest\x12\x12\n" +
+	"\x04hash\x18\x01 \x01(\tR\x04hash\"B\n" +
+	"\x16GetPasteByHashResponse\x12(\n" +
+	"\x05paste\x18\x01 \x01(\v2\x12.pastebin.v1.PasteR\x05paste\"\x13\n" +
+	"\x11ListPastesRequest\"@\n" +
+	"\x12ListPastesResponse\x12*\n" +
+	"\x06pastes\x18\x01 \x03(\v2\x12.pastebin.v1.PasteR\x06pastes\"0\n" +
+	"\x18ListPopularPastesRequest\x12\x14\n" +
+	"\x05limit\x18\x01 \x01(\x05R\x05limit\"G\n" +
+	"\x19ListPopularPastesResponse\x12*\n" +
+	"\x06pastes\x18\x01 \x03(\v2\x12.pastebin.v1.PasteR\x06pastes\"\x9e\x01\n" +
+	"\x12UpdatePasteRequest\x12(\n" +
+	"\x05paste\x18\x01 \x01(\v2\x12.pastebin.v1.PasteR\x05paste\x12;\n" +
+	"\vupdate_mask\x18\x02 \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMask\x12!\n" +
+	"\fdelete_token\x18\x03 \x01(\tR\vdeleteToken\"?\n" +
+	"\x13UpdatePasteResponse\x12(\n" +
+	"\x05paste\x18\x01 \x01(\v2\x12.pastebin.v1.PasteR\x05paste\"G\n" +
+	"\x12DeletePasteRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12!\n" +
+	"\fdelete_token\x18\x02 \x01(\tR\vdeleteToken\"\x15\n" +
+	"\x13DeletePasteResponse\"8\n" +
+	"\x12WatchPastesRequest\x12\"\n" +
+	"\rlast_event_id\x18\x01 \x01(\tR\vlastEventId\"\xa1\x01\n" +
+	"\x13WatchPastesResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12*\n" +
+	"\x02at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x02at\x12(\n" +
+	"\x05paste\x18\x04 \x01(\v2\x12.pastebin.v1.PasteR\x05paste\x12\x10\n" +
+	"\x03url\x18\x05 \x01(\tR\x03url\"/\n" +
+	"\x11CreateUserRequest\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\";\n" +
+	"\x12CreateUserResponse\x12%\n" +
+	"\x04user\x18\x01 \x01(\v2\x11.pastebin.v1.UserR\x04user\" \n" +
+	"\x0eGetUserRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"8\n" +
+	"\x0fGetUserResponse\x12%\n" +
+	"\x04user\x18\x01 \x01(\v2\x11.pastebin.v1.UserR\x04user\"\x12\n" +
+	"\x10ListUsersRequest\"<\n" +
+	"\x11ListUsersResponse\x12'\n" +
+	"\x05users\x18\x01 \x03(\v2\x11.pastebin.v1.UserR\x05users\"#\n" +
+	"\x11DeleteUserRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"\x14\n" +
+	"\x12DeleteUserResponse\"%\n" +
+	"\x13GetUserUsageRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"@\n" +
+	"\x14GetUserUsageResponse\x12(\n" +
+	"\x05usage\x18\x01 \x01(\v2\x12.pastebin.v1.UsageR\x05usage\"\x1e\n" +
+	"\x12CreateStatsRequestJ\x04\b\x01\x10\x02R\x02id\"?\n" +
+	"\x13CreateStatsResponse\x12(\n" +
+	"\x05stats\x18\x01 \x01(\v2\x12.pastebin.v1.StatsR\x05stats\"!\n" +
+	"\x0fGetStatsRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"<\n" +
+	"\x10GetStatsResponse\x12(\n" +
+	"\x05stats\x18\x01 \x01(\v2\x12.pastebin.v1.StatsR\x05stats\"\x12\n" +
+	"\x10ListStatsRequest\"=\n" +
+	"\x11ListStatsResponse\x12(\n" +
+	"\x05stats\x18\x01 \x03(\v2\x12.pastebin.v1.StatsR\x05stats\"$\n" +
+	"\x12DeleteStatsRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x15\n" +
+	"\x13DeleteStatsResponse\"\xe8\x01\n" +
+	"\x15CreateShortURLRequest\x12\x12\n" +
+	"\x03url\x18\x01 \x01(\tH\x00R\x03url\x12\x1f\n" +
+	"\n" +
+	"paste_hash\x18\x02 \x01(\tH\x00R\tpasteHash\x12\x14\n" +
+	"\x05alias\x18\x03 \x01(\tR\x05alias\x12\x1c\n" +
+	"\tpermanent\x18\x04 \x01(\bR\tpermanent\x129\n" +
+	"\n" +
+	"expires_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12!\n" +
+	"\fdelete_token\x18\x06 \x01(\tR\vdeleteTokenB\b\n" +
+	"\x06target\"L\n" +
+	"\x16CreateShortURLResponse\x122\n" +
+	"\tshort_url\x18\x01 \x01(\v2\x15.pastebin.v1.ShortURLR\bshortUrl\"$\n" +
+	"\x12GetShortURLRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"I\n" +
+	"\x13GetShortURLResponse\x122\n" +
+	"\tshort_url\x18\x01 \x01(\v2\x15.pastebin.v1.ShortURLR\bshortUrl\"\x16\n" +
+	"\x14ListShortURLsRequest\"M\n" +
+	"\x15ListShortURLsResponse\x124\n" +
+	"\n" +
+	"short_urls\x18\x01 \x03(\v2\x15.pastebin.v1.ShortURLR\tshortUrls\"J\n" +
+	"\x15DeleteShortURLRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12!\n" +
+	"\fdelete_token\x18\x02 \x01(\tR\vdeleteToken\"\x18\n" +
+	"\x16DeleteShortURLResponse2\xb2\b\n" +
+	"\fPasteService\x12j\n" +
+	"\vCreatePaste\x12\x1f.pastebin.v1.CreatePasteRequest\x1a .pastebin.v1.CreatePasteResponse\"\x18\x82\xd3\xe4\x93\x02\x12:\x01*\"\r/api/v1/paste\x12j\n" +
+	"\bGetPaste\x12\x1c.pastebin.v1.GetPasteRequest\x1a\x1d.pastebin.v1.GetPasteResponse\"!\x82\xd3\xe4\x93\x02\x1bb\x05paste\x12\x12/api/v1/paste/{id}\x12\x83\x01\n" +
+	"\x0eGetPasteByHash\x12\".pastebin.v1.GetPasteByHashRequest\x1a#.pastebin.v1.GetPasteByHashResponse\"(\x82\xd3\xe4\x93\x02\"b\x05paste\x12\x19/api/v1/paste/hash/{hash}\x12M\n" +
+	"\n" +
+	"ListPastes\x12\x1e.pastebin.v1.ListPastesRequest\x1a\x1f.pastebin.v1.ListPastesResponse\x12\x89\x01\n" +
+	"\x11ListPopularPastes\x12%.pastebin.v1.ListPopularPastesRequest\x1a&.pastebin.v1.ListPopularPastesResponse\"%\x82\xd3\xe4\x93\x02\x1fb\x06pastes\x12\x15/api/v1/paste/popular\x12\xd3\x01\n" +
+	"\vUpdatePaste\x12\x1f.pastebin.v1.UpdatePasteRequest\x1a .pastebin.v1.UpdatePasteResponse\"\x80\x01\x92AOrM\n" +
+	"K\n" +
+	"\x0eX-Delete-Token\x127Токен удаления (вместо delete_token)\x18\x01\x82\xd3\xe4\x93\x02(:\x05pasteb\x05paste2\x18/api/v1/paste/{paste.id}\x12\xbe\x01\n" +
+	"\vDeletePaste\x12\x1f.pastebin.v1.DeletePasteRequest\x1a .pastebin.v1.DeletePasteResponse\"l\x92AOrM\n" +
+	"K\n" +
+	"\x0eX-Delete-Token\x127Токен удаления (вместо delete_token)\x18\x01\x82\xd3\xe4\x93\x02\x14*\x12/api/v1/paste/{id}\x12R\n" +
+	"\vWatchPastes\x12\x1f.pastebin.v1.WatchPastesRequest\x1a .pastebin.v1.WatchPastesResponse0\x012\xb2\x04\n" +
+	"\vUserService\x12l\n" +
+	"\n" +
+	"CreateUser\x12\x1e.pastebin.v1.CreateUserRequest\x1a\x1f.pastebin.v1.CreateUserResponse\"\x1d\x82\xd3\xe4\x93\x02\x17:\x01*b\x04user\"\f/api/v1/user\x12e\n" +
+	"\aGetUser\x12\x1b.pastebin.v1.GetUserRequest\x1a\x1c.pastebin.v1.GetUserResponse\"\x1f\x82\xd3\xe4\x93\x02\x19b\x04user\x12\x11/api/v1/user/{id}\x12g\n" +
+	"\tListUsers\x12\x1d.pastebin.v1.ListUsersRequest\x1a\x1e.pastebin.v1.ListUsersResponse\"\x1b\x82\xd3\xe4\x93\x02\x15b\x05users\x12\f/api/v1/user\x12h\n" +
+	"\n" +
+	"DeleteUser\x12\x1e.pastebin.v1.DeleteUserRequest\x1a\x1f.pastebin.v1.DeleteUserResponse\"\x19\x82\xd3\xe4\x93\x02\x13*\x11/api/v1/user/{id}\x12{\n" +
+	"\fGetUserUsage\x12 .pastebin.v1.GetUserUsageRequest\x1a!.pastebin.v1.GetUserUsageResponse\"&\x82\xd3\xe4\x93\x02 b\x05usage\x12\x17/api/v1/user/{id}/usage2\xc3\x03\n" +
+	"\fStatsService\x12q\n" +
+	"\vCreateStats\x12\x1f.pastebin.v1.CreateStatsRequest\x1a .pastebin.v1.CreateStatsResponse\"\x1f\x82\xd3\xe4\x93\x02\x19:\x01*b\x05stats\"\r/api/v1/stats\x12i\n" +
+	"\bGetStats\x12\x1c.pastebin.v1.GetStatsRequest\x1a\x1d.pastebin.v1.GetStatsResponse\" \x82\xd3\xe4\x93\x02\x1ab\x05stats\x12\x11/api/v1/stat/{id}\x12h\n" +
+	"\tListStats\x12\x1d.pastebin.v1.ListStatsRequest\x1a\x1e.pastebin.v1.ListStatsResponse\"\x1c\x82\xd3\xe4\x93\x02\x16b\x05stats\x12\r/api/v1/stats\x12k\n" +
+	"\vDeleteStats\x12\x1f.pastebin.v1.DeleteStatsRequest\x1a .pastebin.v1.DeleteStatsResponse\"\x19\x82\xd3\xe4\x93\x02\x13*\x11/api/v1/stat/{id}2\xe0\x06\n" +
+	"\x0fShortURLService\x12\x8e\x02\n" +
+	"\x0eCreateShortURL\x12\".pastebin.v1.CreateShortURLRequest\x1a#.pastebin.v1.CreateShortURLResponse\"\xb2\x01\x92AZrX\n" +
+	"V\n" +
+	"\x0eX-Delete-Token\x12BТокен удаления пасты (вместо delete_token)\x18\x01\x82\xd3\xe4\x93\x02O:\x01*Z-:\x01*b\tshort_url\"\x1d/api/v1/shorturl/{paste_hash}b\tshort_url\"\x10/api/v1/shorturl\x12z\n" +
+	"\vGetShortURL\x12\x1f.pastebin.v1.GetShortURLRequest\x1a .pastebin.v1.GetShortURLResponse\"(\x82\xd3\xe4\x93\x02\"b\tshort_url\x12\x15/api/v1/shorturl/{id}\x12}\n" +
+	"\rListShortURLs\x12!.pastebin.v1.ListShortURLsRequest\x1a\".pastebin.v1.ListShortURLsResponse\"%\x82\xd3\xe4\x93\x02\x1fb\n" +
+	"short_urls\x12\x11/api/v1/shorturls\x12\xc0\x02\n" +
+	"\x0eDeleteShortURL\x12\".pastebin.v1.DeleteShortURLRequest\x1a#.pastebin.v1.DeleteShortURLResponse\"\xe4\x01\x92A\xc3\x01r\xc0\x01\n" +
+	"K\n" +
+	"\x0eX-Delete-Token\x127Токен удаления (вместо delete_token)\x18\x01\n" +
+	"q\n" +
+	"\rX-Admin-Token\x12^Токен администратора: удаление без токена удаления\x18\x01\x82\xd3\xe4\x93\x02\x17*\x15/api/v1/shorturl/{id}B\xfa\x02\x92A\xac\x02\x12\x85\x02\n" +
+	"\fPastebin API\x12\xef\x01API для управления пастами, пользователями, статистикой и короткими URL. REST-маршруты и эта спецификация генерируются из internal/pb/pastebin/v1.2\x031.02\x10application/json:\x10application/jsonZHgithub.com/GritsyukLeonid/pastebin-go/internal/pb/pastebin/v1;pastebinv1b\x06proto3"
+
+var (
+	file_pastebin_v1_pastebin_proto_rawDescOnce sync.Once
+	file_pastebin_v1_pastebin_proto_rawDescData []byte
+)
+
+func file_pastebin_v1_pastebin_proto_rawDescGZIP() []byte {
+	file_pastebin_v1_pastebin_proto_rawDescOnce.Do(func() {
+		file_pastebin_v1_pastebin_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_pastebin_v1_pastebin_proto_rawDesc), len(file_pastebin_v1_pastebin_proto_rawDesc)))
+	})
+	return file_pastebin_v1_pastebin_proto_rawDescData
+}
+
+var file_pastebin_v1_pastebin_proto_msgTypes = make([]protoimpl.MessageInfo, 48)
+var file_pastebin_v1_pastebin_proto_goTypes = []any{
+	(*Paste)(nil),                     // 0: pastebin.v1.Paste
+	(*User)(nil),                      // 1: pastebin.v1.User
+	(*Quota)(nil),                     // 2: pastebin.v1.Quota
+	(*Usage)(nil),                     // 3: pastebin.v1.Usage
+	(*Stats)(nil),                     // 4: pastebin.v1.Stats
+	(*ShortURL)(nil),                  // 5: pastebin.v1.ShortURL
+	(*CreatePasteRequest)(nil),        // 6: pastebin.v1.CreatePasteRequest
+	(*CreatePasteResponse)(nil),       // 7: pastebin.v1.CreatePasteResponse
+	(*GetPasteRequest)(nil),           // 8: pastebin.v1.GetPasteRequest
+	(*GetPasteResponse)(nil),          // 9: pastebin.v1.GetPasteResponse
+	(*GetPasteByHashRequest)(nil),     // 10: pastebin.v1.GetPasteByHashRequest
+	(*GetPasteByHashResponse)(nil),    // 11: pastebin.v1.GetPasteByHashResponse
+	(*ListPastesRequest)(nil),         // 12: pastebin.v1.ListPastesRequest
+	(*ListPastesResponse)(nil),        // 13: pastebin.v1.ListPastesResponse
+	(*ListPopularPastesRequest)(nil),  // 14: pastebin.v1.ListPopularPastesRequest
+	(*ListPopularPastesResponse)(nil), // 15: pastebin.v1.ListPopularPastesResponse
+	(*UpdatePasteRequest)(nil),        // 16: pastebin.v1.UpdatePasteRequest
+	(*UpdatePasteResponse)(nil),       // 17: pastebin.v1.UpdatePasteResponse
+	(*DeletePasteRequest)(nil),        // 18: pastebin.v1.DeletePasteRequest
+	(*DeletePasteResponse)(nil),       // 19: pastebin.v1.DeletePasteResponse
+	(*WatchPastesRequest)(nil),        // 20: pastebin.v1.WatchPastesRequest
+	(*WatchPastesResponse)(nil),       // 21: pastebin.v1.WatchPastesResponse
+	(*CreateUserRequest)(nil),         // 22: pastebin.v1.CreateUserRequest
+	(*CreateUserResponse)(nil),        // 23: pastebin.v1.CreateUserResponse
+	(*GetUserRequest)(nil),            // 24: pastebin.v1.GetUserRequest
+	(*GetUserResponse)(nil),           // 25: pastebin.v1.GetUserResponse
+	(*ListUsersRequest)(nil),          // 26: pastebin.v1.ListUsersRequest
+	(*ListUsersResponse)(nil),         // 27: pastebin.v1.ListUsersResponse
+	(*DeleteUserRequest)(nil),         // 28: pastebin.v1.DeleteUserRequest
+	(*DeleteUserResponse)(nil),        // 29: pastebin.v1.DeleteUserResponse
+	(*GetUserUsageRequest)(nil),       // 30: pastebin.v1.GetUserUsageRequest
+	(*GetUserUsageResponse)(nil),      // 31: pastebin.v1.GetUserUsageResponse
+	(*CreateStatsRequest)(nil),        // 32: pastebin.v1.CreateStatsRequest
+	(*CreateStatsResponse)(nil),       // 33: pastebin.v1.CreateStatsResponse
+	(*GetStatsRequest)(nil),           // 34: pastebin.v1.GetStatsRequest
+	(*GetStatsResponse)(nil),          // 35: pastebin.v1.GetStatsResponse
+	(*ListStatsRequest)(nil),          // 36: pastebin.v1.ListStatsRequest
+	(*ListStatsResponse)(nil),         // 37: pastebin.v1.ListStatsResponse
+	(*DeleteStatsRequest)(nil),        // 38: pastebin.v1.DeleteStatsRequest
+	(*DeleteStatsResponse)(nil),       // 39: pastebin.v1.DeleteStatsResponse
+	(*CreateShortURLRequest)(nil),     // 40: pastebin.v1.CreateShortURLRequest
+	(*CreateShortURLResponse)(nil),    // 41: pastebin.v1.CreateShortURLResponse
+	(*GetShortURLRequest)(nil),        // 42: pastebin.v1.GetShortURLRequest
+	(*GetShortURLResponse)(nil),       // 43: pastebin.v1.GetShortURLResponse
+	(*ListShortURLsRequest)(nil),      // 44: pastebin.v1.ListShortURLsRequest
+	(*ListShortURLsResponse)(nil),     // 45: pastebin.v1.ListShortURLsResponse
+	(*DeleteShortURLRequest)(nil),     // 46: pastebin.v1.DeleteShortURLRequest
+	(*DeleteShortURLResponse)(nil),    // 47: pastebin.v1.DeleteShortURLResponse
+	(*timestamppb.Timestamp)(nil),     // 48: google.protobuf.Timestamp
+	(*durationpb.Duration)(nil),       // 49: google.protobuf.Duration
+	(*fieldmaskpb.FieldMask)(nil),     // 50: google.protobuf.FieldMask
+}
+var file_pastebin_v1_pastebin_proto_depIdxs = []int32{
+	48, // 0: pastebin.v1.Paste.created_at:type_name -> google.protobuf.Timestamp
+	48, // 1: pastebin.v1.Paste.expires_at:type_name -> google.protobuf.Timestamp
+	2,  // 2: pastebin.v1.Usage.limits:type_name -> pastebin.v1.Quota
+	48, // 3: pastebin.v1.ShortURL.expires_at:type_name -> google.protobuf.Timestamp
+	48, // 4: pastebin.v1.ShortURL.deleted_at:type_name -> google.protobuf.Timestamp
+	48, // 5: pastebin.v1.CreatePasteRequest.expires_at:type_name -> google.protobuf.Timestamp
+	49, // 6: pastebin.v1.CreatePasteRequest.ttl:type_name -> google.protobuf.Duration
+	0,  // 7: pastebin.v1.CreatePasteResponse.paste:type_name -> pastebin.v1.Paste
+	0,  // 8: pastebin.v1.GetPasteResponse.paste:type_name -> pastebin.v1.Paste
+	0,  // 9: pastebin.v1.GetPasteByHashResponse.paste:type_name -> pastebin.v1.Paste
+	0,  // 10: pastebin.v1.ListPastesResponse.pastes:type_name -> pastebin.v1.Paste
+	0,  // 11: pastebin.v1.ListPopularPastesResponse.pastes:type_name -> pastebin.v1.Paste
+	0,  // 12: pastebin.v1.UpdatePasteRequest.paste:type_name -> pastebin.v1.Paste
+	50, // 13: pastebin.v1.UpdatePasteRequest.update_mask:type_name -> google.protobuf.FieldMask
+	0,  // 14: pastebin.v1.UpdatePasteResponse.paste:type_name -> pastebin.v1.Paste
+	48, // 15: pastebin.v1.WatchPastesResponse.at:type_name -> google.protobuf.Timestamp
+	0,  // 16: pastebin.v1.WatchPastesResponse.paste:type_name -> pastebin.v1.Paste
+	1,  // 17: pastebin.v1.CreateUserResponse.user:type_name -> pastebin.v1.User
+	1,  // 18: pastebin.v1.GetUserResponse.user:type_name -> pastebin.v1.User
+	1,  // 19: pastebin.v1.ListUsersResponse.users:type_name -> pastebin.v1.User
+	3,  // 20: pastebin.v1.GetUserUsageResponse.usage:type_name -> pastebin.v1.Usage
+	4,  // 21: pastebin.v1.CreateStatsResponse.stats:type_name -> pastebin.v1.Stats
+	4,  // 22: pastebin.v1.GetStatsResponse.stats:type_name -> pastebin.v1.Stats
+	4,  // 23: pastebin.v1.ListStatsResponse.stats:type_name -> pastebin.v1.Stats
+	48, // 24: pastebin.v1.CreateShortURLRequest.expires_at:type_name -> google.protobuf.Timestamp
+	5,  // 25: pastebin.v1.CreateShortURLResponse.short_url:type_name -> pastebin.v1.ShortURL
+	5,  // 26: pastebin.v1.GetShortURLResponse.short_url:type_name -> pastebin.v1.ShortURL
+	5,  // 27: pastebin.v1.ListShortURLsResponse.short_urls:type_name -> pastebin.v1.ShortURL
+	6,  // 28: pastebin.v1.PasteService.CreatePaste:input_type -> pastebin.v1.CreatePasteRequest
+	8,  // 29: pastebin.v1.PasteService.GetPaste:input_type -> pastebin.v1.GetPasteRequest
+	10, // 30: pastebin.v1.PasteService.GetPasteByHash:input_type -> pastebin.v1.GetPasteByHashRequest
+	12, // 31: pastebin.v1.PasteService.ListPastes:input_type -> pastebin.v1.ListPastesRequest
+	14, // 32: pastebin.v1.PasteService.ListPopularPastes:input_type -> pastebin.v1.ListPopularPastesRequest
+	16, // 33: pastebin.v1.PasteService.UpdatePaste:input_type -> pastebin.v1.UpdatePasteRequest
+	18, // 34: pastebin.v1.PasteService.DeletePaste:input_type -> pastebin.v1.DeletePasteRequest
+	20, // 35: pastebin.v1.PasteService.WatchPastes:input_type -> pastebin.v1.WatchPastesRequest
+	22, // 36: pastebin.v1.UserService.CreateUser:input_type -> pastebin.v1.CreateUserRequest
+	24, // 37: pastebin.v1.UserService.GetUser:input_type -> pastebin.v1.GetUserRequest
+	26, // 38: pastebin.v1.UserService.ListUsers:input_type -> pastebin.v1.ListUsersRequest
+	28, // 39: pastebin.v1.UserService.DeleteUser:input_type -> pastebin.v1.DeleteUserRequest
+	30, // 40: pastebin.v1.UserService.GetUserUsage:input_type -> pastebin.v1.GetUserUsageRequest
+	32, // 41: pastebin.v1.StatsService.CreateStats:input_type -> pastebin.v1.CreateStatsRequest
+	34, // 42: pastebin.v1.StatsService.GetStats:input_type -> pastebin.v1.GetStatsRequest
+	36, // 43: pastebin.v1.StatsService.ListStats:input_type -> pastebin.v1.ListStatsRequest
+	38, // 44: pastebin.v1.StatsService.DeleteStats:input_type -> pastebin.v1.DeleteStatsRequest
+	40, // 45: pastebin.v1.ShortURLService.CreateShortURL:input_type -> pastebin.v1.CreateShortURLRequest
+	42, // 46: pastebin.v1.ShortURLService.GetShortURL:input_type -> pastebin.v1.GetShortURLRequest
+	44, // 47: pastebin.v1.ShortURLService.ListShortURLs:input_type -> pastebin.v1.ListShortURLsRequest
+	46, // 48: pastebin.v1.ShortURLService.DeleteShortURL:input_type -> pastebin.v1.DeleteShortURLRequest
+	7,  // 49: pastebin.v1.PasteService.CreatePaste:output_type -> pastebin.v1.CreatePasteResponse
+	9,  // 50: pastebin.v1.PasteService.GetPaste:output_type -> pastebin.v1.GetPasteResponse
+	11, // 51: pastebin.v1.PasteService.GetPasteByHash:output_type -> pastebin.v1.GetPasteByHashResponse
+	13, // 52: pastebin.v1.PasteService.ListPastes:output_type -> pastebin.v1.ListPastesResponse
+	15, // 53: pastebin.v1.PasteService.ListPopularPastes:output_type -> pastebin.v1.ListPopularPastesResponse
+	17, // 54: pastebin.v1.PasteService.UpdatePaste:output_type -> pastebin.v1.UpdatePasteResponse
+	19, // 55: pastebin.v1.PasteService.DeletePaste:output_type -> pastebin.v1.DeletePasteResponse
+	21, // 56: pastebin.v1.PasteService.WatchPastes:output_type -> pastebin.v1.WatchPastesResponse
+	23, // 57: pastebin.v1.UserService.CreateUser:output_type -> pastebin.v1.CreateUserResponse
+	25, // 58: pastebin.v1.UserService.GetUser:output_type -> pastebin.v1.GetUserResponse
+	27, // 59: pastebin.v1.UserService.ListUsers:output_type -> pastebin.v1.ListUsersResponse
+	29, // 60: pastebin.v1.UserService.DeleteUser:output_type -> pastebin.v1.DeleteUserResponse
+	31, // 61: pastebin.v1.UserService.GetUserUsage:output_type -> pastebin.v1.GetUserUsageResponse
+	33, // 62: pastebin.v1.StatsService.CreateStats:output_type -> pastebin.v1.CreateStatsResponse
+	35, // 63: pastebin.v1.StatsService.GetStats:output_type -> pastebin.v1.GetStatsResponse
+	37, // 64: pastebin.v1.StatsService.ListStats:output_type -> pastebin.v1.ListStatsResponse
+	39, // 65: pastebin.v1.StatsService.DeleteStats:output_type -> pastebin.v1.DeleteStatsResponse
+	41, // 66: pastebin.v1.ShortURLService.CreateShortURL:output_type -> pastebin.v1.CreateShortURLResponse
+	43, // 67: pastebin.v1.ShortURLService.GetShortURL:output_type -> pastebin.v1.GetShortURLResponse
+	45, // 68: pastebin.v1.ShortURLService.ListShortURLs:output_type -> pastebin.v1.ListShortURLsResponse
+	47, // 69: pastebin.v1.ShortURLService.DeleteShortURL:output_type -> pastebin.v1.DeleteShortURLResponse
+	49, // [49:70] is the sub-list for method output_type
+	28, // [28:49] is the sub-list for method input_type
+	28, // [28:28] is the sub-list for extension type_name
+	28, // [28:28] is the sub-list for extension extendee
+	0,  // [0:28] is the sub-list for field type_name
+}
+
+func init() { file_pastebin_v1_pastebin_proto_init() }
+func file_pastebin_v1_pastebin_proto_init() {
+	if File_pastebin_v1_pastebin_proto != nil {
+		return
+	}
+	file_pastebin_v1_pastebin_proto_msgTypes[6].OneofWrappers = []any{
+		(*CreatePasteRequest_ExpiresAt)(nil),
+		(*CreatePasteRequest_Ttl)(nil),
+	}
+	file_pastebin_v1_pastebin_proto_msgTypes[40].OneofWrappers = []any{
+		(*CreateShortURLRequest_Url)(nil),
+		(*CreateShortURLRequest_PasteHash)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_pastebin_v1_pastebin_proto_rawDesc), len(file_pastebin_v1_pastebin_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   48,
+			NumExtensions: 0,
+			NumServices:   4,
+		},
+		GoTypes:           file_pastebin_v1_pastebin_proto_goTypes,
+		DependencyIndexes: file_pastebin_v1_pastebin_proto_depIdxs,
+		MessageInfos:      file_pastebin_v1_pastebin_proto_msgTypes,
+	}.Build()
+	File_pastebin_v1_pastebin_proto = out.File
+	file_pastebin_v1_pastebin_proto_goTypes = nil
+	file_pastebin_v1_pastebin_proto_depIdxs = nil
+}
@@ -2,9 +2,9 @@
 // versions:
 // - protoc-gen-go-grpc v1.5.1
 // - protoc             v3.21.12
-// source: internal/pb/pastebin.proto
+// source: pastebin/v1/pastebin.proto
 
-package pb
+package pastebinv1
 
 import (
 	context "context"
@@ -19,22 +19,45 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	PasteService_CreatePaste_FullMethodName = "/pastebin.PasteService/CreatePaste"
-	PasteService_GetPaste_FullMethodName    = "/pastebin.PasteService/GetPaste"
-	PasteService_ListPastes_FullMethodName  = "/pastebin.PasteService/ListPastes"
-	PasteService_UpdatePaste_FullMethodName = "/pastebin.PasteService/UpdatePaste"
-	PasteService_DeletePaste_FullMethodName = "/pastebin.PasteService/DeletePaste"
+	PasteService_CreatePaste_FullMethodName       = "/pastebin.v1.PasteService/CreatePaste"
+	PasteService_GetPaste_FullMethodName          = "/pastebin.v1.PasteService/GetPaste"
+	PasteService_GetPasteByHash_FullMethodName    = "/pastebin.v1.PasteService/GetPasteByHash"
+	PasteService_ListPastes_FullMethodName        = "/pastebin.v1.PasteService/ListPastes"
+	PasteService_ListPopularPastes_FullMethodName = "/pastebin.v1.PasteService/ListPopularPastes"
+	PasteService_UpdatePaste_FullMethodName       = "/pastebin.v1.PasteService/UpdatePaste"
+	PasteService_DeletePaste_FullMethodName       = "/pastebin.v1.PasteService/DeletePaste"
+	PasteService_WatchPastes_FullMethodName       = "/pastebin.v1.PasteService/WatchPastes"
 )
 
 // PasteServiceClient is the client API for PasteService service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type PasteServiceClient interface {
-	CreatePaste(ctx context.Context, in *Paste, opts ...grpc.CallOption) (*Paste, error)
-	GetPaste(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*Paste, error)
-	ListPastes(ctx context.Context, in *Empty, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Paste], error)
-	UpdatePaste(ctx context.Context, in *Paste, opts ...grpc.CallOption) (*Paste, error)
-	DeletePaste(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*Status, error)
+	// Создать пасту
+	//
+	// Без expires_at и ttl паста живёт 24 часа. Токен удаления возвращается только в этом ответе.
+	CreatePaste(ctx context.Context, in *CreatePasteRequest, opts ...grpc.CallOption) (*CreatePasteResponse, error)
+	// Получить пасту по ID
+	GetPaste(ctx context.Context, in *GetPasteRequest, opts ...grpc.CallOption) (*GetPasteResponse, error)
+	// Получить пасту по hash
+	//
+	// Увеличивает счётчик просмотров.
+	GetPasteByHash(ctx context.Context, in *GetPasteByHashRequest, opts ...grpc.CallOption) (*GetPasteByHashResponse, error)
+	ListPastes(ctx context.Context, in *ListPastesRequest, opts ...grpc.CallOption) (*ListPastesResponse, error)
+	// Популярные пасты
+	//
+	// Самые просматриваемые пасты по убыванию просмотров.
+	ListPopularPastes(ctx context.Context, in *ListPopularPastesRequest, opts ...grpc.CallOption) (*ListPopularPastesResponse, error)
+	// Изменить пасту
+	//
+	// В REST изменяемые поля берутся из тела запроса. Требует токен удаления пасты.
+	UpdatePaste(ctx context.Context, in *UpdatePasteRequest, opts ...grpc.CallOption) (*UpdatePasteResponse, error)
+	// Удалить пасту
+	//
+	// Требует токен удаления, выданный при создании пасты.
+	DeletePaste(ctx context.Context, in *DeletePasteRequest, opts ...grpc.CallOption) (*DeletePasteResponse, error)
+	// Живая лента в REST — Server-Sent Events на /api/v1/paste/stream.
+	WatchPastes(ctx context.Context, in *WatchPastesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WatchPastesResponse], error)
 }
 
 type pasteServiceClient struct {
@@ -45,9 +68,9 @@ func NewPasteServiceClient(cc grpc.ClientConnInterface) PasteServiceClient {
 	return &pasteServiceClient{cc}
 }
 
-func (c *pasteServiceClient) CreatePaste(ctx context.Context, in *Paste, opts ...grpc.CallOption) (*Paste, error) {
+func (c *pasteServiceClient) CreatePaste(ctx context.Context, in *CreatePasteRequest, opts ...grpc.CallOption) (*CreatePasteResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(Paste)
+	out := new(CreatePasteResponse)
 	err := c.cc.Invoke(ctx, PasteService_CreatePaste_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
@@ -55,9 +78,9 @@ func (c *pasteServiceClient) CreatePaste(ctx context.Context, in *Paste, opts ..
 	return out, nil
 }
 
-func (c *pasteServiceClient) GetPaste(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*Paste, error) {
+func (c *pasteServiceClient) GetPaste(ctx context.Context, in *GetPasteRequest, opts ...grpc.CallOption) (*GetPasteResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(Paste)
+	out := new(GetPasteResponse)
 	err := c.cc.Invoke(ctx, PasteService_GetPaste_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
@@ -65,28 +88,39 @@ func (c *pasteServiceClient) GetPaste(ctx context.Context, in *IDRequest, opts .
 	return out, nil
 }
 
-func (c *pasteServiceClient) ListPastes(ctx context.Context, in *Empty, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Paste], error) {
+func (c *pasteServiceClient) GetPasteByHash(ctx context.Context, in *GetPasteByHashRequest, opts ...grpc.CallOption) (*GetPasteByHashResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	stream, err := c.cc.NewStream(ctx, &PasteService_ServiceDesc.Streams[0], PasteService_ListPastes_FullMethodName, cOpts...)
+	out := new(GetPasteByHashResponse)
+	err := c.cc.Invoke(ctx, PasteService_GetPasteByHash_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
-	x := &grpc.GenericClientStream[Empty, Paste]{ClientStream: stream}
-	if err := x.ClientStream.SendMsg(in); err != nil {
+	return out, nil
+}
+
+func (c *pasteServiceClient) ListPastes(ctx context.Context, in *ListPastesRequest, opts ...grpc.CallOption) (*ListPastesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListPastesResponse)
+	err := c.cc.Invoke(ctx, PasteService_ListPastes_FullMethodName, in, out, cOpts...)
+	if err != nil {
 		return nil, err
 	}
-	if err := x.ClientStream.CloseSend(); err != nil {
+	return out, nil
+}
+
+func (c *pasteServiceClient) ListPopularPastes(ctx context.Context, in *ListPopularPastesRequest, opts ...grpc.CallOption) (*ListPopularPastesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListPopularPastesResponse)
+	err := c.cc.Invoke(ctx, PasteService_ListPopularPastes_FullMethodName, in, out, cOpts...)
+	if err != nil {
 		return nil, err
 	}
-	return x, nil
+	return out, nil
 }
 
-// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
-type PasteService_ListPastesClient = grpc.ServerStreamingClient[Paste]
-
-func (c *pasteServiceClient) UpdatePaste(ctx context.Context, in *Paste, opts ...grpc.CallOption) (*Paste, error) {
+func (c *pasteServiceClient) UpdatePaste(ctx context.Context, in *UpdatePasteRequest, opts ...grpc.CallOption) (*UpdatePasteResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(Paste)
+	out := new(UpdatePasteResponse)
 	err := c.cc.Invoke(ctx, PasteService_UpdatePaste_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
@@ -94,9 +128,9 @@ func (c *pasteServiceClient) UpdatePaste(ctx context.Context, in *Paste, opts ..
 	return out, nil
 }
 
-func (c *pasteServiceClient) DeletePaste(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*Status, error) {
+func (c *pasteServiceClient) DeletePaste(ctx context.Context, in *DeletePasteRequest, opts ...grpc.CallOption) (*DeletePasteResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(Status)
+	out := new(DeletePasteResponse)
 	err := c.cc.Invoke(ctx, PasteService_DeletePaste_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
@@ -104,15 +138,54 @@ func (c *pasteServiceClient) DeletePaste(ctx context.Context, in *IDRequest, opt
 	return out, nil
 }
 
+func (c *pasteServiceClient) WatchPastes(ctx context.Context, in *WatchPastesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WatchPastesResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &PasteService_ServiceDesc.Streams[0], PasteService_WatchPastes_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchPastesRequest, WatchPastesResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type PasteService_WatchPastesClient = grpc.ServerStreamingClient[WatchPastesResponse]
+
 // PasteServiceServer is the server API for PasteService service.
 // All implementations must embed UnimplementedPasteServiceServer
 // for forward compatibility.
 type PasteServiceServer interface {
-	CreatePaste(context.Context, *Paste) (*Paste, error)
-	GetPaste(context.Context, *IDRequest) (*Paste, error)
-	ListPastes(*Empty, grpc.ServerStreamingServer[Paste]) error
-	UpdatePaste(context.Context, *Paste) (*Paste, error)
-	DeletePaste(context.Context, *IDRequest) (*Status, error)
+	// Создать пасту
+	//
+	// Без expires_at и ttl паста живёт 24 часа. Токен удаления возвращается только в этом ответе.
+	CreatePaste(context.Context, *CreatePasteRequest) (*CreatePasteResponse, error)
+	// Получить пасту по ID
+	GetPaste(context.Context, *GetPasteRequest) (*GetPasteResponse, error)
+	// Получить пасту по hash
+	//
+	// Увеличивает счётчик просмотров.
+	GetPasteByHash(context.Context, *GetPasteByHashRequest) (*GetPasteByHashResponse, error)
+	ListPastes(context.Context, *ListPastesRequest) (*ListPastesResponse, error)
+	// Популярные пасты
+	//
+	// Самые просматриваемые пасты по убыванию просмотров.
+	ListPopularPastes(context.Context, *ListPopularPastesRequest) (*ListPopularPastesResponse, error)
+	// Изменить пасту
+	//
+	// В REST изменяемые поля берутся из тела запроса. Требует токен удаления пасты.
+	UpdatePaste(context.Context, *UpdatePasteRequest) (*UpdatePasteResponse, error)
+	// Удалить пасту
+	//
+	// Требует токен удаления, выданный при создании пасты.
+	DeletePaste(context.Context, *DeletePasteRequest) (*DeletePasteResponse, error)
+	// Живая лента в REST — Server-Sent Events на /api/v1/paste/stream.
+	WatchPastes(*WatchPastesRequest, grpc.ServerStreamingServer[WatchPastesResponse]) error
 	mustEmbedUnimplementedPasteServiceServer()
 }
 
@@ -123,21 +196,30 @@ type PasteServiceServer interface {
 // pointer dereference when methods are called.
 type UnimplementedPasteServiceServer struct{}
 
-func (UnimplementedPasteServiceServer) CreatePaste(context.Context, *Paste) (*Paste, error) {
+func (UnimplementedPasteServiceServer) CreatePaste(context.Context, *CreatePasteRequest) (*CreatePasteResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreatePaste not implemented")
 }
-func (UnimplementedPasteServiceServer) GetPaste(context.Context, *IDRequest) (*Paste, error) {
+func (UnimplementedPasteServiceServer) GetPaste(context.Context, *GetPasteRequest) (*GetPasteResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetPaste not implemented")
 }
-func (UnimplementedPasteServiceServer) ListPastes(*Empty, grpc.ServerStreamingServer[Paste]) error {
-	return status.Errorf(codes.Unimplemented, "method ListPastes not implemented")
+func (UnimplementedPasteServiceServer) GetPasteByHash(context.Context, *GetPasteByHashRequest) (*GetPasteByHashResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPasteByHash not implemented")
+}
+func (UnimplementedPasteServiceServer) ListPastes(context.Context, *ListPastesRequest) (*ListPastesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPastes not implemented")
 }
-func (UnimplementedPasteServiceServer) UpdatePaste(context.Context, *Paste) (*Paste, error) {
+func (UnimplementedPasteServiceServer) ListPopularPastes(context.Context, *ListPopularPastesRequest) (*ListPopularPastesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPopularPastes not implemented")
+}
+func (UnimplementedPasteServiceServer) UpdatePaste(context.Context, *UpdatePasteRequest) (*UpdatePasteResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdatePaste not implemented")
 }
-func (UnimplementedPasteServiceServer) DeletePaste(context.Context, *IDRequest) (*Status, error) {
+func (UnimplementedPasteServiceServer) DeletePaste(context.Context, *DeletePasteRequest) (*DeletePasteResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeletePaste not implemented")
 }
+func (UnimplementedPasteServiceServer) WatchPastes(*WatchPastesRequest, grpc.ServerStreamingServer[WatchPastesResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchPastes not implemented")
+}
 func (UnimplementedPasteServiceServer) mustEmbedUnimplementedPasteServiceServer() {}
 func (UnimplementedPasteServiceServer) testEmbeddedByValue()                      {}
 
@@ -160,7 +242,7 @@ func RegisterPasteServiceServer(s grpc.ServiceRegistrar, srv PasteServiceServer)
 }
 
 func _PasteService_CreatePaste_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Paste)
+	in := new(CreatePasteRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -172,13 +254,13 @@ func _PasteService_CreatePaste_Handler(srv interface{}, ctx context.Context, dec
 		FullMethod: PasteService_CreatePaste_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(PasteServiceServer).CreatePaste(ctx, req.(*Paste))
+		return srv.(PasteServiceServer).CreatePaste(ctx, req.(*CreatePasteRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
 func _PasteService_GetPaste_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(IDRequest)
+	in := new(GetPasteRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -190,24 +272,67 @@ func _PasteService_GetPaste_Handler(srv interface{}, ctx context.Context, dec fu
 		FullMethod: PasteService_GetPaste_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(PasteServiceServer).GetPaste(ctx, req.(*IDRequest))
+		return srv.(PasteServiceServer).GetPaste(ctx, req.(*GetPasteRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _PasteService_ListPastes_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(Empty)
-	if err := stream.RecvMsg(m); err != nil {
-		return err
+func _PasteService_GetPasteByHash_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPasteByHashRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PasteServiceServer).GetPasteByHash(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PasteService_GetPasteByHash_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PasteServiceServer).GetPasteByHash(ctx, req.(*GetPasteByHashRequest))
 	}
-	return srv.(PasteServiceServer).ListPastes(m, &grpc.GenericServerStream[Empty, Paste]{ServerStream: stream})
+	return interceptor(ctx, in, info, handler)
 }
 
-// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
-type PasteService_ListPastesServer = grpc.ServerStreamingServer[Paste]
+func _PasteService_ListPastes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPastesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PasteServiceServer).ListPastes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PasteService_ListPastes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PasteServiceServer).ListPastes(ctx, req.(*ListPastesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PasteService_ListPopularPastes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPopularPastesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PasteServiceServer).ListPopularPastes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PasteService_ListPopularPastes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PasteServiceServer).ListPopularPastes(ctx, req.(*ListPopularPastesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
 
 func _PasteService_UpdatePaste_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Paste)
+	in := new(UpdatePasteRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -219,13 +344,13 @@ func _PasteService_UpdatePaste_Handler(srv interface{}, ctx context.Context, dec
 		FullMethod: PasteService_UpdatePaste_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(PasteServiceServer).UpdatePaste(ctx, req.(*Paste))
+		return srv.(PasteServiceServer).UpdatePaste(ctx, req.(*UpdatePasteRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
 func _PasteService_DeletePaste_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(IDRequest)
+	in := new(DeletePasteRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -237,16 +362,27 @@ func _PasteService_DeletePaste_Handler(srv interface{}, ctx context.Context, dec
 		FullMethod: PasteService_DeletePaste_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(PasteServiceServer).DeletePaste(ctx, req.(*IDRequest))
+		return srv.(PasteServiceServer).DeletePaste(ctx, req.(*DeletePasteRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PasteService_WatchPastes_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchPastesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(PasteServiceServer).WatchPastes(m, &grpc.GenericServerStream[WatchPastesRequest, WatchPastesResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type PasteService_WatchPastesServer = grpc.ServerStreamingServer[WatchPastesResponse]
+
 // PasteService_ServiceDesc is the grpc.ServiceDesc for PasteService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
 var PasteService_ServiceDesc = grpc.ServiceDesc{
-	ServiceName: "pastebin.PasteService",
+	ServiceName: "pastebin.v1.PasteService",
 	HandlerType: (*PasteServiceServer)(nil),
 	Methods: []grpc.MethodDesc{
 		{
@@ -257,6 +393,18 @@ var PasteService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetPaste",
 			Handler:    _PasteService_GetPaste_Handler,
 		},
+		{
+			MethodName: "GetPasteByHash",
+			Handler:    _PasteService_GetPasteByHash_Handler,
+		},
+		{
+			MethodName: "ListPastes",
+			Handler:    _PasteService_ListPastes_Handler,
+		},
+		{
+			MethodName: "ListPopularPastes",
+			Handler:    _PasteService_ListPopularPastes_Handler,
+		},
 		{
 			MethodName: "UpdatePaste",
 			Handler:    _PasteService_UpdatePaste_Handler,
@@ -268,31 +416,38 @@ var PasteService_ServiceDesc = grpc.ServiceDesc{
 	},
 	Streams: []grpc.StreamDesc{
 		{
-			StreamName:    "ListPastes",
-			Handler:       _PasteService_ListPastes_Handler,
+			StreamName:    "WatchPastes",
+			Handler:       _PasteService_WatchPastes_Handler,
 			ServerStreams: true,
 		},
 	},
-	Metadata: "internal/pb/pastebin.proto",
+	Metadata: "pastebin/v1/pastebin.proto",
 }
 
 const (
-	UserService_CreateUser_FullMethodName = "/pastebin.UserService/CreateUser"
-	UserService_GetUser_FullMethodName    = "/pastebin.UserService/GetUser"
-	UserService_ListUsers_FullMethodName  = "/pastebin.UserService/ListUsers"
-	UserService_UpdateUser_FullMethodName = "/pastebin.UserService/UpdateUser"
-	UserService_DeleteUser_FullMethodName = "/pastebin.UserService/DeleteUser"
+	UserService_CreateUser_FullMethodName   = "/pastebin.v1.UserService/CreateUser"
+	UserService_GetUser_FullMethodName      = "/pastebin.v1.UserService/GetUser"
+	UserService_ListUsers_FullMethodName    = "/pastebin.v1.UserService/ListUsers"
+	UserService_DeleteUser_FullMethodName   = "/pastebin.v1.UserService/DeleteUser"
+	UserService_GetUserUsage_FullMethodName = "/pastebin.v1.UserService/GetUserUsage"
 )
 
 // UserServiceClient is the client API for UserService service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type UserServiceClient interface {
-	CreateUser(ctx context.Context, in *User, opts ...grpc.CallOption) (*User, error)
-	GetUser(ctx context.Context, in *IDRequestInt, opts ...grpc.CallOption) (*User, error)
-	ListUsers(ctx context.Context, in *Empty, opts ...grpc.CallOption) (grpc.ServerStreamingClient[User], error)
-	UpdateUser(ctx context.Context, in *User, opts ...grpc.CallOption) (*User, error)
-	DeleteUser(ctx context.Context, in *IDRequestInt, opts ...grpc.CallOption) (*Status, error)
+	// Создать пользователя
+	CreateUser(ctx context.Context, in *CreateUserRequest, opts ...grpc.CallOption) (*CreateUserResponse, error)
+	// Получить пользователя по ID
+	GetUser(ctx context.Context, in *GetUserRequest, opts ...grpc.CallOption) (*GetUserResponse, error)
+	// Получить всех пользователей
+	ListUsers(ctx context.Context, in *ListUsersRequest, opts ...grpc.CallOption) (*ListUsersResponse, error)
+	// Удалить пользователя
+	DeleteUser(ctx context.Context, in *DeleteUserRequest, opts ...grpc.CallOption) (*DeleteUserResponse, error)
+	// Использование квоты
+	//
+	// Живые пасты, занятый объём, пасты за последние 24 часа и лимиты пользователя.
+	GetUserUsage(ctx context.Context, in *GetUserUsageRequest, opts ...grpc.CallOption) (*GetUserUsageResponse, error)
 }
 
 type userServiceClient struct {
@@ -303,9 +458,9 @@ func NewUserServiceClient(cc grpc.ClientConnInterface) UserServiceClient {
 	return &userServiceClient{cc}
 }
 
-func (c *userServiceClient) CreateUser(ctx context.Context, in *User, opts ...grpc.CallOption) (*User, error) {
+func (c *userServiceClient) CreateUser(ctx context.Context, in *CreateUserRequest, opts ...grpc.CallOption) (*CreateUserResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(User)
+	out := new(CreateUserResponse)
 	err := c.cc.Invoke(ctx, UserService_CreateUser_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
@@ -313,9 +468,9 @@ func (c *userServiceClient) CreateUser(ctx context.Context, in *User, opts ...gr
 	return out, nil
 }
 
-func (c *userServiceClient) GetUser(ctx context.Context, in *IDRequestInt, opts ...grpc.CallOption) (*User, error) {
+func (c *userServiceClient) GetUser(ctx context.Context, in *GetUserRequest, opts ...grpc.CallOption) (*GetUserResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(User)
+	out := new(GetUserResponse)
 	err := c.cc.Invoke(ctx, UserService_GetUser_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
@@ -323,39 +478,30 @@ func (c *userServiceClient) GetUser(ctx context.Context, in *IDRequestInt, opts
 	return out, nil
 }
 
-func (c *userServiceClient) ListUsers(ctx context.Context, in *Empty, opts ...grpc.CallOption) (grpc.ServerStreamingClient[User], error) {
+func (c *userServiceClient) ListUsers(ctx context.Context, in *ListUsersRequest, opts ...grpc.CallOption) (*ListUsersResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	stream, err := c.cc.NewStream(ctx, &UserService_ServiceDesc.Streams[0], UserService_ListUsers_FullMethodName, cOpts...)
+	out := new(ListUsersResponse)
+	err := c.cc.Invoke(ctx, UserService_ListUsers_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
-	x := &grpc.GenericClientStream[Empty, User]{ClientStream: stream}
-	if err := x.ClientStream.SendMsg(in); err != nil {
-		return nil, err
-	}
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
-	}
-	return x, nil
+	return out, nil
 }
 
-// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
-type UserService_ListUsersClient = grpc.ServerStreamingClient[User]
-
-func (c *userServiceClient) UpdateUser(ctx context.Context, in *User, opts ...grpc.CallOption) (*User, error) {
+func (c *userServiceClient) DeleteUser(ctx context.Context, in *DeleteUserRequest, opts ...grpc.CallOption) (*DeleteUserResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(User)
-	err := c.cc.Invoke(ctx, UserService_UpdateUser_FullMethodName, in, out, cOpts...)
+	out := new(DeleteUserResponse)
+	err := c.cc.Invoke(ctx, UserService_DeleteUser_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *userServiceClient) DeleteUser(ctx context.Context, in *IDRequestInt, opts ...grpc.CallOption) (*Status, error) {
+func (c *userServiceClient) GetUserUsage(ctx context.Context, in *GetUserUsageRequest, opts ...grpc.CallOption) (*GetUserUsageResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(Status)
-	err := c.cc.Invoke(ctx, UserService_DeleteUser_FullMethodName, in, out, cOpts...)
+	out := new(GetUserUsageResponse)
+	err := c.cc.Invoke(ctx, UserService_GetUserUsage_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -366,11 +512,18 @@ func (c *userServiceClient) DeleteUser(ctx context.Context, in *IDRequestInt, op
 // All implementations must embed UnimplementedUserServiceServer
 // for forward compatibility.
 type UserServiceServer interface {
-	CreateUser(context.Context, *User) (*User, error)
-	GetUser(context.Context, *IDRequestInt) (*User, error)
-	ListUsers(*Empty, grpc.ServerStreamingServer[User]) error
-	UpdateUser(context.Context, *User) (*User, error)
-	DeleteUser(context.Context, *IDRequestInt) (*Status, error)
+	// Создать пользователя
+	CreateUser(context.Context, *CreateUserRequest) (*CreateUserResponse, error)
+	// Получить пользователя по ID
+	GetUser(context.Context, *GetUserRequest) (*GetUserResponse, error)
+	// Получить всех пользователей
+	ListUsers(context.Context, *ListUsersRequest) (*ListUsersResponse, error)
+	// Удалить пользователя
+	DeleteUser(context.Context, *DeleteUserRequest) (*DeleteUserResponse, error)
+	// Использование квоты
+	//
+	// Живые пасты, занятый объём, пасты за последние 24 часа и лимиты пользователя.
+	GetUserUsage(context.Context, *GetUserUsageRequest) (*GetUserUsageResponse, error)
 	mustEmbedUnimplementedUserServiceServer()
 }
 
@@ -381,21 +534,21 @@ type UserServiceServer interface {
 // pointer dereference when methods are called.
 type UnimplementedUserServiceServer struct{}
 
-func (UnimplementedUserServiceServer) CreateUser(context.Context, *User) (*User, error) {
+func (UnimplementedUserServiceServer) CreateUser(context.Context, *CreateUserRequest) (*CreateUserResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateUser not implemented")
 }
-func (UnimplementedUserServiceServer) GetUser(context.Context, *IDRequestInt) (*User, error) {
+func (UnimplementedUserServiceServer) GetUser(context.Context, *GetUserRequest) (*GetUserResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetUser not implemented")
 }
-func (UnimplementedUserServiceServer) ListUsers(*Empty, grpc.ServerStreamingServer[User]) error {
-	return status.Errorf(codes.Unimplemented, "method ListUsers not implemented")
-}
-func (UnimplementedUserServiceServer) UpdateUser(context.Context, *User) (*User, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UpdateUser not implemented")
+func (UnimplementedUserServiceServer) ListUsers(context.Context, *ListUsersRequest) (*ListUsersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListUsers not implemented")
 }
-func (UnimplementedUserServiceServer) DeleteUser(context.Context, *IDRequestInt) (*Status, error) {
+func (UnimplementedUserServiceServer) DeleteUser(context.Context, *DeleteUserRequest) (*DeleteUserResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteUser not implemented")
 }
+func (UnimplementedUserServiceServer) GetUserUsage(context.Context, *GetUserUsageRequest) (*GetUserUsageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUserUsage not implemented")
+}
 func (UnimplementedUserServiceServer) mustEmbedUnimplementedUserServiceServer() {}
 func (UnimplementedUserServiceServer) testEmbeddedByValue()                     {}
 
@@ -418,7 +571,7 @@ func RegisterUserServiceServer(s grpc.ServiceRegistrar, srv UserServiceServer) {
 }
 
 func _UserService_CreateUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(User)
+	in := new(CreateUserRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -430,13 +583,13 @@ func _UserService_CreateUser_Handler(srv interface{}, ctx context.Context, dec f
 		FullMethod: UserService_CreateUser_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(UserServiceServer).CreateUser(ctx, req.(*User))
+		return srv.(UserServiceServer).CreateUser(ctx, req.(*CreateUserRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
 func _UserService_GetUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(IDRequestInt)
+	in := new(GetUserRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -448,42 +601,31 @@ func _UserService_GetUser_Handler(srv interface{}, ctx context.Context, dec func
 		FullMethod: UserService_GetUser_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(UserServiceServer).GetUser(ctx, req.(*IDRequestInt))
+		return srv.(UserServiceServer).GetUser(ctx, req.(*GetUserRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _UserService_ListUsers_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(Empty)
-	if err := stream.RecvMsg(m); err != nil {
-		return err
-	}
-	return srv.(UserServiceServer).ListUsers(m, &grpc.GenericServerStream[Empty, User]{ServerStream: stream})
-}
-
-// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
-type UserService_ListUsersServer = grpc.ServerStreamingServer[User]
-
-func _UserService_UpdateUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(User)
+func _UserService_ListUsers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListUsersRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(UserServiceServer).UpdateUser(ctx, in)
+		return srv.(UserServiceServer).ListUsers(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: UserService_UpdateUser_FullMethodName,
+		FullMethod: UserService_ListUsers_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(UserServiceServer).UpdateUser(ctx, req.(*User))
+		return srv.(UserServiceServer).ListUsers(ctx, req.(*ListUsersRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
 func _UserService_DeleteUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(IDRequestInt)
+	in := new(DeleteUserRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -495,7 +637,25 @@ func _UserService_DeleteUser_Handler(srv interface{}, ctx context.Context, dec f
 		FullMethod: UserService_DeleteUser_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(UserServiceServer).DeleteUser(ctx, req.(*IDRequestInt))
+		return srv.(UserServiceServer).DeleteUser(ctx, req.(*DeleteUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_GetUserUsage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUserUsageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetUserUsage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GetUserUsage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetUserUsage(ctx, req.(*GetUserUsageRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -504,7 +664,7 @@ func _UserService_DeleteUser_Handler(srv interface{}, ctx context.Context, dec f
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
 var UserService_ServiceDesc = grpc.ServiceDesc{
-	ServiceName: "pastebin.UserService",
+	ServiceName: "pastebin.v1.UserService",
 	HandlerType: (*UserServiceServer)(nil),
 	Methods: []grpc.MethodDesc{
 		{
@@ -516,41 +676,43 @@ var UserService_ServiceDesc = grpc.ServiceDesc{
 			Handler:    _UserService_GetUser_Handler,
 		},
 		{
-			MethodName: "UpdateUser",
-			Handler:    _UserService_UpdateUser_Handler,
+			MethodName: "ListUsers",
+			Handler:    _UserService_ListUsers_Handler,
 		},
 		{
 			MethodName: "DeleteUser",
 			Handler:    _UserService_DeleteUser_Handler,
 		},
-	},
-	Streams: []grpc.StreamDesc{
 		{
-			StreamName:    "ListUsers",
-			Handler:       _UserService_ListUsers_Handler,
-			ServerStreams: true,
+			MethodName: "GetUserUsage",
+			Handler:    _UserService_GetUserUsage_Handler,
 		},
 	},
-	Metadata: "internal/pb/pastebin.proto",
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "pastebin/v1/pastebin.proto",
 }
 
 const (
-	StatsService_CreateStats_FullMethodName = "/pastebin.StatsService/CreateStats"
-	StatsService_GetStats_FullMethodName    = "/pastebin.StatsService/GetStats"
-	StatsService_ListStats_FullMethodName   = "/pastebin.StatsService/ListStats"
-	StatsService_UpdateStats_FullMethodName = "/pastebin.StatsService/UpdateStats"
-	StatsService_DeleteStats_FullMethodName = "/pastebin.StatsService/DeleteStats"
+	StatsService_CreateStats_FullMethodName = "/pastebin.v1.StatsService/CreateStats"
+	StatsService_GetStats_FullMethodName    = "/pastebin.v1.StatsService/GetStats"
+	StatsService_ListStats_FullMethodName   = "/pastebin.v1.StatsService/ListStats"
+	StatsService_DeleteStats_FullMethodName = "/pastebin.v1.StatsService/DeleteStats"
 )
 
 // StatsServiceClient is the client API for StatsService service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type StatsServiceClient interface {
-	CreateStats(ctx context.Context, in *Stats, opts ...grpc.CallOption) (*Stats, error)
-	GetStats(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*Stats, error)
-	ListStats(ctx context.Context, in *Empty, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Stats], error)
-	UpdateStats(ctx context.Context, in *Stats, opts ...grpc.CallOption) (*Status, error)
-	DeleteStats(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*Status, error)
+	// Создать запись статистики
+	//
+	// Обычно статистика создаётся вместе с пастой.
+	CreateStats(ctx context.Context, in *CreateStatsRequest, opts ...grpc.CallOption) (*CreateStatsResponse, error)
+	// Получить статистику пасты
+	GetStats(ctx context.Context, in *GetStatsRequest, opts ...grpc.CallOption) (*GetStatsResponse, error)
+	// Получить всю статистику
+	ListStats(ctx context.Context, in *ListStatsRequest, opts ...grpc.CallOption) (*ListStatsResponse, error)
+	// Удалить статистику пасты
+	DeleteStats(ctx context.Context, in *DeleteStatsRequest, opts ...grpc.CallOption) (*DeleteStatsResponse, error)
 }
 
 type statsServiceClient struct {
@@ -561,9 +723,9 @@ func NewStatsServiceClient(cc grpc.ClientConnInterface) StatsServiceClient {
 	return &statsServiceClient{cc}
 }
 
-func (c *statsServiceClient) CreateStats(ctx context.Context, in *Stats, opts ...grpc.CallOption) (*Stats, error) {
+func (c *statsServiceClient) CreateStats(ctx context.Context, in *CreateStatsRequest, opts ...grpc.CallOption) (*CreateStatsResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(Stats)
+	out := new(CreateStatsResponse)
 	err := c.cc.Invoke(ctx, StatsService_CreateStats_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
@@ -571,9 +733,9 @@ func (c *statsServiceClient) CreateStats(ctx context.Context, in *Stats, opts ..
 	return out, nil
 }
 
-func (c *statsServiceClient) GetStats(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*Stats, error) {
+func (c *statsServiceClient) GetStats(ctx context.Context, in *GetStatsRequest, opts ...grpc.CallOption) (*GetStatsResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(Stats)
+	out := new(GetStatsResponse)
 	err := c.cc.Invoke(ctx, StatsService_GetStats_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
@@ -581,38 +743,19 @@ func (c *statsServiceClient) GetStats(ctx context.Context, in *IDRequest, opts .
 	return out, nil
 }
 
-func (c *statsServiceClient) ListStats(ctx context.Context, in *Empty, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Stats], error) {
+func (c *statsServiceClient) ListStats(ctx context.Context, in *ListStatsRequest, opts ...grpc.CallOption) (*ListStatsResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	stream, err := c.cc.NewStream(ctx, &StatsService_ServiceDesc.Streams[0], StatsService_ListStats_FullMethodName, cOpts...)
-	if err != nil {
-		return nil, err
-	}
-	x := &grpc.GenericClientStream[Empty, Stats]{ClientStream: stream}
-	if err := x.ClientStream.SendMsg(in); err != nil {
-		return nil, err
-	}
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
-	}
-	return x, nil
-}
-
-// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
-type StatsService_ListStatsClient = grpc.ServerStreamingClient[Stats]
-
-func (c *statsServiceClient) UpdateStats(ctx context.Context, in *Stats, opts ...grpc.CallOption) (*Status, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(Status)
-	err := c.cc.Invoke(ctx, StatsService_UpdateStats_FullMethodName, in, out, cOpts...)
+	out := new(ListStatsResponse)
+	err := c.cc.Invoke(ctx, StatsService_ListStats_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *statsServiceClient) DeleteStats(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*Status, error) {
+func (c *statsServiceClient) DeleteStats(ctx context.Context, in *DeleteStatsRequest, opts ...grpc.CallOption) (*DeleteStatsResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(Status)
+	out := new(DeleteStatsResponse)
 	err := c.cc.Invoke(ctx, StatsService_DeleteStats_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
@@ -624,11 +767,16 @@ func (c *statsServiceClient) DeleteStats(ctx context.Context, in *IDRequest, opt
 // All implementations must embed UnimplementedStatsServiceServer
 // for forward compatibility.
 type StatsServiceServer interface {
-	CreateStats(context.Context, *Stats) (*Stats, error)
-	GetStats(context.Context, *IDRequest) (*Stats, error)
-	ListStats(*Empty, grpc.ServerStreamingServer[Stats]) error
-	UpdateStats(context.Context, *Stats) (*Status, error)
-	DeleteStats(context.Context, *IDRequest) (*Status, error)
+	// Создать запись статистики
+	//
+	// Обычно статистика создаётся вместе с пастой.
+	CreateStats(context.Context, *CreateStatsRequest) (*CreateStatsResponse, error)
+	// Получить статистику пасты
+	GetStats(context.Context, *GetStatsRequest) (*GetStatsResponse, error)
+	// Получить всю статистику
+	ListStats(context.Context, *ListStatsRequest) (*ListStatsResponse, error)
+	// Удалить статистику пасты
+	DeleteStats(context.Context, *DeleteStatsRequest) (*DeleteStatsResponse, error)
 	mustEmbedUnimplementedStatsServiceServer()
 }
 
@@ -639,19 +787,16 @@ type StatsServiceServer interface {
 // pointer dereference when methods are called.
 type UnimplementedStatsServiceServer struct{}
 
-func (UnimplementedStatsServiceServer) CreateStats(context.Context, *Stats) (*Stats, error) {
+func (UnimplementedStatsServiceServer) CreateStats(context.Context, *CreateStatsRequest) (*CreateStatsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateStats not implemented")
 }
-func (UnimplementedStatsServiceServer) GetStats(context.Context, *IDRequest) (*Stats, error) {
+func (UnimplementedStatsServiceServer) GetStats(context.Context, *GetStatsRequest) (*GetStatsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetStats not implemented")
 }
-func (UnimplementedStatsServiceServer) ListStats(*Empty, grpc.ServerStreamingServer[Stats]) error {
-	return status.Errorf(codes.Unimplemented, "method ListStats not implemented")
+func (UnimplementedStatsServiceServer) ListStats(context.Context, *ListStatsRequest) (*ListStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListStats not implemented")
 }
-func (UnimplementedStatsServiceServer) UpdateStats(context.Context, *Stats) (*Status, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UpdateStats not implemented")
-}
-func (UnimplementedStatsServiceServer) DeleteStats(context.Context, *IDRequest) (*Status, error) {
+func (UnimplementedStatsServiceServer) DeleteStats(context.Context, *DeleteStatsRequest) (*DeleteStatsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteStats not implemented")
 }
 func (UnimplementedStatsServiceServer) mustEmbedUnimplementedStatsServiceServer() {}
@@ -676,7 +821,7 @@ func RegisterStatsServiceServer(s grpc.ServiceRegistrar, srv StatsServiceServer)
 }
 
 func _StatsService_CreateStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Stats)
+	in := new(CreateStatsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -688,13 +833,13 @@ func _StatsService_CreateStats_Handler(srv interface{}, ctx context.Context, dec
 		FullMethod: StatsService_CreateStats_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(StatsServiceServer).CreateStats(ctx, req.(*Stats))
+		return srv.(StatsServiceServer).CreateStats(ctx, req.(*CreateStatsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
 func _StatsService_GetStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(IDRequest)
+	in := new(GetStatsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -706,42 +851,31 @@ func _StatsService_GetStats_Handler(srv interface{}, ctx context.Context, dec fu
 		FullMethod: StatsService_GetStats_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(StatsServiceServer).GetStats(ctx, req.(*IDRequest))
+		return srv.(StatsServiceServer).GetStats(ctx, req.(*GetStatsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _StatsService_ListStats_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(Empty)
-	if err := stream.RecvMsg(m); err != nil {
-		return err
-	}
-	return srv.(StatsServiceServer).ListStats(m, &grpc.GenericServerStream[Empty, Stats]{ServerStream: stream})
-}
-
-// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
-type StatsService_ListStatsServer = grpc.ServerStreamingServer[Stats]
-
-func _StatsService_UpdateStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Stats)
+func _StatsService_ListStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListStatsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(StatsServiceServer).UpdateStats(ctx, in)
+		return srv.(StatsServiceServer).ListStats(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: StatsService_UpdateStats_FullMethodName,
+		FullMethod: StatsService_ListStats_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(StatsServiceServer).UpdateStats(ctx, req.(*Stats))
+		return srv.(StatsServiceServer).ListStats(ctx, req.(*ListStatsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
 func _StatsService_DeleteStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(IDRequest)
+	in := new(DeleteStatsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -753,7 +887,7 @@ func _StatsService_DeleteStats_Handler(srv interface{}, ctx context.Context, dec
 		FullMethod: StatsService_DeleteStats_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(StatsServiceServer).DeleteStats(ctx, req.(*IDRequest))
+		return srv.(StatsServiceServer).DeleteStats(ctx, req.(*DeleteStatsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -762,7 +896,7 @@ func _StatsService_DeleteStats_Handler(srv interface{}, ctx context.Context, dec
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
 var StatsService_ServiceDesc = grpc.ServiceDesc{
-	ServiceName: "pastebin.StatsService",
+	ServiceName: "pastebin.v1.StatsService",
 	HandlerType: (*StatsServiceServer)(nil),
 	Methods: []grpc.MethodDesc{
 		{
@@ -774,41 +908,43 @@ var StatsService_ServiceDesc = grpc.ServiceDesc{
 			Handler:    _StatsService_GetStats_Handler,
 		},
 		{
-			MethodName: "UpdateStats",
-			Handler:    _StatsService_UpdateStats_Handler,
+			MethodName: "ListStats",
+			Handler:    _StatsService_ListStats_Handler,
 		},
 		{
 			MethodName: "DeleteStats",
 			Handler:    _StatsService_DeleteStats_Handler,
 		},
 	},
-	Streams: []grpc.StreamDesc{
-		{
-			StreamName:    "ListStats",
-			Handler:       _StatsService_ListStats_Handler,
-			ServerStreams: true,
-		},
-	},
-	Metadata: "internal/pb/pastebin.proto",
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "pastebin/v1/pastebin.proto",
 }
 
 const (
-	ShortURLService_CreateShortURL_FullMethodName = "/pastebin.ShortURLService/CreateShortURL"
-	ShortURLService_GetShortURL_FullMethodName    = "/pastebin.ShortURLService/GetShortURL"
-	ShortURLService_ListShortURLs_FullMethodName  = "/pastebin.ShortURLService/ListShortURLs"
-	ShortURLService_UpdateShortURL_FullMethodName = "/pastebin.ShortURLService/UpdateShortURL"
-	ShortURLService_DeleteShortURL_FullMethodName = "/pastebin.ShortURLService/DeleteShortURL"
+	ShortURLService_CreateShortURL_FullMethodName = "/pastebin.v1.ShortURLService/CreateShortURL"
+	ShortURLService_GetShortURL_FullMethodName    = "/pastebin.v1.ShortURLService/GetShortURL"
+	ShortURLService_ListShortURLs_FullMethodName  = "/pastebin.v1.ShortURLService/ListShortURLs"
+	ShortURLService_DeleteShortURL_FullMethodName = "/pastebin.v1.ShortURLService/DeleteShortURL"
 )
 
 // ShortURLServiceClient is the client API for ShortURLService service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type ShortURLServiceClient interface {
-	CreateShortURL(ctx context.Context, in *ShortURL, opts ...grpc.CallOption) (*ShortURL, error)
-	GetShortURL(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*ShortURL, error)
-	ListShortURLs(ctx context.Context, in *Empty, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ShortURL], error)
-	UpdateShortURL(ctx context.Context, in *ShortURL, opts ...grpc.CallOption) (*Status, error)
-	DeleteShortURL(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*Status, error)
+	// Создать короткий URL
+	//
+	// Сокращает внешний http/https-адрес или создаёт ссылку на пасту по hash. Без alias код генерируется.
+	// Алиас для пасты может закрепить только владелец пасты, передав её токен удаления.
+	CreateShortURL(ctx context.Context, in *CreateShortURLRequest, opts ...grpc.CallOption) (*CreateShortURLResponse, error)
+	// Получить короткий URL по ID
+	GetShortURL(ctx context.Context, in *GetShortURLRequest, opts ...grpc.CallOption) (*GetShortURLResponse, error)
+	// Получить все короткие URL
+	ListShortURLs(ctx context.Context, in *ListShortURLsRequest, opts ...grpc.CallOption) (*ListShortURLsResponse, error)
+	// Удалить короткий URL
+	//
+	// Требует токен удаления ссылки (для ссылки на пасту — токен пасты) или токен администратора.
+	// Удалённый код не выдаётся повторно и отвечает 410.
+	DeleteShortURL(ctx context.Context, in *DeleteShortURLRequest, opts ...grpc.CallOption) (*DeleteShortURLResponse, error)
 }
 
 type shortURLServiceClient struct {
@@ -819,9 +955,9 @@ func NewShortURLServiceClient(cc grpc.ClientConnInterface) ShortURLServiceClient
 	return &shortURLServiceClient{cc}
 }
 
-func (c *shortURLServiceClient) CreateShortURL(ctx context.Context, in *ShortURL, opts ...grpc.CallOption) (*ShortURL, error) {
+func (c *shortURLServiceClient) CreateShortURL(ctx context.Context, in *CreateShortURLRequest, opts ...grpc.CallOption) (*CreateShortURLResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(ShortURL)
+	out := new(CreateShortURLResponse)
 	err := c.cc.Invoke(ctx, ShortURLService_CreateShortURL_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
@@ -829,9 +965,9 @@ func (c *shortURLServiceClient) CreateShortURL(ctx context.Context, in *ShortURL
 	return out, nil
 }
 
-func (c *shortURLServiceClient) GetShortURL(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*ShortURL, error) {
+func (c *shortURLServiceClient) GetShortURL(ctx context.Context, in *GetShortURLRequest, opts ...grpc.CallOption) (*GetShortURLResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(ShortURL)
+	out := new(GetShortURLResponse)
 	err := c.cc.Invoke(ctx, ShortURLService_GetShortURL_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
@@ -839,38 +975,19 @@ func (c *shortURLServiceClient) GetShortURL(ctx context.Context, in *IDRequest,
 	return out, nil
 }
 
-func (c *shortURLServiceClient) ListShortURLs(ctx context.Context, in *Empty, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ShortURL], error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	stream, err := c.cc.NewStream(ctx, &ShortURLService_ServiceDesc.Streams[0], ShortURLService_ListShortURLs_FullMethodName, cOpts...)
-	if err != nil {
-		return nil, err
-	}
-	x := &grpc.GenericClientStream[Empty, ShortURL]{ClientStream: stream}
-	if err := x.ClientStream.SendMsg(in); err != nil {
-		return nil, err
-	}
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
-	}
-	return x, nil
-}
-
-// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
-type ShortURLService_ListShortURLsClient = grpc.ServerStreamingClient[ShortURL]
-
-func (c *shortURLServiceClient) UpdateShortURL(ctx context.Context, in *ShortURL, opts ...grpc.CallOption) (*Status, error) {
+func (c *shortURLServiceClient) ListShortURLs(ctx context.Context, in *ListShortURLsRequest, opts ...grpc.CallOption) (*ListShortURLsResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(Status)
-	err := c.cc.Invoke(ctx, ShortURLService_UpdateShortURL_FullMethodName, in, out, cOpts...)
+	out := new(ListShortURLsResponse)
+	err := c.cc.Invoke(ctx, ShortURLService_ListShortURLs_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *shortURLServiceClient) DeleteShortURL(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*Status, error) {
+func (c *shortURLServiceClient) DeleteShortURL(ctx context.Context, in *DeleteShortURLRequest, opts ...grpc.CallOption) (*DeleteShortURLResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(Status)
+	out := new(DeleteShortURLResponse)
 	err := c.cc.Invoke(ctx, ShortURLService_DeleteShortURL_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
@@ -882,11 +999,20 @@ func (c *shortURLServiceClient) DeleteShortURL(ctx context.Context, in *IDReques
 // All implementations must embed UnimplementedShortURLServiceServer
 // for forward compatibility.
 type ShortURLServiceServer interface {
-	CreateShortURL(context.Context, *ShortURL) (*ShortURL, error)
-	GetShortURL(context.Context, *IDRequest) (*ShortURL, error)
-	ListShortURLs(*Empty, grpc.ServerStreamingServer[ShortURL]) error
-	UpdateShortURL(context.Context, *ShortURL) (*Status, error)
-	DeleteShortURL(context.Context, *IDRequest) (*Status, error)
+	// Создать короткий URL
+	//
+	// Сокращает внешний http/https-адрес или создаёт ссылку на пасту по hash. Без alias код генерируется.
+	// Алиас для пасты может закрепить только владелец пасты, передав её токен удаления.
+	CreateShortURL(context.Context, *CreateShortURLRequest) (*CreateShortURLResponse, error)
+	// Получить короткий URL по ID
+	GetShortURL(context.Context, *GetShortURLRequest) (*GetShortURLResponse, error)
+	// Получить все короткие URL
+	ListShortURLs(context.Context, *ListShortURLsRequest) (*ListShortURLsResponse, error)
+	// Удалить короткий URL
+	//
+	// Требует токен удаления ссылки (для ссылки на пасту — токен пасты) или токен администратора.
+	// Удалённый код не выдаётся повторно и отвечает 410.
+	DeleteShortURL(context.Context, *DeleteShortURLRequest) (*DeleteShortURLResponse, error)
 	mustEmbedUnimplementedShortURLServiceServer()
 }
 
@@ -897,19 +1023,16 @@ type ShortURLServiceServer interface {
 // pointer dereference when methods are called.
 type UnimplementedShortURLServiceServer struct{}
 
-func (UnimplementedShortURLServiceServer) CreateShortURL(context.Context, *ShortURL) (*ShortURL, error) {
+func (UnimplementedShortURLServiceServer) CreateShortURL(context.Context, *CreateShortURLRequest) (*CreateShortURLResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateShortURL not implemented")
 }
-func (UnimplementedShortURLServiceServer) GetShortURL(context.Context, *IDRequest) (*ShortURL, error) {
+func (UnimplementedShortURLServiceServer) GetShortURL(context.Context, *GetShortURLRequest) (*GetShortURLResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetShortURL not implemented")
 }
-func (UnimplementedShortURLServiceServer) ListShortURLs(*Empty, grpc.ServerStreamingServer[ShortURL]) error {
-	return status.Errorf(codes.Unimplemented, "method ListShortURLs not implemented")
+func (UnimplementedShortURLServiceServer) ListShortURLs(context.Context, *ListShortURLsRequest) (*ListShortURLsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListShortURLs not implemented")
 }
-func (UnimplementedShortURLServiceServer) UpdateShortURL(context.Context, *ShortURL) (*Status, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UpdateShortURL not implemented")
-}
-func (UnimplementedShortURLServiceServer) DeleteShortURL(context.Context, *IDRequest) (*Status, error) {
+func (UnimplementedShortURLServiceServer) DeleteShortURL(context.Context, *DeleteShortURLRequest) (*DeleteShortURLResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteShortURL not implemented")
 }
 func (UnimplementedShortURLServiceServer) mustEmbedUnimplementedShortURLServiceServer() {}
@@ -934,7 +1057,7 @@ func RegisterShortURLServiceServer(s grpc.ServiceRegistrar, srv ShortURLServiceS
 }
 
 func _ShortURLService_CreateShortURL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ShortURL)
+	in := new(CreateShortURLRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -946,13 +1069,13 @@ func _ShortURLService_CreateShortURL_Handler(srv interface{}, ctx context.Contex
 		FullMethod: ShortURLService_CreateShortURL_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ShortURLServiceServer).CreateShortURL(ctx, req.(*ShortURL))
+		return srv.(ShortURLServiceServer).CreateShortURL(ctx, req.(*CreateShortURLRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
 func _ShortURLService_GetShortURL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(IDRequest)
+	in := new(GetShortURLRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -964,42 +1087,31 @@ func _ShortURLService_GetShortURL_Handler(srv interface{}, ctx context.Context,
 		FullMethod: ShortURLService_GetShortURL_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ShortURLServiceServer).GetShortURL(ctx, req.(*IDRequest))
+		return srv.(ShortURLServiceServer).GetShortURL(ctx, req.(*GetShortURLRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ShortURLService_ListShortURLs_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(Empty)
-	if err := stream.RecvMsg(m); err != nil {
-		return err
-	}
-	return srv.(ShortURLServiceServer).ListShortURLs(m, &grpc.GenericServerStream[Empty, ShortURL]{ServerStream: stream})
-}
-
-// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
-type ShortURLService_ListShortURLsServer = grpc.ServerStreamingServer[ShortURL]
-
-func _ShortURLService_UpdateShortURL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ShortURL)
+func _ShortURLService_ListShortURLs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListShortURLsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ShortURLServiceServer).UpdateShortURL(ctx, in)
+		return srv.(ShortURLServiceServer).ListShortURLs(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ShortURLService_UpdateShortURL_FullMethodName,
+		FullMethod: ShortURLService_ListShortURLs_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ShortURLServiceServer).UpdateShortURL(ctx, req.(*ShortURL))
+		return srv.(ShortURLServiceServer).ListShortURLs(ctx, req.(*ListShortURLsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
 func _ShortURLService_DeleteShortURL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(IDRequest)
+	in := new(DeleteShortURLRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -1011,7 +1123,7 @@ func _ShortURLService_DeleteShortURL_Handler(srv interface{}, ctx context.Contex
 		FullMethod: ShortURLService_DeleteShortURL_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ShortURLServiceServer).DeleteShortURL(ctx, req.(*IDRequest))
+		return srv.(ShortURLServiceServer).DeleteShortURL(ctx, req.(*DeleteShortURLRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -1020,7 +1132,7 @@ func _ShortURLService_DeleteShortURL_Handler(srv interface{}, ctx context.Contex
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
 var ShortURLService_ServiceDesc = grpc.ServiceDesc{
-	ServiceName: "pastebin.ShortURLService",
+	ServiceName: "pastebin.v1.ShortURLService",
 	HandlerType: (*ShortURLServiceServer)(nil),
 	Methods: []grpc.MethodDesc{
 		{
@@ -1032,20 +1144,14 @@ var ShortURLService_ServiceDesc = grpc.ServiceDesc{
 			Handler:    _ShortURLService_GetShortURL_Handler,
 		},
 		{
-			MethodName: "UpdateShortURL",
-			Handler:    _ShortURLService_UpdateShortURL_Handler,
+			MethodName: "ListShortURLs",
+			Handler:    _ShortURLService_ListShortURLs_Handler,
 		},
 		{
 			MethodName: "DeleteShortURL",
 			Handler:    _ShortURLService_DeleteShortURL_Handler,
 		},
 	},
-	Streams: []grpc.StreamDesc{
-		{
-			StreamName:    "ListShortURLs",
-			Handler:       _ShortURLService_ListShortURLs_Handler,
-			ServerStreams: true,
-		},
-	},
-	Metadata: "internal/pb/pastebin.proto",
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "pastebin/v1/pastebin.proto",
 }
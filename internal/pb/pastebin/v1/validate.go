@@ -0,0 +1,141 @@
+package pastebinv1
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Проверки запросов до вызова сервисов: обязательные поля и формат идентификаторов.
+// Их вызывает перехватчик gRPC-сервера (internal/grpc/grpcserver) для сообщений с методом Validate;
+// бизнес-правила (квоты, алиасы, адреса назначения) проверяют сами сервисы.
+
+// maxIDLength — наибольшая длина строкового ID: пасты, хэша, короткого кода или записи статистики.
+const maxIDLength = 64
+
+var ErrInvalidRequest = errors.New("invalid request")
+
+func invalid(format string, args ...any) error {
+	return fmt.Errorf("%w: %s", ErrInvalidRequest, fmt.Sprintf(format, args...))
+}
+
+// validID проверяет строковый ID: 1–64 символа из латиницы, цифр, '-' и '_'.
+func validID(field, id string) error {
+	if id == "" {
+		return invalid("%s is required", field)
+	}
+	if len(id) > maxIDLength {
+		return invalid("%s is longer than %d characters", field, maxIDLength)
+	}
+	for _, r := range id {
+		if !(r >= 'a' && r <= 'z') && !(r >= 'A' && r <= 'Z') && !(r >= '0' && r <= '9') && r != '-' && r != '_' {
+			return invalid("%s contains unsupported character %q", field, r)
+		}
+	}
+	return nil
+}
+
+func positive(field string, id int64) error {
+	if id <= 0 {
+		return invalid("%s must be positive", field)
+	}
+	return nil
+}
+
+func notNegative(field string, n int64) error {
+	if n < 0 {
+		return invalid("%s must not be negative", field)
+	}
+	return nil
+}
+
+func (r *CreatePasteRequest) Validate() error {
+	if r.GetContent() == "" {
+		return invalid("content is required")
+	}
+	if ttl := r.GetTtl(); ttl != nil && ttl.AsDuration() <= 0 {
+		return invalid("ttl must be positive")
+	}
+	return notNegative("user_id", r.GetUserId())
+}
+
+func (r *GetPasteRequest) Validate() error { return validID("id", r.GetId()) }
+
+func (r *GetPasteByHashRequest) Validate() error { return validID("hash", r.GetHash()) }
+
+func (r *ListPopularPastesRequest) Validate() error {
+	return notNegative("limit", int64(r.GetLimit()))
+}
+
+func (r *UpdatePasteRequest) Validate() error {
+	if r.GetPaste() == nil {
+		return invalid("paste is required")
+	}
+	return validID("paste.id", r.GetPaste().GetId())
+}
+
+func (r *DeletePasteRequest) Validate() error { return validID("id", r.GetId()) }
+
+func (r *CreateUserRequest) Validate() error {
+	if r.GetUsername() == "" {
+		return invalid("username is required")
+	}
+	return nil
+}
+
+func (r *GetUserRequest) Validate() error { return positive("id", r.GetId()) }
+
+func (r *DeleteUserRequest) Validate() error { return positive("id", r.GetId()) }
+
+func (r *GetUserUsageRequest) Validate() error { return positive("id", r.GetId()) }
+
+func (r *GetStatsRequest) Validate() error { return validID("id", r.GetId()) }
+
+func (r *DeleteStatsRequest) Validate() error { return validID("id", r.GetId()) }
+
+func (r *CreateShortURLRequest) Validate() error {
+	switch target := r.GetTarget().(type) {
+	case *CreateShortURLRequest_Url:
+		if target.Url == "" {
+			return invalid("url is required")
+		}
+	case *CreateShortURLRequest_PasteHash:
+		return validID("paste_hash", target.PasteHash)
+	default:
+		return invalid("url or paste_hash is required")
+	}
+	return nil
+}
+
+func (r *GetShortURLRequest) Validate() error { return validID("id", r.GetId()) }
+
+func (r *DeleteShortURLRequest) Validate() error { return validID("id", r.GetId()) }
+
+func (r *GetShortURLAnalyticsRequest) Validate() error { return validID("id", r.GetId()) }
+
+func (r *CreateWebhookRequest) Validate() error {
+	if r.GetUrl() == "" {
+		return invalid("url is required")
+	}
+	if len(r.GetEvents()) == 0 {
+		return invalid("events are required")
+	}
+	return notNegative("user_id", r.GetUserId())
+}
+
+func (r *GetWebhookRequest) Validate() error { return positive("id", r.GetId()) }
+
+func (r *DeleteWebhookRequest) Validate() error { return positive("id", r.GetId()) }
+
+func (r *ListWebhookDeliveriesRequest) Validate() error {
+	if err := positive("id", r.GetId()); err != nil {
+		return err
+	}
+	return notNegative("limit", int64(r.GetLimit()))
+}
+
+func (r *ListAuditRequest) Validate() error {
+	if r.GetFrom() != nil && r.GetTo() != nil && r.GetTo().AsTime().Before(r.GetFrom().AsTime()) {
+		return invalid("to is before from")
+	}
+	return notNegative("limit", int64(r.GetLimit()))
+}
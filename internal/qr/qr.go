@@ -0,0 +1,194 @@
+// Package qr рисует QR-коды коротких ссылок в PNG и SVG без внешних сервисов
+// и кэширует готовые изображения.
+package qr
+
+import (
+	"bytes"
+	"container/list"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"strings"
+	"sync"
+
+	"github.com/skip2/go-qrcode"
+)
+
+const (
+	FormatPNG = "png"
+	FormatSVG = "svg"
+
+	MinSize       = 64
+	MaxSize       = 2048
+	MaxMargin     = 16
+	DefaultSize   = 256
+	DefaultMargin = 4
+)
+
+var ErrInvalidOptions = errors.New("invalid qr options")
+
+var levels = map[string]qrcode.RecoveryLevel{
+	"L": qrcode.Low,
+	"M": qrcode.Medium,
+	"Q": qrcode.High,
+	"H": qrcode.Highest,
+}
+
+// Options — параметры изображения. Size — сторона в пикселях, Margin — «тихая зона»
+// в модулях, Level — уровень коррекции ошибок L, M, Q или H.
+type Options struct {
+	Format string
+	Size   int
+	Margin int
+	Level  string
+}
+
+func DefaultOptions() Options {
+	return Options{Format: FormatPNG, Size: DefaultSize, Margin: DefaultMargin, Level: "M"}
+}
+
+func (o Options) Validate() error {
+	if o.Format != FormatPNG && o.Format != FormatSVG {
+		return fmt.Errorf("%w: format must be png or svg", ErrInvalidOptions)
+	}
+	if o.Size < MinSize || o.Size > MaxSize {
+		return fmt.Errorf("%w: size must be between %d and %d", ErrInvalidOptions, MinSize, MaxSize)
+	}
+	if o.Margin < 0 || o.Margin > MaxMargin {
+		return fmt.Errorf("%w: margin must be between 0 and %d", ErrInvalidOptions, MaxMargin)
+	}
+	if _, ok := levels[o.Level]; !ok {
+		return fmt.Errorf("%w: level must be L, M, Q or H", ErrInvalidOptions)
+	}
+	return nil
+}
+
+func (o Options) ContentType() string {
+	if o.Format == FormatSVG {
+		return "image/svg+xml"
+	}
+	return "image/png"
+}
+
+// Render кодирует content в QR-код и рисует его в формате o.Format.
+func Render(content string, o Options) ([]byte, error) {
+	if err := o.Validate(); err != nil {
+		return nil, err
+	}
+	code, err := qrcode.New(content, levels[o.Level])
+	if err != nil {
+		return nil, err
+	}
+	code.DisableBorder = true
+	modules := code.Bitmap()
+
+	if o.Format == FormatSVG {
+		return renderSVG(modules, o), nil
+	}
+	return renderPNG(modules, o)
+}
+
+// renderPNG масштабирует модули целым коэффициентом, поэтому сторона может быть чуть меньше o.Size.
+func renderPNG(modules [][]bool, o Options) ([]byte, error) {
+	total := len(modules) + 2*o.Margin
+	scale := o.Size / total
+	if scale < 1 {
+		scale = 1
+	}
+
+	img := image.NewPaletted(image.Rect(0, 0, total*scale, total*scale), color.Palette{color.White, color.Black})
+	for y, row := range modules {
+		for x, dark := range row {
+			if !dark {
+				continue
+			}
+			for dy := 0; dy < scale; dy++ {
+				for dx := 0; dx < scale; dx++ {
+					img.SetColorIndex((x+o.Margin)*scale+dx, (y+o.Margin)*scale+dy, 1)
+				}
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func renderSVG(modules [][]bool, o Options) []byte {
+	total := len(modules) + 2*o.Margin
+
+	var path strings.Builder
+	for y, row := range modules {
+		for x, dark := range row {
+			if dark {
+				fmt.Fprintf(&path, "M%d %dh1v1h-1z", x+o.Margin, y+o.Margin)
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" shape-rendering="crispEdges">`,
+		o.Size, o.Size, total, total)
+	fmt.Fprintf(&buf, `<rect width="%d" height="%d" fill="#fff"/><path fill="#000" d="%s"/></svg>`, total, total, path.String())
+	return buf.Bytes()
+}
+
+// Cache хранит последние отрисованные изображения (LRU) по содержимому и параметрам.
+type Cache struct {
+	mu       sync.Mutex
+	capacity int
+	order    *list.List
+	items    map[string]*list.Element
+}
+
+type cacheEntry struct {
+	key  string
+	data []byte
+}
+
+func NewCache(capacity int) *Cache {
+	return &Cache{capacity: capacity, order: list.New(), items: make(map[string]*list.Element)}
+}
+
+// Get возвращает изображение из кэша или рисует и запоминает его.
+func (c *Cache) Get(content string, o Options) ([]byte, error) {
+	key := fmt.Sprintf("%s|%d|%d|%s|%s", o.Format, o.Size, o.Margin, o.Level, content)
+
+	c.mu.Lock()
+	if el, ok := c.items[key]; ok {
+		c.order.MoveToFront(el)
+		c.mu.Unlock()
+		return el.Value.(*cacheEntry).data, nil
+	}
+	c.mu.Unlock()
+
+	data, err := Render(content, o)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.items[key]; ok {
+		return el.Value.(*cacheEntry).data, nil
+	}
+	c.items[key] = c.order.PushFront(&cacheEntry{key: key, data: data})
+	if c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.items, oldest.Value.(*cacheEntry).key)
+	}
+	return data, nil
+}
+
+// Len возвращает число изображений в кэше.
+func (c *Cache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
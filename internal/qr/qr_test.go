@@ -0,0 +1,81 @@
+package qr
+
+import (
+	"bytes"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderPNG(t *testing.T) {
+	data, err := Render("http://localhost:8080/s/abc123", DefaultOptions())
+	assert.NoError(t, err)
+
+	img, err := png.Decode(bytes.NewReader(data))
+	assert.NoError(t, err)
+	bounds := img.Bounds()
+	assert.Equal(t, bounds.Dx(), bounds.Dy())
+	assert.LessOrEqual(t, bounds.Dx(), DefaultSize)
+	assert.Greater(t, bounds.Dx(), DefaultSize/2)
+
+	// С тихой зоной угол белый, без неё — тёмный угол поискового узора.
+	r, _, _, _ := img.At(0, 0).RGBA()
+	assert.Equal(t, uint32(0xffff), r)
+
+	o := DefaultOptions()
+	o.Margin = 0
+	data, err = Render("http://localhost:8080/s/abc123", o)
+	assert.NoError(t, err)
+	img, err = png.Decode(bytes.NewReader(data))
+	assert.NoError(t, err)
+	r, _, _, _ = img.At(0, 0).RGBA()
+	assert.Equal(t, uint32(0), r)
+}
+
+func TestRenderSVG(t *testing.T) {
+	o := DefaultOptions()
+	o.Format = FormatSVG
+	o.Margin = 2
+	data, err := Render("http://localhost:8080/s/abc123", o)
+	assert.NoError(t, err)
+
+	svg := string(data)
+	assert.True(t, strings.HasPrefix(svg, "<svg "))
+	assert.Contains(t, svg, `width="256"`)
+	assert.Contains(t, svg, "M2 2h1v1h-1z")
+}
+
+func TestOptionsValidate(t *testing.T) {
+	valid := DefaultOptions()
+	assert.NoError(t, valid.Validate())
+
+	for _, o := range []Options{
+		{Format: "gif", Size: 256, Margin: 4, Level: "M"},
+		{Format: FormatPNG, Size: 10, Margin: 4, Level: "M"},
+		{Format: FormatPNG, Size: 256, Margin: -1, Level: "M"},
+		{Format: FormatPNG, Size: 256, Margin: 4, Level: "X"},
+	} {
+		assert.ErrorIs(t, o.Validate(), ErrInvalidOptions)
+	}
+}
+
+func TestCache(t *testing.T) {
+	c := NewCache(2)
+	o := DefaultOptions()
+
+	first, err := c.Get("a", o)
+	assert.NoError(t, err)
+	again, _ := c.Get("a", o)
+	assert.Equal(t, first, again)
+	assert.Equal(t, 1, c.Len())
+
+	_, _ = c.Get("b", o)
+	_, _ = c.Get("c", o)
+	assert.Equal(t, 2, c.Len())
+
+	_, err = c.Get("a", Options{Format: "gif"})
+	assert.ErrorIs(t, err, ErrInvalidOptions)
+	assert.Equal(t, 2, c.Len())
+}
@@ -0,0 +1,167 @@
+package ratelimit
+
+import (
+	"context"
+	"log/slog"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/GritsyukLeonid/pastebin-go/internal/reqctx"
+)
+
+// Middleware применяет Policy к HTTP-запросам и gRPC-вызовам.
+type Middleware struct {
+	limiter   Limiter
+	policy    Policy
+	isResolve func(*http.Request) bool
+}
+
+func NewMiddleware(limiter Limiter, policy Policy) *Middleware {
+	return &Middleware{limiter: limiter, policy: policy, isResolve: func(r *http.Request) bool {
+		return strings.HasPrefix(r.URL.Path, "/s/")
+	}}
+}
+
+// WithResolve задаёт, какие HTTP-запросы считаются переходами по коротким ссылкам
+// (по умолчанию — пути /s/...).
+func (m *Middleware) WithResolve(match func(*http.Request) bool) *Middleware {
+	m.isResolve = match
+	return m
+}
+
+func (m *Middleware) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		class, ok := m.classifyHTTP(r)
+		if !ok {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		res, allowed := m.allow(r.Context(), class, httpClientKey(r))
+		if res != nil {
+			setHeaders(w.Header().Set, res)
+		}
+		if !allowed {
+			http.Error(w, "rate limit exceeded", http.StatusTooManyRequests)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (m *Middleware) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if err := m.checkGRPC(ctx, info.FullMethod, func(md metadata.MD) error { return grpc.SetHeader(ctx, md) }); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+func (m *Middleware) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := m.checkGRPC(ss.Context(), info.FullMethod, ss.SetHeader); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
+
+func (m *Middleware) checkGRPC(ctx context.Context, fullMethod string, setHeader func(metadata.MD) error) error {
+	// Пробы оркестратора не должны расходовать лимиты клиентов.
+	if strings.HasPrefix(fullMethod, "/grpc.health.v1.Health/") {
+		return nil
+	}
+	res, allowed := m.allow(ctx, classifyGRPC(fullMethod), grpcClientKey(ctx))
+	if res != nil {
+		md := metadata.MD{}
+		setHeaders(func(k, v string) { md.Set(strings.ToLower(k), v) }, res)
+		_ = setHeader(md)
+	}
+	if !allowed {
+		return status.Error(codes.ResourceExhausted, "rate limit exceeded")
+	}
+	return nil
+}
+
+// allow при ошибке хранилища лимитов пропускает запрос: недоступность Redis не должна останавливать сервис.
+func (m *Middleware) allow(ctx context.Context, class Class, key string) (*Result, bool) {
+	limit := m.policy.For(class)
+	if limit.Disabled() {
+		return nil, true
+	}
+	res, err := m.limiter.Allow(ctx, string(class)+":"+key, limit)
+	if err != nil {
+		slog.WarnContext(ctx, "rate limiter unavailable, request allowed", "error", err)
+		return nil, true
+	}
+	return &res, res.Allowed
+}
+
+func setHeaders(set func(key, value string), res *Result) {
+	set("X-RateLimit-Limit", strconv.Itoa(res.Limit))
+	set("X-RateLimit-Remaining", strconv.Itoa(res.Remaining))
+	set("X-RateLimit-Reset", strconv.Itoa(ceilSeconds(res.Reset)))
+	if !res.Allowed {
+		set("Retry-After", strconv.Itoa(ceilSeconds(res.RetryAfter)))
+	}
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
+
+func (m *Middleware) classifyHTTP(r *http.Request) (Class, bool) {
+	switch {
+	case strings.HasPrefix(r.URL.Path, "/swagger/"), r.URL.Path == "/healthz", r.URL.Path == "/readyz", r.URL.Path == "/metrics":
+		return "", false
+	case m.isResolve(r):
+		return ClassResolve, true
+	case r.Method == http.MethodGet || r.Method == http.MethodHead || r.Method == http.MethodOptions:
+		return ClassRead, true
+	default:
+		return ClassWrite, true
+	}
+}
+
+func classifyGRPC(fullMethod string) Class {
+	method := fullMethod[strings.LastIndex(fullMethod, "/")+1:]
+	if strings.HasPrefix(method, "Get") || strings.HasPrefix(method, "List") {
+		return ClassRead
+	}
+	return ClassWrite
+}
+
+// httpClientKey — IP клиента: маршруты HTTP вне шлюза не проверяют ключей, а непроверенный
+// X-API-Key позволил бы обойти лимит, меняя ключ в каждом запросе.
+func httpClientKey(r *http.Request) string {
+	return "ip:" + reqctx.RemoteIP(r)
+}
+
+// grpcClientKey — исполнитель, которого записала аутентификация (service:<имя> или обезличенный
+// ключ), иначе IP клиента. Перехватчик лимитов стоит в цепочке после аутентификации.
+func grpcClientKey(ctx context.Context) string {
+	if user := reqctx.User(ctx); user != "" {
+		return user
+	}
+	if ip := reqctx.ClientIP(ctx); ip != "" {
+		return "ip:" + ip
+	}
+	if p, ok := peer.FromContext(ctx); ok {
+		if host, _, err := net.SplitHostPort(p.Addr.String()); err == nil {
+			return "ip:" + host
+		}
+		return "ip:" + p.Addr.String()
+	}
+	return "ip:unknown"
+}
@@ -0,0 +1,105 @@
+// Package ratelimit реализует ограничение частоты запросов по алгоритму token bucket.
+// Ключом служит проверенный API-ключ или сервис по сертификату, иначе IP клиента; лимиты задаются отдельно
+// для чтения, записи и переходов по коротким ссылкам.
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/GritsyukLeonid/pastebin-go/internal/config"
+)
+
+type Class string
+
+const (
+	ClassRead    Class = "read"
+	ClassWrite   Class = "write"
+	ClassResolve Class = "resolve"
+)
+
+// Limit — параметры корзины: Rate токенов в секунду, не более Burst токенов.
+type Limit struct {
+	Rate  float64
+	Burst int
+}
+
+func PerMinute(n, burst int) Limit {
+	return Limit{Rate: float64(n) / 60, Burst: burst}
+}
+
+// Disabled сообщает, что лимит не задан и запросы не ограничиваются.
+func (l Limit) Disabled() bool {
+	return l.Rate <= 0 || l.Burst <= 0
+}
+
+type Policy struct {
+	Read    Limit
+	Write   Limit
+	Resolve Limit
+}
+
+func DefaultPolicy() Policy {
+	return Policy{
+		Read:    PerMinute(300, 60),
+		Write:   PerMinute(30, 10),
+		Resolve: PerMinute(600, 120),
+	}
+}
+
+func PolicyFrom(c config.RateLimitConfig) Policy {
+	return Policy{
+		Read:    PerMinute(c.Read.PerMinute, c.Read.Burst),
+		Write:   PerMinute(c.Write.PerMinute, c.Write.Burst),
+		Resolve: PerMinute(c.Resolve.PerMinute, c.Resolve.Burst),
+	}
+}
+
+func (p Policy) For(c Class) Limit {
+	switch c {
+	case ClassRead:
+		return p.Read
+	case ClassWrite:
+		return p.Write
+	case ClassResolve:
+		return p.Resolve
+	default:
+		return Limit{}
+	}
+}
+
+type Result struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	RetryAfter time.Duration
+	// Reset — время до полного восстановления корзины.
+	Reset time.Duration
+}
+
+type Limiter interface {
+	Allow(ctx context.Context, key string, limit Limit) (Result, error)
+}
+
+// take списывает один токен из корзины с tokens токенами, пополнявшейся elapsed времени.
+// Возвращает результат и новое число токенов.
+func take(tokens float64, elapsed time.Duration, l Limit) (Result, float64) {
+	burst := float64(l.Burst)
+	tokens = math.Min(burst, tokens+elapsed.Seconds()*l.Rate)
+
+	res := Result{Limit: l.Burst}
+	if tokens >= 1 {
+		tokens--
+		res.Allowed = true
+	} else {
+		res.RetryAfter = secondsToDuration((1 - tokens) / l.Rate)
+	}
+	res.Remaining = int(math.Floor(tokens))
+	res.Reset = secondsToDuration((burst - tokens) / l.Rate)
+	return res, tokens
+}
+
+func secondsToDuration(s float64) time.Duration {
+	return time.Duration(s * float64(time.Second))
+}
@@ -0,0 +1,175 @@
+package ratelimit
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/GritsyukLeonid/pastebin-go/internal/links"
+	"github.com/GritsyukLeonid/pastebin-go/internal/reqctx"
+)
+
+func TestMemoryLimiterTokenBucket(t *testing.T) {
+	now := time.Unix(1000, 0)
+	limiter := NewMemoryLimiter()
+	limiter.now = func() time.Time { return now }
+	ctx := context.Background()
+	limit := Limit{Rate: 1, Burst: 2}
+
+	res, err := limiter.Allow(ctx, "k", limit)
+	assert.NoError(t, err)
+	assert.True(t, res.Allowed)
+	assert.Equal(t, 1, res.Remaining)
+
+	res, _ = limiter.Allow(ctx, "k", limit)
+	assert.True(t, res.Allowed)
+	assert.Equal(t, 0, res.Remaining)
+
+	res, _ = limiter.Allow(ctx, "k", limit)
+	assert.False(t, res.Allowed)
+	assert.Equal(t, time.Second, res.RetryAfter)
+
+	res, _ = limiter.Allow(ctx, "other", limit)
+	assert.True(t, res.Allowed, "корзины разных ключей независимы")
+
+	now = now.Add(time.Second)
+	res, _ = limiter.Allow(ctx, "k", limit)
+	assert.True(t, res.Allowed)
+}
+
+func TestMemoryLimiterSweepsFullBuckets(t *testing.T) {
+	now := time.Unix(1000, 0)
+	limiter := NewMemoryLimiter()
+	limiter.now = func() time.Time { return now }
+
+	_, _ = limiter.Allow(context.Background(), "k", Limit{Rate: 1, Burst: 1})
+	assert.Len(t, limiter.buckets, 1)
+
+	now = now.Add(2 * sweepInterval)
+	_, _ = limiter.Allow(context.Background(), "other", Limit{Rate: 1, Burst: 1})
+	assert.Len(t, limiter.buckets, 1)
+}
+
+func TestHTTPMiddleware(t *testing.T) {
+	policy := Policy{
+		Read:    Limit{Rate: 1, Burst: 5},
+		Write:   Limit{Rate: 0.01, Burst: 1},
+		Resolve: Limit{},
+	}
+	handler := NewMiddleware(NewMemoryLimiter(), policy).Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	do := func(method, path, apiKey string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, nil)
+		req.RemoteAddr = "198.51.100.1:1234"
+		if apiKey != "" {
+			req.Header.Set("X-API-Key", apiKey)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := do(http.MethodPost, "/api/v1/paste", "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "1", rec.Header().Get("X-RateLimit-Limit"))
+	assert.Equal(t, "0", rec.Header().Get("X-RateLimit-Remaining"))
+
+	rec = do(http.MethodPost, "/api/v1/paste", "")
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.Equal(t, "100", rec.Header().Get("Retry-After"))
+
+	rec = do(http.MethodPost, "/api/v1/paste", "secret")
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code, "непроверенный ключ не даёт новой корзины")
+
+	rec = do(http.MethodGet, "/api/v1/paste/1", "")
+	assert.Equal(t, http.StatusOK, rec.Code, "чтение ограничивается отдельно от записи")
+	assert.Equal(t, "4", rec.Header().Get("X-RateLimit-Remaining"))
+
+	rec = do(http.MethodGet, "/s/abc123", "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Empty(t, rec.Header().Get("X-RateLimit-Limit"), "лимит переходов отключён")
+
+	rec = do(http.MethodGet, "/readyz", "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Empty(t, rec.Header().Get("X-RateLimit-Limit"), "пробы не ограничиваются")
+}
+
+func TestHTTPMiddlewareKeysByClientBehindProxy(t *testing.T) {
+	builder, err := links.NewBuilder(links.Config{BaseURL: links.DefaultBaseURL, TrustForwarded: true, TrustedProxies: []string{"10.0.0.0/8"}})
+	require.NoError(t, err)
+	limited := NewMiddleware(NewMemoryLimiter(), Policy{Write: Limit{Rate: 0.01, Burst: 1}}).Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	handler := reqctx.ClientIPHandler(limited, builder.ClientIP)
+
+	do := func(peer, forwardedFor string) int {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/paste", nil)
+		req.RemoteAddr = peer
+		req.Header.Set("X-Forwarded-For", forwardedFor)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	assert.Equal(t, http.StatusOK, do("10.0.0.5:1234", "198.51.100.1"))
+	assert.Equal(t, http.StatusOK, do("10.0.0.5:1234", "198.51.100.2"), "клиенты за прокси не делят корзину")
+	assert.Equal(t, http.StatusTooManyRequests, do("10.0.0.5:1234", "198.51.100.1"))
+
+	assert.Equal(t, http.StatusOK, do("203.0.113.9:1234", "198.51.100.3"))
+	assert.Equal(t, http.StatusTooManyRequests, do("203.0.113.9:1234", "198.51.100.4"),
+		"X-Forwarded-For от недоверенного адреса не даёт новой корзины")
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	policy := Policy{Read: Limit{Rate: 0.01, Burst: 1}, Write: Limit{Rate: 0.01, Burst: 1}}
+	interceptor := NewMiddleware(NewMemoryLimiter(), policy).UnaryServerInterceptor()
+	handler := func(ctx context.Context, req any) (any, error) { return "ok", nil }
+	info := &grpc.UnaryServerInfo{FullMethod: "/pastebin.PasteService/GetPaste"}
+
+	resp, err := interceptor(context.Background(), nil, info, handler)
+	assert.NoError(t, err)
+	assert.Equal(t, "ok", resp)
+
+	_, err = interceptor(context.Background(), nil, info, handler)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	info = &grpc.UnaryServerInfo{FullMethod: "/pastebin.PasteService/CreatePaste"}
+	_, err = interceptor(context.Background(), nil, info, handler)
+	assert.NoError(t, err)
+
+	info = &grpc.UnaryServerInfo{FullMethod: "/grpc.health.v1.Health/Check"}
+	for i := 0; i < 3; i++ {
+		_, err = interceptor(context.Background(), nil, info, handler)
+		assert.NoError(t, err)
+	}
+}
+
+func TestGRPCKeyUsesAuthenticatedIdentity(t *testing.T) {
+	policy := Policy{Write: Limit{Rate: 0.01, Burst: 1}}
+	interceptor := NewMiddleware(NewMemoryLimiter(), policy).UnaryServerInterceptor()
+	handler := func(ctx context.Context, req any) (any, error) { return "ok", nil }
+	info := &grpc.UnaryServerInfo{FullMethod: "/pastebin.v1.PasteService/CreatePaste"}
+	anonymous := func(key string) context.Context {
+		ctx := reqctx.WithClientIP(reqctx.WithUser(context.Background(), ""), "198.51.100.1")
+		return metadata.NewIncomingContext(ctx, metadata.Pairs("x-api-key", key))
+	}
+
+	_, err := interceptor(anonymous("a"), nil, info, handler)
+	require.NoError(t, err)
+	_, err = interceptor(anonymous("b"), nil, info, handler)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err), "непроверенный ключ не даёт новой корзины")
+
+	_, err = interceptor(reqctx.WithUser(anonymous("a"), "service:billing"), nil, info, handler)
+	assert.NoError(t, err, "у проверенного исполнителя своя корзина")
+}
@@ -0,0 +1,66 @@
+package ratelimit
+
+import (
+	"context"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// tokenBucketScript выполняет списание токена атомарно на стороне Redis.
+// KEYS[1] — ключ корзины; ARGV: rate (токенов/с), burst, текущее время (мс).
+// Возвращает {allowed, tokens * 1000}.
+var tokenBucketScript = redis.NewScript(`
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+
+local state = redis.call("HMGET", KEYS[1], "tokens", "ts")
+local tokens = tonumber(state[1])
+local ts = tonumber(state[2])
+if tokens == nil then
+  tokens = burst
+  ts = now
+end
+
+tokens = math.min(burst, tokens + math.max(0, now - ts) / 1000 * rate)
+local allowed = 0
+if tokens >= 1 then
+  tokens = tokens - 1
+  allowed = 1
+end
+
+redis.call("HSET", KEYS[1], "tokens", tokens, "ts", now)
+redis.call("PEXPIRE", KEYS[1], math.ceil((burst - tokens) / rate * 1000) + 1000)
+return {allowed, math.floor(tokens * 1000)}
+`)
+
+// RedisLimiter хранит корзины в Redis, чтобы лимиты были общими для нескольких экземпляров.
+type RedisLimiter struct {
+	client *redis.Client
+	prefix string
+}
+
+func NewRedisLimiter(client *redis.Client) *RedisLimiter {
+	return &RedisLimiter{client: client, prefix: "ratelimit:"}
+}
+
+func (r *RedisLimiter) Allow(ctx context.Context, key string, limit Limit) (Result, error) {
+	now := time.Now().UnixMilli()
+	vals, err := tokenBucketScript.Run(ctx, r.client, []string{r.prefix + key}, limit.Rate, limit.Burst, now).Int64Slice()
+	if err != nil {
+		return Result{}, err
+	}
+
+	tokens := float64(vals[1]) / 1000
+	res := Result{
+		Allowed:   vals[0] == 1,
+		Limit:     limit.Burst,
+		Remaining: int(tokens),
+		Reset:     secondsToDuration((float64(limit.Burst) - tokens) / limit.Rate),
+	}
+	if !res.Allowed {
+		res.RetryAfter = secondsToDuration((1 - tokens) / limit.Rate)
+	}
+	return res, nil
+}
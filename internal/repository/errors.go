@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/lib/pq"
+)
+
+// ErrAlreadyExists возвращается при попытке сохранить запись с занятым первичным ключом.
+var ErrAlreadyExists = errors.New("already exists")
+
+const uniqueViolation = "23505"
+
+func mapPgError(err error) error {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == uniqueViolation {
+		return ErrAlreadyExists
+	}
+	return err
+}
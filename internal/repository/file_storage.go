@@ -0,0 +1,290 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/GritsyukLeonid/pastebin-go/internal/model"
+)
+
+// FileStorage реализует StorageInterface поверх JSON-файлов (глобальных срезов этого пакета).
+// Используется тестовой gRPC-реализацией, которая работает без PostgreSQL.
+type FileStorage struct {
+	// quotaMu делает проверку квоты и запись пасты одной операцией.
+	quotaMu sync.Mutex
+	mu      sync.Mutex
+	// notified — срок пасты на момент предупреждения об истечении; новый срок снимает отметку.
+	notified map[string]time.Time
+}
+
+func NewFileStorage() *FileStorage {
+	return &FileStorage{notified: map[string]time.Time{}}
+}
+
+// Paste
+func (s *FileStorage) SavePaste(_ context.Context, p model.Paste, since time.Time, check QuotaCheck) error {
+	s.quotaMu.Lock()
+	defer s.quotaMu.Unlock()
+	if err := s.checkQuota(p, since, check); err != nil {
+		return err
+	}
+	return StoreObject(&p)
+}
+
+func (s *FileStorage) GetPasteByID(_ context.Context, id string) (*model.Paste, error) {
+	p, err := GetPasteByID(id)
+	if err != nil {
+		return nil, err
+	}
+	out := *p
+	return &out, nil
+}
+
+func (s *FileStorage) DeletePaste(_ context.Context, id string) error {
+	p, err := GetPasteByID(id)
+	if err != nil {
+		return err
+	}
+	if err := TombstoneShortURLs(p.Hash, time.Now()); err != nil {
+		return err
+	}
+	return DeletePaste(id)
+}
+
+func (s *FileStorage) GetAllPastes(_ context.Context) ([]model.Paste, error) {
+	pasteMutex.Lock()
+	defer pasteMutex.Unlock()
+	out := make([]model.Paste, 0, len(Pastes))
+	for _, p := range Pastes {
+		out = append(out, *p)
+	}
+	return out, nil
+}
+
+func (s *FileStorage) DeleteExpiredPastes(ctx context.Context) ([]model.Paste, error) {
+	all, err := s.GetAllPastes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var deleted []model.Paste
+	now := time.Now()
+	for _, p := range all {
+		if !p.ExpiresAt.Before(now) {
+			continue
+		}
+		if err := s.DeletePaste(ctx, p.ID); err != nil {
+			return deleted, err
+		}
+		p.Content = ""
+		deleted = append(deleted, p)
+	}
+	return deleted, nil
+}
+
+func (s *FileStorage) MarkExpiringPastes(ctx context.Context, before time.Time) ([]model.Paste, error) {
+	all, err := s.GetAllPastes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var marked []model.Paste
+	now := time.Now()
+	for _, p := range all {
+		if !p.ExpiresAt.After(now) || p.ExpiresAt.After(before) {
+			continue
+		}
+		if at, ok := s.notified[p.ID]; ok && at.Equal(p.ExpiresAt) {
+			continue
+		}
+		s.notified[p.ID] = p.ExpiresAt
+		p.Content = ""
+		marked = append(marked, p)
+	}
+	return marked, nil
+}
+
+func (s *FileStorage) GetPasteByHash(_ context.Context, hash string) (*model.Paste, error) {
+	pasteMutex.Lock()
+	defer pasteMutex.Unlock()
+	for _, p := range Pastes {
+		if p.Hash == hash {
+			out := *p
+			return &out, nil
+		}
+	}
+	return nil, fmt.Errorf("paste not found")
+}
+
+func (s *FileStorage) UpdatePaste(ctx context.Context, p model.Paste, since time.Time, check QuotaCheck) error {
+	s.quotaMu.Lock()
+	defer s.quotaMu.Unlock()
+	existing, err := s.GetPasteByID(ctx, p.ID)
+	if err != nil {
+		return err
+	}
+	if err := s.checkQuota(*existing, since, check); err != nil {
+		return err
+	}
+	existing.Content = p.Content
+	existing.ExpiresAt = p.ExpiresAt
+	return UpdatePaste(p.ID, existing)
+}
+
+// checkQuota передаёт check использование владельца p без самой p; вызывается под quotaMu.
+func (s *FileStorage) checkQuota(p model.Paste, since time.Time, check QuotaCheck) error {
+	return check(s.usage(p.UserID, p.ClientIP, since, p.ID))
+}
+
+func (s *FileStorage) GetPasteUsage(_ context.Context, userID int64, clientIP string, since time.Time) (*model.Usage, error) {
+	u := s.usage(userID, clientIP, since, "")
+	return &u, nil
+}
+
+func (s *FileStorage) usage(userID int64, clientIP string, since time.Time, exclude string) model.Usage {
+	pasteMutex.Lock()
+	defer pasteMutex.Unlock()
+	now := time.Now()
+	var u model.Usage
+	for _, p := range Pastes {
+		if p.ID == exclude || !p.ExpiresAt.IsZero() && !p.ExpiresAt.After(now) {
+			continue
+		}
+		if userID != 0 && p.UserID != userID {
+			continue
+		}
+		if userID == 0 && (p.UserID != 0 || p.ClientIP != clientIP) {
+			continue
+		}
+		u.LivePastes++
+		u.StoredBytes += int64(len(p.Content))
+		if !p.CreatedAt.Before(since) {
+			u.PastesToday++
+		}
+	}
+	return u
+}
+
+// User
+func (s *FileStorage) SaveUser(_ context.Context, u model.User) error {
+	return AddUser(&u)
+}
+
+func (s *FileStorage) GetUserByID(_ context.Context, id string) (*model.User, error) {
+	uid, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	u, err := GetUserByID(uid)
+	if err != nil {
+		return nil, err
+	}
+	out := *u
+	return &out, nil
+}
+
+func (s *FileStorage) DeleteUser(_ context.Context, id string) error {
+	uid, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return err
+	}
+	return DeleteUser(uid)
+}
+
+func (s *FileStorage) GetAllUsers(_ context.Context) ([]model.User, error) {
+	userMutex.Lock()
+	defer userMutex.Unlock()
+	out := make([]model.User, 0, len(Users))
+	for _, u := range Users {
+		out = append(out, *u)
+	}
+	return out, nil
+}
+
+// ShortURL
+func (s *FileStorage) SaveShortURL(_ context.Context, u model.ShortURL) error {
+	if _, err := GetShortURLByID(u.ID); err == nil {
+		return ErrAlreadyExists
+	}
+	return StoreObject(&u)
+}
+
+func (s *FileStorage) GetShortURLByID(_ context.Context, id string) (*model.ShortURL, error) {
+	u, err := GetShortURLByID(id)
+	if err != nil {
+		return nil, err
+	}
+	out := *u
+	return &out, nil
+}
+
+func (s *FileStorage) DeleteShortURL(_ context.Context, id string) error {
+	return DeleteShortURL(id, time.Now())
+}
+
+func (s *FileStorage) GetAllShortURLs(_ context.Context) ([]model.ShortURL, error) {
+	urlMutex.Lock()
+	defer urlMutex.Unlock()
+	out := make([]model.ShortURL, 0, len(URLs))
+	for _, u := range URLs {
+		if u.DeletedAt == nil {
+			out = append(out, *u)
+		}
+	}
+	return out, nil
+}
+
+func (s *FileStorage) GetShortURLsByOriginal(_ context.Context, original string) ([]model.ShortURL, error) {
+	urlMutex.Lock()
+	defer urlMutex.Unlock()
+	var out []model.ShortURL
+	for _, u := range URLs {
+		if u.Original == original && u.DeletedAt == nil {
+			out = append(out, *u)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	return out, nil
+}
+
+// Stats
+func (s *FileStorage) SaveStats(_ context.Context, st model.Stats) error {
+	return StoreObject(&st)
+}
+
+func (s *FileStorage) GetStatsByID(_ context.Context, id string) (*model.Stats, error) {
+	st, err := GetStatsByID(id)
+	if err != nil {
+		return nil, err
+	}
+	out := *st
+	return &out, nil
+}
+
+func (s *FileStorage) DeleteStats(_ context.Context, id string) error {
+	return DeleteStats(id)
+}
+
+func (s *FileStorage) GetAllStats(_ context.Context) ([]model.Stats, error) {
+	statsMutex.Lock()
+	defer statsMutex.Unlock()
+	out := make([]model.Stats, 0, len(StatsSet))
+	for _, st := range StatsSet {
+		out = append(out, *st)
+	}
+	return out, nil
+}
+
+func (s *FileStorage) IncrementStatsViews(_ context.Context, id string) error {
+	st, err := GetStatsByID(id)
+	if err != nil {
+		return StoreObject(&model.Stats{ID: id, Views: 1})
+	}
+	updated := *st
+	updated.IncrementViews()
+	return UpdateStats(id, &updated)
+}
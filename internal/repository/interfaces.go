@@ -1,33 +1,92 @@
 package repository
 
 import (
+	"context"
+	"time"
+
 	"github.com/GritsyukLeonid/pastebin-go/internal/model"
 )
 
+// QuotaCheck проверяет использование владельца пасты; ошибка отменяет запись.
+type QuotaCheck func(model.Usage) error
+
 type StorageInterface interface {
 	// Paste
-	SavePaste(model.Paste) error
-	GetPasteByID(string) (*model.Paste, error)
-	DeletePaste(string) error
-	GetAllPastes() ([]model.Paste, error)
-	GetPasteByHash(string) (*model.Paste, error)
+	// SavePaste сохраняет пасту, если check принимает использование её владельца (UserID, а при
+	// UserID == 0 — анонимные пасты с ClientIP; PastesToday — созданные после since). Подсчёт
+	// и запись атомарны: параллельные запросы одного владельца не превышают квоту.
+	SavePaste(ctx context.Context, p model.Paste, since time.Time, check QuotaCheck) error
+	GetPasteByID(context.Context, string) (*model.Paste, error)
+	DeletePaste(context.Context, string) error
+	GetAllPastes(context.Context) ([]model.Paste, error)
+	GetPasteByHash(context.Context, string) (*model.Paste, error)
+	// UpdatePaste меняет содержимое и срок пасты так же атомарно, как SavePaste; использование
+	// передаётся в check без прежней версии пасты.
+	UpdatePaste(ctx context.Context, p model.Paste, since time.Time, check QuotaCheck) error
+	// GetPasteUsage считает живые пасты пользователя, а при userID == 0 — анонимные пасты с clientIP.
+	GetPasteUsage(ctx context.Context, userID int64, clientIP string, since time.Time) (*model.Usage, error)
+	// DeleteExpiredPastes удаляет просроченные пасты и возвращает их без содержимого.
+	DeleteExpiredPastes(context.Context) ([]model.Paste, error)
+	// MarkExpiringPastes отмечает живые пасты, срок которых истекает до before, и возвращает их без содержимого.
+	// Каждая паста возвращается один раз, пока её срок не изменится.
+	MarkExpiringPastes(ctx context.Context, before time.Time) ([]model.Paste, error)
 
 	// User
-	SaveUser(model.User) error
-	GetUserByID(string) (*model.User, error)
-	DeleteUser(string) error
-	GetAllUsers() ([]model.User, error)
+	SaveUser(context.Context, model.User) error
+	GetUserByID(context.Context, string) (*model.User, error)
+	DeleteUser(context.Context, string) error
+	GetAllUsers(context.Context) ([]model.User, error)
 
 	// ShortURL
-	SaveShortURL(model.ShortURL) error
-	GetShortURLByID(string) (*model.ShortURL, error)
-	DeleteShortURL(string) error
-	GetAllShortURLs() ([]model.ShortURL, error)
+	SaveShortURL(context.Context, model.ShortURL) error
+	GetShortURLByID(context.Context, string) (*model.ShortURL, error)
+	// DeleteShortURL превращает ссылку в «надгробие»: код остаётся занятым и больше не выдаётся.
+	DeleteShortURL(context.Context, string) error
+	GetAllShortURLs(context.Context) ([]model.ShortURL, error)
+	// GetShortURLsByOriginal возвращает живые ссылки на пасту или адрес original.
+	GetShortURLsByOriginal(context.Context, string) ([]model.ShortURL, error)
 
 	// Stats
-	SaveStats(model.Stats) error
-	GetStatsByID(string) (*model.Stats, error)
-	DeleteStats(string) error
-	GetAllStats() ([]model.Stats, error)
-	IncrementStatsViews(id string) error
+	SaveStats(context.Context, model.Stats) error
+	GetStatsByID(context.Context, string) (*model.Stats, error)
+	DeleteStats(context.Context, string) error
+	GetAllStats(context.Context) ([]model.Stats, error)
+	IncrementStatsViews(ctx context.Context, id string) error
+}
+
+// ClickStorage хранит переходы по коротким ссылкам.
+type ClickStorage interface {
+	SaveClick(context.Context, model.Click) error
+	// GetClickAnalytics объединяет сырые и прореженные переходы с момента since; bucket — шаг временного ряда.
+	GetClickAnalytics(ctx context.Context, shortID string, since time.Time, bucket time.Duration, topReferrers int) (*model.ClickAnalytics, error)
+	// DownsampleClicks сворачивает переходы до before в дневные агрегаты, удаляет сырые события
+	// и хэши посетителей за те же дни и возвращает число удалённых переходов.
+	DownsampleClicks(ctx context.Context, before time.Time) (int64, error)
+}
+
+// AuditStorage — журнал аудита: записи только добавляются и удаляются по сроку хранения.
+type AuditStorage interface {
+	AppendAudit(context.Context, model.AuditEntry) error
+	ListAudit(context.Context, model.AuditFilter) ([]model.AuditEntry, error)
+	// DeleteAuditBefore удаляет записи старше before и возвращает их число.
+	DeleteAuditBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+// WebhookStorage хранит подписки на вебхуки и очередь их доставок.
+type WebhookStorage interface {
+	SaveWebhook(context.Context, model.Webhook) (int64, error)
+	GetWebhook(ctx context.Context, id int64) (*model.Webhook, error)
+	DeleteWebhook(ctx context.Context, id int64) error
+	// ListWebhooksForEvent возвращает вебхуки, подписанные на event, с тем же владельцем, что у сущности
+	// события: для userID 0 — только вебхуки без владельца.
+	ListWebhooksForEvent(ctx context.Context, event string, userID int64) ([]model.Webhook, error)
+
+	EnqueueWebhookDeliveries(context.Context, []model.WebhookDelivery) error
+	// ClaimWebhookDeliveries берёт до limit доставок, время которых наступило к now, и откладывает их
+	// на lease, чтобы другой экземпляр сервиса не отправил их одновременно.
+	ClaimWebhookDeliveries(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]model.WebhookDelivery, error)
+	// RecordWebhookAttempt сохраняет попытку и новое состояние доставки: Status, AttemptCount и NextAttemptAt.
+	RecordWebhookAttempt(context.Context, model.WebhookDelivery, model.WebhookAttempt) error
+	// ListWebhookDeliveries возвращает доставки вебхука с попытками от новых к старым; пустой status — все.
+	ListWebhookDeliveries(ctx context.Context, webhookID int64, status string, limit int) ([]model.WebhookDelivery, error)
 }
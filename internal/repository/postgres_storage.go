@@ -3,52 +3,206 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"fmt"
+	"time"
 
 	"github.com/GritsyukLeonid/pastebin-go/internal/model"
-	_ "github.com/lib/pq"
+	"github.com/lib/pq"
 )
 
 type PostgresStorage struct {
 	db *sql.DB
+	q  tracedQuerier
 }
 
 func NewPostgresStorage(db *sql.DB) *PostgresStorage {
-	return &PostgresStorage{db: db}
+	return &PostgresStorage{db: db, q: tracedQuerier{q: db}}
 }
 
 // Paste
-func (s *PostgresStorage) SavePaste(p model.Paste) error {
-	query := `INSERT INTO pastes (id, hash, content, created_at, expires_at, views) VALUES ($1, $2, $3, $4, $5, $6)`
-	_, err := s.db.ExecContext(context.Background(), query, p.ID, p.Hash, p.Content, p.CreatedAt, p.ExpiresAt, p.Views)
-	return err
+func (s *PostgresStorage) SavePaste(ctx context.Context, p model.Paste, since time.Time, check QuotaCheck) error {
+	ctx, end := observe(ctx, "SavePaste")
+	defer end()
+	return s.inTx(ctx, func(tx querier) error {
+		if err := checkPasteQuota(ctx, tx, p, since, check); err != nil {
+			return err
+		}
+		query := `INSERT INTO pastes (id, hash, content, created_at, expires_at, views, delete_token_hash, user_id, client_ip) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+		_, err := tx.ExecContext(ctx, query, p.ID, p.Hash, p.Content, p.CreatedAt, p.ExpiresAt, p.Views, p.DeleteTokenHash, nullUserID(p.UserID), p.ClientIP)
+		return err
+	})
+}
+
+func (s *PostgresStorage) UpdatePaste(ctx context.Context, p model.Paste, since time.Time, check QuotaCheck) error {
+	ctx, end := observe(ctx, "UpdatePaste")
+	defer end()
+	return s.inTx(ctx, func(tx querier) error {
+		if err := checkPasteQuota(ctx, tx, p, since, check); err != nil {
+			return err
+		}
+		query := `
+			UPDATE pastes SET content = $2, expires_at = $3,
+			    expiry_notified_at = CASE WHEN expires_at = $3 THEN expiry_notified_at END
+			WHERE id = $1
+		`
+		res, err := tx.ExecContext(ctx, query, p.ID, p.Content, p.ExpiresAt)
+		if err != nil {
+			return err
+		}
+		return expectAffected(res)
+	})
+}
+
+// checkPasteQuota берёт блокировку владельца p до конца транзакции и передаёт check его использование
+// без самой p. Параллельная транзакция того же владельца ждёт, пока эта не завершится, и видит её пасту.
+func checkPasteQuota(ctx context.Context, tx querier, p model.Paste, since time.Time, check QuotaCheck) error {
+	owner := "ip:" + p.ClientIP
+	if p.UserID != 0 {
+		owner = fmt.Sprintf("user:%d", p.UserID)
+	}
+	if _, err := tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock(hashtext('paste_quota:' || $1))`, owner); err != nil {
+		return err
+	}
+	u, err := pasteUsage(ctx, tx, p.UserID, p.ClientIP, since, p.ID)
+	if err != nil {
+		return err
+	}
+	return check(*u)
+}
+
+func (s *PostgresStorage) GetPasteUsage(ctx context.Context, userID int64, clientIP string, since time.Time) (*model.Usage, error) {
+	ctx, end := observe(ctx, "GetPasteUsage")
+	defer end()
+	return pasteUsage(ctx, s.q, userID, clientIP, since, "")
+}
+
+// pasteUsage считает живые пасты владельца, кроме пасты exclude.
+func pasteUsage(ctx context.Context, q querier, userID int64, clientIP string, since time.Time, exclude string) (*model.Usage, error) {
+	query := `
+		SELECT COUNT(*),
+		       COALESCE(SUM(octet_length(content)), 0),
+		       COUNT(*) FILTER (WHERE created_at >= $3)
+		FROM pastes
+		WHERE expires_at > NOW() AND id <> $4
+		  AND ((user_id = $1) OR ($1 = 0 AND user_id IS NULL AND client_ip = $2))
+	`
+	row := q.QueryRowContext(ctx, query, userID, clientIP, since, exclude)
+	var u model.Usage
+	err := row.Scan(&u.LivePastes, &u.StoredBytes, &u.PastesToday)
+	if err != nil {
+		return nil, err
+	}
+	return &u, nil
 }
 
-func (s *PostgresStorage) GetPasteByID(id string) (*model.Paste, error) {
-	query := `SELECT id, hash, content, created_at, expires_at, views FROM pastes WHERE id = $1`
-	row := s.db.QueryRowContext(context.Background(), query, id)
+func (s *PostgresStorage) GetPasteByID(ctx context.Context, id string) (*model.Paste, error) {
+	ctx, end := observe(ctx, "GetPasteByID")
+	defer end()
+	query := `SELECT id, hash, content, created_at, expires_at, views, delete_token_hash, COALESCE(user_id, 0), client_ip FROM pastes WHERE id = $1`
+	row := s.q.QueryRowContext(ctx, query, id)
 	var p model.Paste
-	err := row.Scan(&p.ID, &p.Hash, &p.Content, &p.CreatedAt, &p.ExpiresAt, &p.Views)
+	err := row.Scan(&p.ID, &p.Hash, &p.Content, &p.CreatedAt, &p.ExpiresAt, &p.Views, &p.DeleteTokenHash, &p.UserID, &p.ClientIP)
 	if err != nil {
 		return nil, err
 	}
 	return &p, nil
 }
 
-func (s *PostgresStorage) DeletePaste(id string) error {
-	query := `DELETE FROM pastes WHERE id = $1`
-	_, err := s.db.ExecContext(context.Background(), query, id)
-	return err
+// DeletePaste удаляет пасту и в той же транзакции превращает её короткие ссылки в «надгробия».
+func (s *PostgresStorage) DeletePaste(ctx context.Context, id string) error {
+	ctx, end := observe(ctx, "DeletePaste")
+	defer end()
+	return s.inTx(ctx, func(tx querier) error {
+		tombstone := `
+			UPDATE shorturls SET deleted_at = NOW()
+			WHERE target_type = 'paste' AND deleted_at IS NULL
+			  AND original IN (SELECT hash FROM pastes WHERE id = $1)
+		`
+		if _, err := tx.ExecContext(ctx, tombstone, id); err != nil {
+			return err
+		}
+		_, err := tx.ExecContext(ctx, `DELETE FROM pastes WHERE id = $1`, id)
+		return err
+	})
 }
 
-func (s *PostgresStorage) DeleteExpiredPastes() error {
-	query := `DELETE FROM pastes WHERE expires_at < NOW()`
-	_, err := s.db.ExecContext(context.Background(), query)
-	return err
+// DeleteExpiredPastes удаляет просроченные пасты вместе с их короткими ссылками
+// и помечает «надгробиями» ссылки с истёкшим собственным сроком. Возвращает удалённые пасты без содержимого.
+func (s *PostgresStorage) DeleteExpiredPastes(ctx context.Context) ([]model.Paste, error) {
+	ctx, end := observe(ctx, "DeleteExpiredPastes")
+	defer end()
+	var deleted []model.Paste
+	err := s.inTx(ctx, func(tx querier) error {
+		tombstone := `
+			UPDATE shorturls SET deleted_at = NOW()
+			WHERE deleted_at IS NULL AND (
+			    expires_at < NOW()
+			    OR (target_type = 'paste' AND original IN (SELECT hash FROM pastes WHERE expires_at < NOW()))
+			)
+		`
+		if _, err := tx.ExecContext(ctx, tombstone); err != nil {
+			return err
+		}
+		rows, err := tx.QueryContext(ctx, `DELETE FROM pastes WHERE expires_at < NOW() RETURNING `+pasteSummaryColumns)
+		if err != nil {
+			return err
+		}
+		deleted, err = scanPasteSummaries(rows)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return deleted, nil
 }
 
-func (s *PostgresStorage) GetAllPastes() ([]model.Paste, error) {
-	query := `SELECT id, hash, content, created_at, expires_at, views FROM pastes`
-	rows, err := s.db.QueryContext(context.Background(), query)
+func (s *PostgresStorage) MarkExpiringPastes(ctx context.Context, before time.Time) ([]model.Paste, error) {
+	ctx, end := observe(ctx, "MarkExpiringPastes")
+	defer end()
+	query := `
+		UPDATE pastes SET expiry_notified_at = NOW()
+		WHERE expiry_notified_at IS NULL AND expires_at > NOW() AND expires_at <= $1
+		RETURNING ` + pasteSummaryColumns
+	rows, err := s.q.QueryContext(ctx, query, before)
+	if err != nil {
+		return nil, err
+	}
+	return scanPasteSummaries(rows)
+}
+
+// pasteSummaryColumns — поля пасты без содержимого и токена для RETURNING.
+const pasteSummaryColumns = `id, hash, created_at, expires_at, views, COALESCE(user_id, 0), client_ip, octet_length(content)`
+
+func scanPasteSummaries(rows *sql.Rows) ([]model.Paste, error) {
+	defer rows.Close()
+	var pastes []model.Paste
+	for rows.Next() {
+		var p model.Paste
+		if err := rows.Scan(&p.ID, &p.Hash, &p.CreatedAt, &p.ExpiresAt, &p.Views, &p.UserID, &p.ClientIP, &p.Size); err != nil {
+			return nil, err
+		}
+		pastes = append(pastes, p)
+	}
+	return pastes, rows.Err()
+}
+
+// GetPasteTotals возвращает число живых паст и их суммарный размер по всем пользователям.
+func (s *PostgresStorage) GetPasteTotals(ctx context.Context) (*model.Usage, error) {
+	ctx, end := observe(ctx, "GetPasteTotals")
+	defer end()
+	query := `SELECT COUNT(*), COALESCE(SUM(octet_length(content)), 0) FROM pastes WHERE expires_at > NOW()`
+	var u model.Usage
+	if err := s.q.QueryRowContext(ctx, query).Scan(&u.LivePastes, &u.StoredBytes); err != nil {
+		return nil, err
+	}
+	return &u, nil
+}
+
+func (s *PostgresStorage) GetAllPastes(ctx context.Context) ([]model.Paste, error) {
+	ctx, end := observe(ctx, "GetAllPastes")
+	defer end()
+	query := `SELECT id, hash, content, created_at, expires_at, views, delete_token_hash, COALESCE(user_id, 0), client_ip FROM pastes`
+	rows, err := s.q.QueryContext(ctx, query)
 	if err != nil {
 		return nil, err
 	}
@@ -57,7 +211,7 @@ func (s *PostgresStorage) GetAllPastes() ([]model.Paste, error) {
 	var pastes []model.Paste
 	for rows.Next() {
 		var p model.Paste
-		err := rows.Scan(&p.ID, &p.Hash, &p.Content, &p.CreatedAt, &p.ExpiresAt, &p.Views)
+		err := rows.Scan(&p.ID, &p.Hash, &p.Content, &p.CreatedAt, &p.ExpiresAt, &p.Views, &p.DeleteTokenHash, &p.UserID, &p.ClientIP)
 		if err != nil {
 			return nil, err
 		}
@@ -67,15 +221,19 @@ func (s *PostgresStorage) GetAllPastes() ([]model.Paste, error) {
 }
 
 // User
-func (s *PostgresStorage) SaveUser(u model.User) error {
+func (s *PostgresStorage) SaveUser(ctx context.Context, u model.User) error {
+	ctx, end := observe(ctx, "SaveUser")
+	defer end()
 	query := `INSERT INTO users (id, username) VALUES ($1, $2)`
-	_, err := s.db.ExecContext(context.Background(), query, u.ID, u.Username)
+	_, err := s.q.ExecContext(ctx, query, u.ID, u.Username)
 	return err
 }
 
-func (s *PostgresStorage) GetUserByID(id string) (*model.User, error) {
+func (s *PostgresStorage) GetUserByID(ctx context.Context, id string) (*model.User, error) {
+	ctx, end := observe(ctx, "GetUserByID")
+	defer end()
 	query := `SELECT id, username FROM users WHERE id = $1`
-	row := s.db.QueryRowContext(context.Background(), query, id)
+	row := s.q.QueryRowContext(ctx, query, id)
 	var u model.User
 	err := row.Scan(&u.ID, &u.Username)
 	if err != nil {
@@ -84,15 +242,19 @@ func (s *PostgresStorage) GetUserByID(id string) (*model.User, error) {
 	return &u, nil
 }
 
-func (s *PostgresStorage) DeleteUser(id string) error {
+func (s *PostgresStorage) DeleteUser(ctx context.Context, id string) error {
+	ctx, end := observe(ctx, "DeleteUser")
+	defer end()
 	query := `DELETE FROM users WHERE id = $1`
-	_, err := s.db.ExecContext(context.Background(), query, id)
+	_, err := s.q.ExecContext(ctx, query, id)
 	return err
 }
 
-func (s *PostgresStorage) GetAllUsers() ([]model.User, error) {
+func (s *PostgresStorage) GetAllUsers(ctx context.Context) ([]model.User, error) {
+	ctx, end := observe(ctx, "GetAllUsers")
+	defer end()
 	query := `SELECT id, username FROM users`
-	rows, err := s.db.QueryContext(context.Background(), query)
+	rows, err := s.q.QueryContext(ctx, query)
 	if err != nil {
 		return nil, err
 	}
@@ -110,12 +272,14 @@ func (s *PostgresStorage) GetAllUsers() ([]model.User, error) {
 	return users, nil
 }
 
-func (s *PostgresStorage) GetPasteByHash(hash string) (*model.Paste, error) {
-	query := `SELECT id, hash, content, created_at, expires_at, views FROM pastes WHERE hash = $1`
-	row := s.db.QueryRowContext(context.Background(), query, hash)
+func (s *PostgresStorage) GetPasteByHash(ctx context.Context, hash string) (*model.Paste, error) {
+	ctx, end := observe(ctx, "GetPasteByHash")
+	defer end()
+	query := `SELECT id, hash, content, created_at, expires_at, views, delete_token_hash, COALESCE(user_id, 0), client_ip FROM pastes WHERE hash = $1`
+	row := s.q.QueryRowContext(ctx, query, hash)
 
 	var p model.Paste
-	err := row.Scan(&p.ID, &p.Hash, &p.Content, &p.CreatedAt, &p.ExpiresAt, &p.Views)
+	err := row.Scan(&p.ID, &p.Hash, &p.Content, &p.CreatedAt, &p.ExpiresAt, &p.Views, &p.DeleteTokenHash, &p.UserID, &p.ClientIP)
 	if err != nil {
 		return nil, err
 	}
@@ -123,32 +287,51 @@ func (s *PostgresStorage) GetPasteByHash(hash string) (*model.Paste, error) {
 }
 
 // ShortURL
-func (s *PostgresStorage) SaveShortURL(u model.ShortURL) error {
-	query := `INSERT INTO shorturls (id, original) VALUES ($1, $2)`
-	_, err := s.db.ExecContext(context.Background(), query, u.ID, u.Original)
-	return err
+func (s *PostgresStorage) SaveShortURL(ctx context.Context, u model.ShortURL) error {
+	ctx, end := observe(ctx, "SaveShortURL")
+	defer end()
+	query := `INSERT INTO shorturls (id, original, target_type, permanent, expires_at, delete_token_hash) VALUES ($1, $2, $3, $4, $5, $6)`
+	_, err := s.q.ExecContext(ctx, query, u.ID, u.Original, u.TargetType, u.Permanent, u.ExpiresAt, u.DeleteTokenHash)
+	return mapPgError(err)
 }
 
-func (s *PostgresStorage) GetShortURLByID(id string) (*model.ShortURL, error) {
-	query := `SELECT id, original FROM shorturls WHERE id = $1`
-	row := s.db.QueryRowContext(context.Background(), query, id)
+func (s *PostgresStorage) GetShortURLByID(ctx context.Context, id string) (*model.ShortURL, error) {
+	ctx, end := observe(ctx, "GetShortURLByID")
+	defer end()
+	query := `SELECT id, original, target_type, permanent, expires_at, deleted_at, delete_token_hash FROM shorturls WHERE id = $1`
+	row := s.q.QueryRowContext(ctx, query, id)
 	var u model.ShortURL
-	err := row.Scan(&u.ID, &u.Original)
+	err := row.Scan(&u.ID, &u.Original, &u.TargetType, &u.Permanent, &u.ExpiresAt, &u.DeletedAt, &u.DeleteTokenHash)
 	if err != nil {
 		return nil, err
 	}
 	return &u, nil
 }
 
-func (s *PostgresStorage) DeleteShortURL(id string) error {
-	query := `DELETE FROM shorturls WHERE id = $1`
-	_, err := s.db.ExecContext(context.Background(), query, id)
+func (s *PostgresStorage) DeleteShortURL(ctx context.Context, id string) error {
+	ctx, end := observe(ctx, "DeleteShortURL")
+	defer end()
+	query := `UPDATE shorturls SET deleted_at = NOW() WHERE id = $1 AND deleted_at IS NULL`
+	_, err := s.q.ExecContext(ctx, query, id)
 	return err
 }
 
-func (s *PostgresStorage) GetAllShortURLs() ([]model.ShortURL, error) {
-	query := `SELECT id, original FROM shorturls`
-	rows, err := s.db.QueryContext(context.Background(), query)
+func (s *PostgresStorage) GetAllShortURLs(ctx context.Context) ([]model.ShortURL, error) {
+	ctx, end := observe(ctx, "GetAllShortURLs")
+	defer end()
+	query := `SELECT id, original, target_type, permanent, expires_at, deleted_at FROM shorturls WHERE deleted_at IS NULL`
+	return s.queryShortURLs(ctx, query)
+}
+
+func (s *PostgresStorage) GetShortURLsByOriginal(ctx context.Context, original string) ([]model.ShortURL, error) {
+	ctx, end := observe(ctx, "GetShortURLsByOriginal")
+	defer end()
+	query := `SELECT id, original, target_type, permanent, expires_at, deleted_at FROM shorturls WHERE original = $1 AND deleted_at IS NULL ORDER BY id`
+	return s.queryShortURLs(ctx, query, original)
+}
+
+func (s *PostgresStorage) queryShortURLs(ctx context.Context, query string, args ...interface{}) ([]model.ShortURL, error) {
+	rows, err := s.q.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -157,7 +340,7 @@ func (s *PostgresStorage) GetAllShortURLs() ([]model.ShortURL, error) {
 	var urls []model.ShortURL
 	for rows.Next() {
 		var u model.ShortURL
-		err := rows.Scan(&u.ID, &u.Original)
+		err := rows.Scan(&u.ID, &u.Original, &u.TargetType, &u.Permanent, &u.ExpiresAt, &u.DeletedAt)
 		if err != nil {
 			return nil, err
 		}
@@ -167,15 +350,19 @@ func (s *PostgresStorage) GetAllShortURLs() ([]model.ShortURL, error) {
 }
 
 // Stats
-func (s *PostgresStorage) SaveStats(st model.Stats) error {
+func (s *PostgresStorage) SaveStats(ctx context.Context, st model.Stats) error {
+	ctx, end := observe(ctx, "SaveStats")
+	defer end()
 	query := `INSERT INTO stats (id, views) VALUES ($1, $2)`
-	_, err := s.db.ExecContext(context.Background(), query, st.ID, st.Views)
+	_, err := s.q.ExecContext(ctx, query, st.ID, st.Views)
 	return err
 }
 
-func (s *PostgresStorage) GetStatsByID(id string) (*model.Stats, error) {
+func (s *PostgresStorage) GetStatsByID(ctx context.Context, id string) (*model.Stats, error) {
+	ctx, end := observe(ctx, "GetStatsByID")
+	defer end()
 	query := `SELECT id, views FROM stats WHERE id = $1`
-	row := s.db.QueryRowContext(context.Background(), query, id)
+	row := s.q.QueryRowContext(ctx, query, id)
 	var st model.Stats
 	err := row.Scan(&st.ID, &st.Views)
 	if err != nil {
@@ -184,15 +371,19 @@ func (s *PostgresStorage) GetStatsByID(id string) (*model.Stats, error) {
 	return &st, nil
 }
 
-func (s *PostgresStorage) DeleteStats(id string) error {
+func (s *PostgresStorage) DeleteStats(ctx context.Context, id string) error {
+	ctx, end := observe(ctx, "DeleteStats")
+	defer end()
 	query := `DELETE FROM stats WHERE id = $1`
-	_, err := s.db.ExecContext(context.Background(), query, id)
+	_, err := s.q.ExecContext(ctx, query, id)
 	return err
 }
 
-func (s *PostgresStorage) GetAllStats() ([]model.Stats, error) {
+func (s *PostgresStorage) GetAllStats(ctx context.Context) ([]model.Stats, error) {
+	ctx, end := observe(ctx, "GetAllStats")
+	defer end()
 	query := `SELECT id, views FROM stats`
-	rows, err := s.db.QueryContext(context.Background(), query)
+	rows, err := s.q.QueryContext(ctx, query)
 	if err != nil {
 		return nil, err
 	}
@@ -210,13 +401,430 @@ func (s *PostgresStorage) GetAllStats() ([]model.Stats, error) {
 	return stats, nil
 }
 
-func (s *PostgresStorage) IncrementStatsViews(id string) error {
+func (s *PostgresStorage) IncrementStatsViews(ctx context.Context, id string) error {
+	ctx, end := observe(ctx, "IncrementStatsViews")
+	defer end()
 	query := `
 		INSERT INTO stats (id, views)
 		VALUES ($1, 1)
 		ON CONFLICT (id) DO UPDATE
 		SET views = stats.views + 1;
 	`
-	_, err := s.db.ExecContext(context.Background(), query, id)
+	_, err := s.q.ExecContext(ctx, query, id)
+	return err
+}
+
+// Clicks
+func (s *PostgresStorage) SaveClick(ctx context.Context, c model.Click) error {
+	ctx, end := observe(ctx, "SaveClick")
+	defer end()
+	query := `
+		WITH click AS (
+			INSERT INTO shorturl_clicks (short_id, clicked_at, referrer_host, agent_class, country, ip_hash) VALUES ($1, $2, $3, $4, $5, $6)
+		)
+		INSERT INTO shorturl_visitor_days (short_id, day, ip_hash) VALUES ($1, ($2::TIMESTAMPTZ AT TIME ZONE 'UTC')::DATE, $6)
+		ON CONFLICT DO NOTHING
+	`
+	_, err := s.q.ExecContext(ctx, query, c.ShortID, c.At, c.ReferrerHost, c.AgentClass, c.Country, c.IPHash)
+	return err
+}
+
+// clickEvents — сырые и прореженные переходы ссылки $1 с момента $2 в едином виде.
+const clickEvents = `
+	WITH events AS (
+		SELECT clicked_at AS at, referrer_host, agent_class, country, 1::BIGINT AS clicks
+		FROM shorturl_clicks WHERE short_id = $1 AND clicked_at >= $2
+		UNION ALL
+		SELECT day::TIMESTAMP AT TIME ZONE 'UTC', referrer_host, agent_class, country, clicks
+		FROM shorturl_clicks_daily WHERE short_id = $1 AND day >= ($2::TIMESTAMPTZ AT TIME ZONE 'UTC')::DATE
+	)
+`
+
+func (s *PostgresStorage) GetClickAnalytics(ctx context.Context, shortID string, since time.Time, bucket time.Duration, topReferrers int) (*model.ClickAnalytics, error) {
+	ctx, end := observe(ctx, "GetClickAnalytics")
+	defer end()
+	a := model.ClickAnalytics{ShortID: shortID, Since: since}
+
+	totals := `
+		SELECT
+			(SELECT COUNT(*) FROM shorturl_clicks WHERE short_id = $1 AND clicked_at >= $2)
+			+ (SELECT COALESCE(SUM(clicks), 0) FROM shorturl_clicks_daily WHERE short_id = $1 AND day >= ($2::TIMESTAMPTZ AT TIME ZONE 'UTC')::DATE),
+			(SELECT COUNT(DISTINCT ip_hash) FROM shorturl_visitor_days WHERE short_id = $1 AND day >= ($2::TIMESTAMPTZ AT TIME ZONE 'UTC')::DATE)
+	`
+	if err := s.q.QueryRowContext(ctx, totals, shortID, since).Scan(&a.TotalClicks, &a.UniqueVisitors); err != nil {
+		return nil, err
+	}
+
+	buckets := clickEvents + `
+		SELECT to_timestamp(floor(extract(epoch FROM at) / $3) * $3) AS start, SUM(clicks)
+		FROM events GROUP BY start ORDER BY start
+	`
+	rows, err := s.q.QueryContext(ctx, buckets, shortID, since, int64(bucket.Seconds()))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	a.Buckets = []model.ClickBucket{}
+	for rows.Next() {
+		var b model.ClickBucket
+		if err := rows.Scan(&b.Start, &b.Clicks); err != nil {
+			return nil, err
+		}
+		a.Buckets = append(a.Buckets, b)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	referrers := clickEvents + `
+		SELECT referrer_host, SUM(clicks) AS n FROM events
+		WHERE referrer_host <> '' GROUP BY referrer_host ORDER BY n DESC, referrer_host LIMIT $3
+	`
+	rows, err = s.q.QueryContext(ctx, referrers, shortID, since, topReferrers)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	a.TopReferrers = []model.ReferrerCount{}
+	for rows.Next() {
+		var r model.ReferrerCount
+		if err := rows.Scan(&r.Host, &r.Clicks); err != nil {
+			return nil, err
+		}
+		a.TopReferrers = append(a.TopReferrers, r)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if a.Countries, err = s.sumClicksBy(ctx, "country", shortID, since); err != nil {
+		return nil, err
+	}
+	if a.AgentClasses, err = s.sumClicksBy(ctx, "agent_class", shortID, since); err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
+
+// sumClicksBy считает переходы в разрезе column; column — только константы из этого файла.
+func (s *PostgresStorage) sumClicksBy(ctx context.Context, column, shortID string, since time.Time) (map[string]int64, error) {
+	query := clickEvents + `SELECT ` + column + `, SUM(clicks) FROM events GROUP BY 1`
+	rows, err := s.q.QueryContext(ctx, query, shortID, since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := make(map[string]int64)
+	for rows.Next() {
+		var key string
+		var n int64
+		if err := rows.Scan(&key, &n); err != nil {
+			return nil, err
+		}
+		out[key] = n
+	}
+	return out, rows.Err()
+}
+
+func (s *PostgresStorage) DownsampleClicks(ctx context.Context, before time.Time) (int64, error) {
+	ctx, end := observe(ctx, "DownsampleClicks")
+	defer end()
+	var removed int64
+	err := s.inTx(ctx, func(tx querier) error {
+		daily := `
+			INSERT INTO shorturl_clicks_daily (short_id, day, referrer_host, agent_class, country, clicks)
+			SELECT short_id, (clicked_at AT TIME ZONE 'UTC')::DATE, referrer_host, agent_class, country, COUNT(*)
+			FROM shorturl_clicks WHERE clicked_at < $1
+			GROUP BY 1, 2, 3, 4, 5
+			ON CONFLICT (short_id, day, referrer_host, agent_class, country)
+			DO UPDATE SET clicks = shorturl_clicks_daily.clicks + EXCLUDED.clicks
+		`
+		if _, err := tx.ExecContext(ctx, daily, before); err != nil {
+			return err
+		}
+		visitors := `DELETE FROM shorturl_visitor_days WHERE day < ($1::TIMESTAMPTZ AT TIME ZONE 'UTC')::DATE`
+		if _, err := tx.ExecContext(ctx, visitors, before); err != nil {
+			return err
+		}
+		res, err := tx.ExecContext(ctx, `DELETE FROM shorturl_clicks WHERE clicked_at < $1`, before)
+		if err != nil {
+			return err
+		}
+		removed, err = res.RowsAffected()
+		return err
+	})
+	return removed, err
+}
+
+// Audit
+func (s *PostgresStorage) AppendAudit(ctx context.Context, e model.AuditEntry) error {
+	ctx, end := observe(ctx, "AppendAudit")
+	defer end()
+	query := `INSERT INTO audit_log (entity, entity_id, action, actor, request_id, created_at, before, after) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+	_, err := s.q.ExecContext(ctx, query, e.Entity, e.EntityID, e.Action, e.Actor, e.RequestID, e.At, nullJSON(e.Before), nullJSON(e.After))
 	return err
 }
+
+func (s *PostgresStorage) ListAudit(ctx context.Context, f model.AuditFilter) ([]model.AuditEntry, error) {
+	ctx, end := observe(ctx, "ListAudit")
+	defer end()
+	query := `
+		SELECT id, entity, entity_id, action, actor, request_id, created_at, before, after
+		FROM audit_log
+		WHERE ($1 = '' OR entity = $1)
+		  AND ($2 = '' OR actor = $2)
+		  AND ($3::TIMESTAMPTZ IS NULL OR created_at >= $3)
+		  AND ($4::TIMESTAMPTZ IS NULL OR created_at < $4)
+		ORDER BY created_at DESC, id DESC
+		LIMIT $5
+	`
+	rows, err := s.q.QueryContext(ctx, query, f.Entity, f.Actor, nullTime(f.From), nullTime(f.To), f.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []model.AuditEntry
+	for rows.Next() {
+		var e model.AuditEntry
+		var before, after []byte
+		if err := rows.Scan(&e.ID, &e.Entity, &e.EntityID, &e.Action, &e.Actor, &e.RequestID, &e.At, &before, &after); err != nil {
+			return nil, err
+		}
+		e.Before, e.After = before, after
+		entries = append(entries, e)
+	}
+	return entries, rows.Err()
+}
+
+func (s *PostgresStorage) DeleteAuditBefore(ctx context.Context, before time.Time) (int64, error) {
+	ctx, end := observe(ctx, "DeleteAuditBefore")
+	defer end()
+	res, err := s.q.ExecContext(ctx, `DELETE FROM audit_log WHERE created_at < $1`, before)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+// Webhook
+func (s *PostgresStorage) SaveWebhook(ctx context.Context, w model.Webhook) (int64, error) {
+	ctx, end := observe(ctx, "SaveWebhook")
+	defer end()
+	query := `INSERT INTO webhooks (url, secret, events, user_id, created_at) VALUES ($1, $2, $3, $4, $5) RETURNING id`
+	var id int64
+	err := s.q.QueryRowContext(ctx, query, w.URL, w.Secret, pq.Array(w.Events), nullUserID(w.UserID), w.CreatedAt).Scan(&id)
+	return id, err
+}
+
+func (s *PostgresStorage) GetWebhook(ctx context.Context, id int64) (*model.Webhook, error) {
+	ctx, end := observe(ctx, "GetWebhook")
+	defer end()
+	query := `SELECT id, url, secret, events, COALESCE(user_id, 0), created_at FROM webhooks WHERE id = $1`
+	var w model.Webhook
+	err := s.q.QueryRowContext(ctx, query, id).Scan(&w.ID, &w.URL, &w.Secret, pq.Array(&w.Events), &w.UserID, &w.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &w, nil
+}
+
+// DeleteWebhook удаляет вебхук вместе с его доставками.
+func (s *PostgresStorage) DeleteWebhook(ctx context.Context, id int64) error {
+	ctx, end := observe(ctx, "DeleteWebhook")
+	defer end()
+	res, err := s.q.ExecContext(ctx, `DELETE FROM webhooks WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	return expectAffected(res)
+}
+
+func (s *PostgresStorage) ListWebhooksForEvent(ctx context.Context, event string, userID int64) ([]model.Webhook, error) {
+	ctx, end := observe(ctx, "ListWebhooksForEvent")
+	defer end()
+	query := `
+		SELECT id, url, secret, events, COALESCE(user_id, 0), created_at FROM webhooks
+		WHERE $1 = ANY(events) AND COALESCE(user_id, 0) = $2
+		ORDER BY id
+	`
+	rows, err := s.q.QueryContext(ctx, query, event, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var hooks []model.Webhook
+	for rows.Next() {
+		var w model.Webhook
+		if err := rows.Scan(&w.ID, &w.URL, &w.Secret, pq.Array(&w.Events), &w.UserID, &w.CreatedAt); err != nil {
+			return nil, err
+		}
+		hooks = append(hooks, w)
+	}
+	return hooks, rows.Err()
+}
+
+func (s *PostgresStorage) EnqueueWebhookDeliveries(ctx context.Context, deliveries []model.WebhookDelivery) error {
+	ctx, end := observe(ctx, "EnqueueWebhookDeliveries")
+	defer end()
+	return s.inTx(ctx, func(tx querier) error {
+		query := `
+			INSERT INTO webhook_deliveries (webhook_id, event_id, event, payload, status, next_attempt_at, created_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)
+		`
+		for _, d := range deliveries {
+			if _, err := tx.ExecContext(ctx, query, d.WebhookID, d.EventID, d.Event, string(d.Payload), d.Status, d.NextAttemptAt, d.CreatedAt); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (s *PostgresStorage) ClaimWebhookDeliveries(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]model.WebhookDelivery, error) {
+	ctx, end := observe(ctx, "ClaimWebhookDeliveries")
+	defer end()
+	query := `
+		UPDATE webhook_deliveries SET next_attempt_at = $2
+		WHERE id IN (
+		    SELECT id FROM webhook_deliveries
+		    WHERE status = 'pending' AND next_attempt_at <= $1
+		    ORDER BY next_attempt_at
+		    LIMIT $3
+		    FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + deliveryColumns
+	rows, err := s.q.QueryContext(ctx, query, now, now.Add(lease), limit)
+	if err != nil {
+		return nil, err
+	}
+	return scanDeliveries(rows)
+}
+
+func (s *PostgresStorage) RecordWebhookAttempt(ctx context.Context, d model.WebhookDelivery, a model.WebhookAttempt) error {
+	ctx, end := observe(ctx, "RecordWebhookAttempt")
+	defer end()
+	return s.inTx(ctx, func(tx querier) error {
+		insert := `
+			INSERT INTO webhook_attempts (delivery_id, attempt, at, status_code, error, duration_ms)
+			VALUES ($1, $2, $3, $4, $5, $6)
+		`
+		status := sql.NullInt64{Int64: int64(a.StatusCode), Valid: a.StatusCode != 0}
+		if _, err := tx.ExecContext(ctx, insert, d.ID, a.Attempt, a.At, status, a.Error, a.DurationMS); err != nil {
+			return err
+		}
+		update := `UPDATE webhook_deliveries SET status = $2, attempt_count = $3, next_attempt_at = $4 WHERE id = $1`
+		res, err := tx.ExecContext(ctx, update, d.ID, d.Status, d.AttemptCount, d.NextAttemptAt)
+		if err != nil {
+			return err
+		}
+		return expectAffected(res)
+	})
+}
+
+func (s *PostgresStorage) ListWebhookDeliveries(ctx context.Context, webhookID int64, status string, limit int) ([]model.WebhookDelivery, error) {
+	ctx, end := observe(ctx, "ListWebhookDeliveries")
+	defer end()
+	query := `
+		SELECT ` + deliveryColumns + ` FROM webhook_deliveries
+		WHERE webhook_id = $1 AND ($2 = '' OR status = $2)
+		ORDER BY created_at DESC, id DESC
+		LIMIT $3
+	`
+	rows, err := s.q.QueryContext(ctx, query, webhookID, status, limit)
+	if err != nil {
+		return nil, err
+	}
+	deliveries, err := scanDeliveries(rows)
+	if err != nil || len(deliveries) == 0 {
+		return deliveries, err
+	}
+
+	index := make(map[int64]int, len(deliveries))
+	ids := make([]int64, len(deliveries))
+	for i, d := range deliveries {
+		index[d.ID] = i
+		ids[i] = d.ID
+	}
+	rows, err = s.q.QueryContext(ctx, `
+		SELECT delivery_id, attempt, at, COALESCE(status_code, 0), error, duration_ms
+		FROM webhook_attempts WHERE delivery_id = ANY($1)
+		ORDER BY delivery_id, attempt
+	`, pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var id int64
+		var a model.WebhookAttempt
+		if err := rows.Scan(&id, &a.Attempt, &a.At, &a.StatusCode, &a.Error, &a.DurationMS); err != nil {
+			return nil, err
+		}
+		d := &deliveries[index[id]]
+		d.Attempts = append(d.Attempts, a)
+	}
+	return deliveries, rows.Err()
+}
+
+const deliveryColumns = `id, webhook_id, event_id, event, payload, status, attempt_count, next_attempt_at, created_at`
+
+func scanDeliveries(rows *sql.Rows) ([]model.WebhookDelivery, error) {
+	defer rows.Close()
+	var deliveries []model.WebhookDelivery
+	for rows.Next() {
+		var d model.WebhookDelivery
+		var payload []byte
+		var next sql.NullTime
+		if err := rows.Scan(&d.ID, &d.WebhookID, &d.EventID, &d.Event, &payload, &d.Status, &d.AttemptCount, &next, &d.CreatedAt); err != nil {
+			return nil, err
+		}
+		d.Payload = payload
+		if next.Valid && d.Status == model.DeliveryPending {
+			d.NextAttemptAt = &next.Time
+		}
+		d.Attempts = []model.WebhookAttempt{}
+		deliveries = append(deliveries, d)
+	}
+	return deliveries, rows.Err()
+}
+
+func (s *PostgresStorage) inTx(ctx context.Context, fn func(tx querier) error) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if err := fn(tracedQuerier{q: tx}); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+func nullUserID(id int64) sql.NullInt64 {
+	return sql.NullInt64{Int64: id, Valid: id != 0}
+}
+
+// nullJSON сохраняет пустое описание как NULL, а не как невалидный JSONB.
+func nullJSON(b []byte) any {
+	if len(b) == 0 {
+		return nil
+	}
+	return string(b)
+}
+
+func nullTime(t time.Time) sql.NullTime {
+	return sql.NullTime{Time: t, Valid: !t.IsZero()}
+}
+
+func expectAffected(res sql.Result) error {
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
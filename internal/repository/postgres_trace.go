@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/GritsyukLeonid/pastebin-go/internal/metrics"
+	"github.com/GritsyukLeonid/pastebin-go/internal/tracing"
+)
+
+// querier — общие методы *sql.DB и *sql.Tx.
+type querier interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// tracedQuerier открывает спан на каждый SQL-запрос.
+type tracedQuerier struct {
+	q querier
+}
+
+func (t tracedQuerier) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	ctx, span := tracing.StartQuery(ctx, query)
+	defer span.End()
+	res, err := t.q.ExecContext(ctx, query, args...)
+	tracing.Fail(span, err)
+	return res, err
+}
+
+func (t tracedQuerier) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	ctx, span := tracing.StartQuery(ctx, query)
+	defer span.End()
+	rows, err := t.q.QueryContext(ctx, query, args...)
+	tracing.Fail(span, err)
+	return rows, err
+}
+
+func (t tracedQuerier) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	ctx, span := tracing.StartQuery(ctx, query)
+	defer span.End()
+	row := t.q.QueryRowContext(ctx, query, args...)
+	tracing.Fail(span, row.Err())
+	return row
+}
+
+// observe открывает спан метода хранилища и замеряет его длительность для метрик:
+//
+//	ctx, end := observe(ctx, "GetPasteByID")
+//	defer end()
+func observe(ctx context.Context, method string) (context.Context, func()) {
+	start := time.Now()
+	ctx, span := tracing.Start(ctx, "PostgresStorage."+method)
+	return ctx, func() {
+		span.End()
+		metrics.ObserveQuery(method, start)
+	}
+}
@@ -1,10 +1,12 @@
 package repository
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
 	"sync"
+	"time"
 
 	"github.com/GritsyukLeonid/pastebin-go/internal/model"
 )
@@ -20,12 +22,9 @@ var (
 	StatsSet []*model.Stats
 	URLs     []*model.ShortURL
 
-	prevCounts = map[string]int{
-		"Paste":    0,
-		"User":     0,
-		"Stats":    0,
-		"ShortURL": 0,
-	}
+	// Хэши токенов удаления не попадают в pastes.json и urls.json (json:"-"), поэтому хранятся отдельно.
+	pasteTokenHashes    = map[string]string{}
+	shortURLTokenHashes = map[string]string{}
 )
 
 func StoreObject(obj model.Storable) error {
@@ -34,6 +33,12 @@ func StoreObject(obj model.Storable) error {
 		pasteMutex.Lock()
 		defer pasteMutex.Unlock()
 		Pastes = append(Pastes, v)
+		if v.DeleteTokenHash != "" {
+			pasteTokenHashes[v.ID] = v.DeleteTokenHash
+			if err := saveJSON("paste_tokens.json", pasteTokenHashes); err != nil {
+				return err
+			}
+		}
 		return saveJSON("pastes.json", Pastes)
 	case *model.User:
 		userMutex.Lock()
@@ -49,54 +54,18 @@ func StoreObject(obj model.Storable) error {
 		urlMutex.Lock()
 		defer urlMutex.Unlock()
 		URLs = append(URLs, v)
+		if v.DeleteTokenHash != "" {
+			shortURLTokenHashes[v.ID] = v.DeleteTokenHash
+			if err := saveJSON("shorturl_tokens.json", shortURLTokenHashes); err != nil {
+				return err
+			}
+		}
 		return saveJSON("urls.json", URLs)
 	default:
 		return fmt.Errorf("unknown type: %s", v.GetTypeName())
 	}
 }
 
-func DetectNewObjects() map[string][]string {
-	result := make(map[string][]string)
-
-	pasteMutex.Lock()
-	if len(Pastes) > prevCounts["Paste"] {
-		for i := prevCounts["Paste"]; i < len(Pastes); i++ {
-			result["Paste"] = append(result["Paste"], fmt.Sprintf("%+v", Pastes[i]))
-		}
-		prevCounts["Paste"] = len(Pastes)
-	}
-	pasteMutex.Unlock()
-
-	userMutex.Lock()
-	if len(Users) > prevCounts["User"] {
-		for i := prevCounts["User"]; i < len(Users); i++ {
-			result["User"] = append(result["User"], fmt.Sprintf("%+v", Users[i]))
-		}
-		prevCounts["User"] = len(Users)
-	}
-	userMutex.Unlock()
-
-	statsMutex.Lock()
-	if len(StatsSet) > prevCounts["Stats"] {
-		for i := prevCounts["Stats"]; i < len(StatsSet); i++ {
-			result["Stats"] = append(result["Stats"], fmt.Sprintf("%+v", StatsSet[i]))
-		}
-		prevCounts["Stats"] = len(StatsSet)
-	}
-	statsMutex.Unlock()
-
-	urlMutex.Lock()
-	if len(URLs) > prevCounts["ShortURL"] {
-		for i := prevCounts["ShortURL"]; i < len(URLs); i++ {
-			result["ShortURL"] = append(result["ShortURL"], fmt.Sprintf("%+v", URLs[i]))
-		}
-		prevCounts["ShortURL"] = len(URLs)
-	}
-	urlMutex.Unlock()
-
-	return result
-}
-
 func saveJSON(filename string, data any) error {
 	file, err := os.Create(filename)
 	if err != nil {
@@ -109,16 +78,31 @@ func saveJSON(filename string, data any) error {
 	return enc.Encode(data)
 }
 
+// CheckFileStorage проверяет, что в рабочий каталог с JSON-файлами можно писать.
+func CheckFileStorage(ctx context.Context) error {
+	f, err := os.CreateTemp(".", ".healthcheck-*")
+	if err != nil {
+		return err
+	}
+	name := f.Name()
+	f.Close()
+	return os.Remove(name)
+}
+
 func LoadData() {
 	loadJSON("pastes.json", &Pastes)
 	loadJSON("users.json", &Users)
 	loadJSON("stats.json", &StatsSet)
 	loadJSON("urls.json", &URLs)
+	loadJSON("paste_tokens.json", &pasteTokenHashes)
+	loadJSON("shorturl_tokens.json", &shortURLTokenHashes)
 
-	prevCounts["Paste"] = len(Pastes)
-	prevCounts["User"] = len(Users)
-	prevCounts["Stats"] = len(StatsSet)
-	prevCounts["ShortURL"] = len(URLs)
+	for _, p := range Pastes {
+		p.DeleteTokenHash = pasteTokenHashes[p.ID]
+	}
+	for _, u := range URLs {
+		u.DeleteTokenHash = shortURLTokenHashes[u.ID]
+	}
 }
 
 func GetAllPastes() []*model.Paste {
@@ -156,6 +140,12 @@ func DeletePaste(id string) error {
 	for i, p := range Pastes {
 		if p.ID == id {
 			Pastes = append(Pastes[:i], Pastes[i+1:]...)
+			if _, ok := pasteTokenHashes[id]; ok {
+				delete(pasteTokenHashes, id)
+				if err := saveJSON("paste_tokens.json", pasteTokenHashes); err != nil {
+					return err
+				}
+			}
 			return saveJSON("pastes.json", Pastes)
 		}
 	}
@@ -270,18 +260,41 @@ func UpdateShortURL(id string, newURL *model.ShortURL) error {
 	}
 	return fmt.Errorf("shorturl not found")
 }
-func DeleteShortURL(id string) error {
+
+// DeleteShortURL помечает ссылку удалённой; запись остаётся, чтобы код не выдавался повторно.
+func DeleteShortURL(id string, at time.Time) error {
 	urlMutex.Lock()
 	defer urlMutex.Unlock()
-	for i, u := range URLs {
+	for _, u := range URLs {
 		if u.ID == id {
-			URLs = append(URLs[:i], URLs[i+1:]...)
+			if u.DeletedAt == nil {
+				deletedAt := at
+				u.DeletedAt = &deletedAt
+			}
 			return saveJSON("urls.json", URLs)
 		}
 	}
 	return fmt.Errorf("shorturl not found")
 }
 
+// TombstoneShortURLs помечает удалёнными короткие ссылки на пасту с указанным hash.
+func TombstoneShortURLs(hash string, at time.Time) error {
+	urlMutex.Lock()
+	defer urlMutex.Unlock()
+	changed := false
+	for _, u := range URLs {
+		if u.Original == hash && !u.IsURL() && u.DeletedAt == nil {
+			deletedAt := at
+			u.DeletedAt = &deletedAt
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+	return saveJSON("urls.json", URLs)
+}
+
 func loadJSON(filename string, target any) {
 	file, err := os.Open(filename)
 	if err != nil {
@@ -0,0 +1,67 @@
+package reqctx
+
+import (
+	"bufio"
+	"errors"
+	"net"
+	"net/http"
+)
+
+// StatusRecorder запоминает код ответа и число записанных байт для middleware.
+// Flush и Hijack передаются исходному ResponseWriter, поэтому потоковые ответы продолжают работать.
+type StatusRecorder struct {
+	http.ResponseWriter
+	status int
+	bytes  int64
+}
+
+func NewStatusRecorder(w http.ResponseWriter) *StatusRecorder {
+	if rec, ok := w.(*StatusRecorder); ok {
+		return rec
+	}
+	return &StatusRecorder{ResponseWriter: w}
+}
+
+func (r *StatusRecorder) Status() int {
+	if r.status == 0 {
+		return http.StatusOK
+	}
+	return r.status
+}
+
+func (r *StatusRecorder) Bytes() int64 {
+	return r.bytes
+}
+
+func (r *StatusRecorder) WriteHeader(code int) {
+	if r.status == 0 {
+		r.status = code
+	}
+	r.ResponseWriter.WriteHeader(code)
+}
+
+func (r *StatusRecorder) Write(b []byte) (int, error) {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+	n, err := r.ResponseWriter.Write(b)
+	r.bytes += int64(n)
+	return n, err
+}
+
+func (r *StatusRecorder) Flush() {
+	if f, ok := r.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+func (r *StatusRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	if h, ok := r.ResponseWriter.(http.Hijacker); ok {
+		return h.Hijack()
+	}
+	return nil, nil, errors.New("hijacking is not supported")
+}
+
+func (r *StatusRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
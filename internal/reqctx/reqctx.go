@@ -0,0 +1,137 @@
+// Package reqctx хранит в context.Context сведения о запросе и клиенте, который его выполняет:
+// IP, идентификатор запроса и пользователя.
+package reqctx
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"net"
+	"net/http"
+	"sync"
+
+	"github.com/gorilla/mux"
+)
+
+// RequestIDHeader — заголовок HTTP и ключ метаданных gRPC (в нижнем регистре) с идентификатором запроса.
+const RequestIDHeader = "X-Request-ID"
+
+const maxRequestIDLength = 128
+
+type contextKey int
+
+const (
+	clientIPKey contextKey = iota
+	requestIDKey
+	userKey
+)
+
+func WithClientIP(ctx context.Context, ip string) context.Context {
+	return context.WithValue(ctx, clientIPKey, ip)
+}
+
+func ClientIP(ctx context.Context) string {
+	ip, _ := ctx.Value(clientIPKey).(string)
+	return ip
+}
+
+// ClientIPHandler сохраняет в контексте запроса IP клиента, который определяет clientIP
+// (за доверенным прокси — из X-Forwarded-For), чтобы его видели лимиты, квоты и журнал.
+func ClientIPHandler(next http.Handler, clientIP func(*http.Request) string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		next.ServeHTTP(w, r.WithContext(WithClientIP(r.Context(), clientIP(r))))
+	})
+}
+
+// RemoteIP возвращает IP клиента запроса: сохранённый ClientIPHandler, а без него — адрес TCP-соединения.
+func RemoteIP(r *http.Request) string {
+	if ip := ClientIP(r.Context()); ip != "" {
+		return ip
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey, id)
+}
+
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey).(string)
+	return id
+}
+
+// RequestIDOrNew возвращает присланный клиентом идентификатор, если он допустим, иначе новый.
+func RequestIDOrNew(id string) string {
+	if validRequestID(id) {
+		return id
+	}
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// validRequestID пропускает только печатный ASCII без пробелов, чтобы идентификатор
+// нельзя было использовать для подделки строк журнала или заголовков.
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] <= ' ' || id[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+type user struct {
+	mu sync.Mutex
+	id string
+}
+
+// WithUser добавляет в контекст изменяемую ячейку пользователя, которую заполняет SetUser.
+// Так обработчик может сообщить middleware журнала, кто выполнил запрос.
+func WithUser(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, userKey, &user{id: id})
+}
+
+func SetUser(ctx context.Context, id string) {
+	if u, ok := ctx.Value(userKey).(*user); ok {
+		u.mu.Lock()
+		u.id = id
+		u.mu.Unlock()
+	}
+}
+
+func User(ctx context.Context) string {
+	u, ok := ctx.Value(userKey).(*user)
+	if !ok {
+		return ""
+	}
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	return u.id
+}
+
+// APIKeyID — обезличенный идентификатор API-ключа: сам ключ не попадает в журналы, память и Redis.
+func APIKeyID(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return "key:" + hex.EncodeToString(sum[:8])
+}
+
+// Route возвращает шаблон маршрута router для запроса (/api/v1/paste/{id}) или "unmatched".
+// Сырые пути не годятся для меток и полей журнала: в них идентификаторы.
+func Route(router *mux.Router, r *http.Request) string {
+	var match mux.RouteMatch
+	if router.Match(r, &match) && match.Route != nil {
+		if tpl, err := match.Route.GetPathTemplate(); err == nil {
+			return tpl
+		}
+	}
+	return "unmatched"
+}
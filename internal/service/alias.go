@@ -0,0 +1,54 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	minAliasLength = 3
+	maxAliasLength = 32
+)
+
+var (
+	ErrInvalidAlias   = errors.New("invalid alias")
+	ErrShortCodeTaken = errors.New("short code already exists")
+)
+
+// reservedAliases совпадают с путями сервиса или зарезервированы под них. Пути, которые и так
+// не проходят проверку длины и символов (/s/, /favicon.ico), здесь не перечисляются.
+var reservedAliases = map[string]struct{}{
+	"api":     {},
+	"swagger": {},
+	"docs":    {},
+	"admin":   {},
+	"static":  {},
+	"assets":  {},
+	"health":  {},
+	"healthz": {},
+	"readyz":  {},
+	"livez":   {},
+	"metrics": {},
+}
+
+// ValidateAlias проверяет пользовательский короткий код: длина, допустимые символы
+// (латиница, цифры, '-' и '_') и список зарезервированных слов.
+func ValidateAlias(alias string) error {
+	if len(alias) < minAliasLength || len(alias) > maxAliasLength {
+		return fmt.Errorf("%w: length must be between %d and %d", ErrInvalidAlias, minAliasLength, maxAliasLength)
+	}
+	for _, r := range alias {
+		if !isAliasRune(r) {
+			return fmt.Errorf("%w: unsupported character %q", ErrInvalidAlias, r)
+		}
+	}
+	if _, ok := reservedAliases[strings.ToLower(alias)]; ok {
+		return fmt.Errorf("%w: %q is reserved", ErrInvalidAlias, alias)
+	}
+	return nil
+}
+
+func isAliasRune(r rune) bool {
+	return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '-' || r == '_'
+}
@@ -0,0 +1,54 @@
+package service
+
+import (
+	"time"
+
+	"github.com/GritsyukLeonid/pastebin-go/internal/model"
+)
+
+// Краткие описания сущностей для событий (Before и After) и журнала аудита. Содержимое паст и токены
+// в журнал не попадают: только размер и метаданные.
+
+type pasteAudit struct {
+	Hash      string    `json:"hash"`
+	Size      int       `json:"size,omitempty"`
+	ExpiresAt time.Time `json:"expiresAt"`
+	UserID    int64     `json:"userId,omitempty"`
+	ShortCode string    `json:"shortCode,omitempty"`
+}
+
+func auditPaste(p *model.Paste) any {
+	if p == nil {
+		return nil
+	}
+	return pasteAudit{Hash: p.Hash, Size: p.ContentSize(), ExpiresAt: p.ExpiresAt, UserID: p.UserID, ShortCode: p.ShortCode}
+}
+
+func auditUser(u *model.User) any {
+	if u == nil {
+		return nil
+	}
+	return struct {
+		Username string `json:"username"`
+	}{u.Username}
+}
+
+func auditStats(s *model.Stats) any {
+	if s == nil {
+		return nil
+	}
+	return struct {
+		Views int `json:"views"`
+	}{s.Views}
+}
+
+func auditShortURL(u *model.ShortURL) any {
+	if u == nil {
+		return nil
+	}
+	return struct {
+		Original   string     `json:"original"`
+		TargetType string     `json:"targetType"`
+		ExpiresAt  *time.Time `json:"expiresAt,omitempty"`
+	}{u.Original, u.TargetType, u.ExpiresAt}
+}
@@ -0,0 +1,60 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/GritsyukLeonid/pastebin-go/internal/model"
+	"github.com/GritsyukLeonid/pastebin-go/internal/repository"
+	"github.com/GritsyukLeonid/pastebin-go/internal/tracing"
+)
+
+var ErrInvalidAuditFilter = errors.New("invalid audit filter")
+
+const (
+	DefaultAuditLimit = 100
+	MaxAuditLimit     = 1000
+)
+
+type auditService struct {
+	audit     repository.AuditStorage
+	retention time.Duration
+}
+
+// NewAuditService создаёт сервис журнала аудита; записи хранятся retention.
+func NewAuditService(audit repository.AuditStorage, retention time.Duration) AuditService {
+	return &auditService{audit: audit, retention: retention}
+}
+
+func (s *auditService) ListAudit(ctx context.Context, f model.AuditFilter) ([]model.AuditEntry, error) {
+	ctx, span := tracing.Start(ctx, "AuditService.ListAudit")
+	defer span.End()
+
+	if f.Limit == 0 {
+		f.Limit = DefaultAuditLimit
+	}
+	if f.Limit < 0 || f.Limit > MaxAuditLimit {
+		return nil, fmt.Errorf("%w: limit must be between 1 and %d", ErrInvalidAuditFilter, MaxAuditLimit)
+	}
+	if !f.From.IsZero() && !f.To.IsZero() && !f.From.Before(f.To) {
+		return nil, fmt.Errorf("%w: from must be before to", ErrInvalidAuditFilter)
+	}
+
+	entries, err := s.audit.ListAudit(ctx, f)
+	if err != nil {
+		return nil, err
+	}
+	if entries == nil {
+		entries = []model.AuditEntry{}
+	}
+	return entries, nil
+}
+
+func (s *auditService) PruneAudit(ctx context.Context) (int64, error) {
+	ctx, span := tracing.Start(ctx, "AuditService.PruneAudit")
+	defer span.End()
+
+	return s.audit.DeleteAuditBefore(ctx, time.Now().Add(-s.retention))
+}
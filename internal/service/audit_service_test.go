@@ -0,0 +1,68 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/GritsyukLeonid/pastebin-go/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+// Моки
+
+type mockAuditStorage struct {
+	filter model.AuditFilter
+	before time.Time
+}
+
+func (m *mockAuditStorage) AppendAudit(_ context.Context, e model.AuditEntry) error {
+	return nil
+}
+
+func (m *mockAuditStorage) ListAudit(_ context.Context, f model.AuditFilter) ([]model.AuditEntry, error) {
+	m.filter = f
+	return nil, nil
+}
+
+func (m *mockAuditStorage) DeleteAuditBefore(_ context.Context, before time.Time) (int64, error) {
+	m.before = before
+	return 3, nil
+}
+
+// Тесты
+
+func TestListAuditDefaultsLimit(t *testing.T) {
+	storage := &mockAuditStorage{}
+	svc := NewAuditService(storage, 90*24*time.Hour)
+
+	entries, err := svc.ListAudit(context.Background(), model.AuditFilter{Entity: "paste"})
+	assert.NoError(t, err)
+	assert.NotNil(t, entries, "пустой результат сериализуется как [], а не null")
+	assert.Equal(t, DefaultAuditLimit, storage.filter.Limit)
+	assert.Equal(t, "paste", storage.filter.Entity)
+}
+
+func TestListAuditRejectsInvalidFilter(t *testing.T) {
+	svc := NewAuditService(&mockAuditStorage{}, 90*24*time.Hour)
+	now := time.Now()
+
+	for _, f := range []model.AuditFilter{
+		{Limit: MaxAuditLimit + 1},
+		{Limit: -1},
+		{From: now, To: now.Add(-time.Hour)},
+	} {
+		_, err := svc.ListAudit(context.Background(), f)
+		assert.ErrorIs(t, err, ErrInvalidAuditFilter)
+	}
+}
+
+func TestPruneAuditUsesRetention(t *testing.T) {
+	storage := &mockAuditStorage{}
+	svc := NewAuditService(storage, 24*time.Hour)
+
+	n, err := svc.PruneAudit(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), n)
+	assert.WithinDuration(t, time.Now().Add(-24*time.Hour), storage.before, time.Minute)
+}
@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/GritsyukLeonid/pastebin-go/internal/analytics"
+	"github.com/GritsyukLeonid/pastebin-go/internal/model"
+	"github.com/GritsyukLeonid/pastebin-go/internal/repository"
+	"github.com/GritsyukLeonid/pastebin-go/internal/tracing"
+)
+
+var ErrInvalidBucket = errors.New("bucket must be hour or day")
+
+const (
+	BucketHour = "hour"
+	BucketDay  = "day"
+
+	topReferrersLimit = 10
+)
+
+var bucketSizes = map[string]time.Duration{
+	BucketHour: time.Hour,
+	BucketDay:  24 * time.Hour,
+}
+
+type clickService struct {
+	storage   repository.StorageInterface
+	clicks    repository.ClickStorage
+	geo       analytics.GeoResolver
+	hasher    analytics.IPHasher
+	retention time.Duration
+}
+
+// NewClickService создаёт сервис аналитики; сырые переходы хранятся retention, затем прореживаются по дням.
+func NewClickService(storage repository.StorageInterface, clicks repository.ClickStorage, geo analytics.GeoResolver, hasher analytics.IPHasher, retention time.Duration) ClickService {
+	return &clickService{storage: storage, clicks: clicks, geo: geo, hasher: hasher, retention: retention}
+}
+
+func (s *clickService) RecordClick(ctx context.Context, shortID, ip, referrer, userAgent string) error {
+	ctx, span := tracing.Start(ctx, "ClickService.RecordClick")
+	defer span.End()
+
+	return s.clicks.SaveClick(ctx, model.Click{
+		ShortID:      shortID,
+		At:           time.Now().UTC(),
+		ReferrerHost: analytics.ReferrerHost(referrer),
+		AgentClass:   analytics.ClassifyAgent(userAgent),
+		Country:      s.geo.Country(ip),
+		IPHash:       s.hasher.Hash(ip),
+	})
+}
+
+// GetAnalytics доступна и для истёкших ссылок: статистика переживает саму ссылку.
+func (s *clickService) GetAnalytics(ctx context.Context, shortID string, since time.Time, bucket string) (model.ClickAnalytics, error) {
+	ctx, span := tracing.Start(ctx, "ClickService.GetAnalytics")
+	defer span.End()
+
+	size, ok := bucketSizes[bucket]
+	if !ok {
+		return model.ClickAnalytics{}, ErrInvalidBucket
+	}
+	if _, err := s.storage.GetShortURLByID(ctx, shortID); err != nil {
+		return model.ClickAnalytics{}, fmt.Errorf("%w: %v", ErrShortURLNotFound, err)
+	}
+
+	since = since.UTC().Truncate(size)
+	a, err := s.clicks.GetClickAnalytics(ctx, shortID, since, size, topReferrersLimit)
+	if err != nil {
+		return model.ClickAnalytics{}, err
+	}
+	a.Bucket = bucket
+	return *a, nil
+}
+
+func (s *clickService) DownsampleClicks(ctx context.Context) (int64, error) {
+	ctx, span := tracing.Start(ctx, "ClickService.DownsampleClicks")
+	defer span.End()
+
+	// Граница выравнивается по суткам UTC, чтобы день не делился между сырыми и дневными данными.
+	before := time.Now().UTC().Add(-s.retention).Truncate(24 * time.Hour)
+	return s.clicks.DownsampleClicks(ctx, before)
+}
@@ -0,0 +1,94 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/GritsyukLeonid/pastebin-go/internal/analytics"
+	"github.com/GritsyukLeonid/pastebin-go/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+// Моки
+
+type mockClickStorage struct {
+	clicks     []model.Click
+	since      time.Time
+	bucket     time.Duration
+	downsample time.Time
+}
+
+func (m *mockClickStorage) SaveClick(_ context.Context, c model.Click) error {
+	m.clicks = append(m.clicks, c)
+	return nil
+}
+
+func (m *mockClickStorage) GetClickAnalytics(_ context.Context, shortID string, since time.Time, bucket time.Duration, top int) (*model.ClickAnalytics, error) {
+	m.since, m.bucket = since, bucket
+	return &model.ClickAnalytics{ShortID: shortID, Since: since, TotalClicks: int64(len(m.clicks))}, nil
+}
+
+func (m *mockClickStorage) DownsampleClicks(_ context.Context, before time.Time) (int64, error) {
+	m.downsample = before
+	return 0, nil
+}
+
+type fixedGeo string
+
+func (g fixedGeo) Country(string) string { return string(g) }
+
+func setupClickService() (ClickService, *mockClickStorage) {
+	storage := &mockShortURLStorage{shorts: map[string]model.ShortURL{
+		"abc123": {ID: "abc123", Original: "https://go.dev", TargetType: model.TargetURL},
+	}}
+	clicks := &mockClickStorage{}
+	svc := NewClickService(storage, clicks, fixedGeo("DE"), analytics.NewIPHasher("test"), 30*24*time.Hour)
+	return svc, clicks
+}
+
+// Тесты
+
+func TestRecordClick(t *testing.T) {
+	svc, clicks := setupClickService()
+	ctx := context.Background()
+
+	err := svc.RecordClick(ctx, "abc123", "203.0.113.7", "https://www.reddit.com/r/golang", "Mozilla/5.0 (iPhone; CPU iPhone OS 17_0) Mobile/15E148")
+	assert.NoError(t, err)
+
+	assert.Len(t, clicks.clicks, 1)
+	c := clicks.clicks[0]
+	assert.Equal(t, "abc123", c.ShortID)
+	assert.Equal(t, "reddit.com", c.ReferrerHost)
+	assert.Equal(t, analytics.AgentMobile, c.AgentClass)
+	assert.Equal(t, "DE", c.Country)
+	assert.NotEmpty(t, c.IPHash)
+	assert.NotContains(t, c.IPHash, "203.0.113.7")
+}
+
+func TestGetAnalytics(t *testing.T) {
+	svc, clicks := setupClickService()
+	ctx := context.Background()
+	since := time.Date(2025, 3, 1, 10, 45, 0, 0, time.UTC)
+
+	a, err := svc.GetAnalytics(ctx, "abc123", since, BucketHour)
+	assert.NoError(t, err)
+	assert.Equal(t, BucketHour, a.Bucket)
+	assert.Equal(t, time.Hour, clicks.bucket)
+	assert.Equal(t, time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC), clicks.since)
+
+	_, err = svc.GetAnalytics(ctx, "abc123", since, "week")
+	assert.ErrorIs(t, err, ErrInvalidBucket)
+
+	_, err = svc.GetAnalytics(ctx, "missing", since, BucketDay)
+	assert.ErrorIs(t, err, ErrShortURLNotFound)
+}
+
+func TestDownsampleClicksAlignsToDay(t *testing.T) {
+	svc, clicks := setupClickService()
+
+	_, err := svc.DownsampleClicks(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, clicks.downsample, clicks.downsample.Truncate(24*time.Hour))
+	assert.True(t, clicks.downsample.Before(time.Now().Add(-29*24*time.Hour)))
+}
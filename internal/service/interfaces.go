@@ -2,16 +2,28 @@ package service
 
 import (
 	"context"
+	"time"
 
+	"github.com/GritsyukLeonid/pastebin-go/internal/events"
 	"github.com/GritsyukLeonid/pastebin-go/internal/model"
 )
 
 type PasteService interface {
 	CreatePaste(ctx context.Context, p model.Paste) (model.Paste, error)
 	GetPasteByID(ctx context.Context, id string) (model.Paste, error)
-	DeletePaste(ctx context.Context, id string) error
+	// UpdatePaste меняет заданные в upd поля пасты; deleteToken подтверждает владение.
+	UpdatePaste(ctx context.Context, id string, upd model.PasteUpdate, deleteToken string) (model.Paste, error)
+	// CreateAlias закрепляет за пастой пользовательский короткий код; deleteToken подтверждает владение.
+	CreateAlias(ctx context.Context, u model.ShortURL, deleteToken string) (model.ShortURL, error)
+	DeletePaste(ctx context.Context, id, deleteToken string) error
+	// ForceDeletePaste удаляет пасту без токена удаления — для администратора, в том числе
+	// пасты, созданные до появления токенов.
+	ForceDeletePaste(ctx context.Context, id string) error
 	ListPastes(ctx context.Context) ([]model.Paste, error)
 	GetPasteByHash(ctx context.Context, hash string) (model.Paste, error)
+	DeleteExpiredPastes(ctx context.Context) (int64, error)
+	// NotifyExpiringPastes предупреждает подписчиков о пастах, срок которых истекает в течение notice.
+	NotifyExpiringPastes(ctx context.Context, notice time.Duration) (int64, error)
 }
 
 type UserService interface {
@@ -19,13 +31,18 @@ type UserService interface {
 	GetUserByID(ctx context.Context, id string) (model.User, error)
 	DeleteUser(ctx context.Context, id string) error
 	ListUsers(ctx context.Context) ([]model.User, error)
+	GetUsage(ctx context.Context, id string) (model.Usage, error)
 }
 
 type ShortURLService interface {
 	CreateShortURL(ctx context.Context, u model.ShortURL) (model.ShortURL, error)
+	GenerateShortURL(ctx context.Context, u model.ShortURL) (model.ShortURL, error)
+	ShortenURL(ctx context.Context, u model.ShortURL) (model.ShortURL, error)
 	GetShortURLByID(ctx context.Context, id string) (model.ShortURL, error)
-	DeleteShortURL(ctx context.Context, id string) error
+	DeleteShortURL(ctx context.Context, id, deleteToken string) error
+	ForceDeleteShortURL(ctx context.Context, id string) error
 	ListShortURLs(ctx context.Context) ([]model.ShortURL, error)
+	GetShortURLForTarget(ctx context.Context, original string) (model.ShortURL, error)
 }
 
 type StatsService interface {
@@ -36,3 +53,31 @@ type StatsService interface {
 	IncrementViews(ctx context.Context, id string) error
 	ListTopStats(ctx context.Context, limit int) ([]model.Stats, error)
 }
+
+type ClickService interface {
+	// RecordClick сохраняет переход по ссылке; ip, referrer и userAgent берутся из запроса как есть.
+	RecordClick(ctx context.Context, shortID, ip, referrer, userAgent string) error
+	GetAnalytics(ctx context.Context, shortID string, since time.Time, bucket string) (model.ClickAnalytics, error)
+	// DownsampleClicks сворачивает сырые переходы старше срока хранения в дневные агрегаты.
+	DownsampleClicks(ctx context.Context) (int64, error)
+}
+
+type AuditService interface {
+	ListAudit(ctx context.Context, f model.AuditFilter) ([]model.AuditEntry, error)
+	// PruneAudit удаляет записи старше срока хранения и возвращает их число.
+	PruneAudit(ctx context.Context) (int64, error)
+}
+
+type WebhookService interface {
+	// CreateWebhook сохраняет подписку; без секрета он генерируется. Секрет возвращается только здесь.
+	CreateWebhook(ctx context.Context, w model.Webhook) (model.Webhook, error)
+	// GetWebhook, DeleteWebhook и ListDeliveries требуют секрет вебхука.
+	GetWebhook(ctx context.Context, id int64, secret string) (model.Webhook, error)
+	DeleteWebhook(ctx context.Context, id int64, secret string) error
+	// ListDeliveries возвращает доставки с попытками; status dead — недоставленные события.
+	ListDeliveries(ctx context.Context, id int64, secret, status string, limit int) ([]model.WebhookDelivery, error)
+	// HandleEvent ставит событие в очередь доставки подписанным вебхукам; подписчик шины событий.
+	HandleEvent(ctx context.Context, e events.Event) error
+	// DeliverDue отправляет доставки, время которых наступило, и возвращает их число.
+	DeliverDue(ctx context.Context) (int, error)
+}
@@ -7,29 +7,61 @@ import (
 	"fmt"
 	"time"
 
-	"github.com/GritsyukLeonid/pastebin-go/internal/logging"
+	"github.com/GritsyukLeonid/pastebin-go/internal/events"
 	"github.com/GritsyukLeonid/pastebin-go/internal/model"
 	"github.com/GritsyukLeonid/pastebin-go/internal/repository"
+	"github.com/GritsyukLeonid/pastebin-go/internal/reqctx"
+	"github.com/GritsyukLeonid/pastebin-go/internal/tracing"
+)
+
+var (
+	ErrInvalidDeleteToken = errors.New("invalid delete token")
+	ErrPasteNotFound      = errors.New("paste not found")
+	ErrInvalidExpiration  = errors.New("expiration must be in the future")
 )
 
 type pasteService struct {
 	storage      repository.StorageInterface
-	logger       logging.Logger
+	events       events.Publisher
 	statsService StatsService
 	shortService ShortURLService
+	quotas       QuotaConfig
 }
 
-func NewPasteService(storage repository.StorageInterface, logger logging.Logger, stats StatsService, short ShortURLService) PasteService {
+func NewPasteService(storage repository.StorageInterface, publisher events.Publisher, stats StatsService, short ShortURLService, quotas QuotaConfig) PasteService {
 	return &pasteService{
 		storage:      storage,
-		logger:       logger,
+		events:       publisher,
 		statsService: stats,
 		shortService: short,
+		quotas:       quotas,
 	}
 }
 
 func (s *pasteService) CreatePaste(ctx context.Context, p model.Paste) (model.Paste, error) {
+	ctx, span := tracing.Start(ctx, "PasteService.CreatePaste")
+	defer span.End()
+
 	now := time.Now()
+	if p.ExpiresAt.Before(now) {
+		return model.Paste{}, ErrInvalidExpiration
+	}
+
+	alias := p.ShortCode
+	if alias != "" {
+		if err := ValidateAlias(alias); err != nil {
+			return model.Paste{}, err
+		}
+	}
+
+	if p.UserID != 0 {
+		if err := checkOwner(ctx, s.storage, p.UserID); err != nil {
+			return model.Paste{}, err
+		}
+	} else {
+		p.ClientIP = reqctx.ClientIP(ctx)
+	}
+
 	p.ID = fmt.Sprintf("%d", now.UnixNano())
 	p.CreatedAt = now
 
@@ -37,27 +69,41 @@ func (s *pasteService) CreatePaste(ctx context.Context, p model.Paste) (model.Pa
 	hash.Write([]byte(p.Content + now.String()))
 	p.Hash = fmt.Sprintf("%x", hash.Sum(nil))[:10]
 
-	if err := s.storage.SavePaste(p); err != nil {
+	if _, err := p.IssueDeleteToken(); err != nil {
 		return model.Paste{}, err
 	}
 
-	if len(p.Hash) >= 6 {
-		_, _ = s.shortService.CreateShortURL(ctx, model.ShortURL{
-			ID:       p.Hash[:6],
-			Original: p.Hash,
-		})
+	quota := s.quotas.forOwner(p.UserID)
+	err := s.storage.SavePaste(ctx, p, dailyWindowStart(now), func(u model.Usage) error {
+		return checkQuota(quota, u, int64(len(p.Content)))
+	})
+	if err != nil {
+		return model.Paste{}, err
 	}
 
-	if p.ExpiresAt.Before(now) {
-		return model.Paste{}, errors.New("expiration must be in the future")
+	// Короткая ссылка обязана указывать на только что созданную пасту:
+	// если занять код не удалось, паста откатывается.
+	var short model.ShortURL
+	if alias != "" {
+		short, err = s.shortService.CreateShortURL(ctx, *model.NewShortURL(p.Hash, alias))
+	} else {
+		short, err = s.shortService.GenerateShortURL(ctx, *model.NewShortURL(p.Hash, ""))
 	}
+	if err != nil {
+		_ = s.storage.DeletePaste(ctx, p.ID)
+		return model.Paste{}, err
+	}
+	p.ShortCode = short.ID
 
-	_ = s.logger.LogChange("paste", p.ID, "created")
+	s.events.Publish(ctx, events.Event{Type: events.PasteCreated, EntityID: p.ID, Paste: &p, After: auditPaste(&p)})
 	return p, nil
 }
 
 func (s *pasteService) GetPasteByID(ctx context.Context, id string) (model.Paste, error) {
-	paste, err := s.storage.GetPasteByID(id)
+	ctx, span := tracing.Start(ctx, "PasteService.GetPasteByID")
+	defer span.End()
+
+	paste, err := s.storage.GetPasteByID(ctx, id)
 	if err != nil {
 		return model.Paste{}, err
 	}
@@ -65,7 +111,10 @@ func (s *pasteService) GetPasteByID(ctx context.Context, id string) (model.Paste
 }
 
 func (s *pasteService) GetPasteByHash(ctx context.Context, hash string) (model.Paste, error) {
-	paste, err := s.storage.GetPasteByHash(hash)
+	ctx, span := tracing.Start(ctx, "PasteService.GetPasteByHash")
+	defer span.End()
+
+	paste, err := s.storage.GetPasteByHash(ctx, hash)
 	if err != nil {
 		return model.Paste{}, err
 	}
@@ -74,14 +123,130 @@ func (s *pasteService) GetPasteByHash(ctx context.Context, hash string) (model.P
 	return *paste, nil
 }
 
-func (s *pasteService) DeletePaste(ctx context.Context, id string) error {
-	err := s.storage.DeletePaste(id)
+// CreateAlias закрепляет за пастой с hash u.Original пользовательский код u.ID.
+func (s *pasteService) CreateAlias(ctx context.Context, u model.ShortURL, deleteToken string) (model.ShortURL, error) {
+	ctx, span := tracing.Start(ctx, "PasteService.CreateAlias")
+	defer span.End()
+
+	if err := ValidateAlias(u.ID); err != nil {
+		return model.ShortURL{}, err
+	}
+
+	paste, err := s.storage.GetPasteByHash(ctx, u.Original)
+	if err != nil {
+		return model.ShortURL{}, fmt.Errorf("%w: %v", ErrPasteNotFound, err)
+	}
+	if !paste.CheckDeleteToken(deleteToken) {
+		return model.ShortURL{}, ErrInvalidDeleteToken
+	}
+
+	u.TargetType = model.TargetPaste
+	return s.shortService.CreateShortURL(ctx, u)
+}
+
+func (s *pasteService) UpdatePaste(ctx context.Context, id string, upd model.PasteUpdate, deleteToken string) (model.Paste, error) {
+	ctx, span := tracing.Start(ctx, "PasteService.UpdatePaste")
+	defer span.End()
+
+	paste, err := s.authorizePaste(ctx, id, deleteToken)
+	if err != nil {
+		return model.Paste{}, err
+	}
+
+	if upd.ExpiresAt != nil && !upd.ExpiresAt.After(time.Now()) {
+		return model.Paste{}, ErrInvalidExpiration
+	}
+	before := auditPaste(paste)
+	if upd.Content != nil {
+		paste.Content = *upd.Content
+	}
+	if upd.ExpiresAt != nil {
+		paste.ExpiresAt = *upd.ExpiresAt
+	}
+	quota := s.quotas.forOwner(paste.UserID)
+	err = s.storage.UpdatePaste(ctx, *paste, dailyWindowStart(time.Now()), func(u model.Usage) error {
+		return checkSize(quota, u, int64(len(paste.Content)))
+	})
+	if err != nil {
+		return model.Paste{}, err
+	}
+
+	s.events.Publish(ctx, events.Event{Type: events.PasteUpdated, EntityID: id, Paste: paste, Before: before, After: auditPaste(paste)})
+	return *paste, nil
+}
+
+func (s *pasteService) DeletePaste(ctx context.Context, id, deleteToken string) error {
+	ctx, span := tracing.Start(ctx, "PasteService.DeletePaste")
+	defer span.End()
+
+	paste, err := s.authorizePaste(ctx, id, deleteToken)
+	if err != nil {
+		return err
+	}
+	return s.deletePaste(ctx, paste)
+}
+
+func (s *pasteService) ForceDeletePaste(ctx context.Context, id string) error {
+	ctx, span := tracing.Start(ctx, "PasteService.ForceDeletePaste")
+	defer span.End()
+
+	paste, err := s.storage.GetPasteByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	return s.deletePaste(ctx, paste)
+}
+
+func (s *pasteService) deletePaste(ctx context.Context, paste *model.Paste) error {
+	err := s.storage.DeletePaste(ctx, paste.ID)
 	if err == nil {
-		_ = s.logger.LogChange("paste", id, "deleted")
+		s.events.Publish(ctx, events.Event{Type: events.PasteDeleted, EntityID: paste.ID, Paste: paste, Before: auditPaste(paste)})
 	}
 	return err
 }
 
+// authorizePaste проверяет, что deleteToken подтверждает владение пастой.
+// Любая операция изменения пасты должна проходить через эту проверку.
+func (s *pasteService) authorizePaste(ctx context.Context, id, deleteToken string) (*model.Paste, error) {
+	paste, err := s.storage.GetPasteByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !paste.CheckDeleteToken(deleteToken) {
+		return nil, ErrInvalidDeleteToken
+	}
+	return paste, nil
+}
+
+// DeleteExpiredPastes удаляет просроченные пасты и публикует paste.expired для каждой.
+func (s *pasteService) DeleteExpiredPastes(ctx context.Context) (int64, error) {
+	ctx, span := tracing.Start(ctx, "PasteService.DeleteExpiredPastes")
+	defer span.End()
+
+	expired, err := s.storage.DeleteExpiredPastes(ctx)
+	for i := range expired {
+		p := &expired[i]
+		s.events.Publish(ctx, events.Event{Type: events.PasteExpired, EntityID: p.ID, Paste: p, Before: auditPaste(p)})
+	}
+	return int64(len(expired)), err
+}
+
+// NotifyExpiringPastes публикует paste.expiring для паст, срок которых истекает в течение notice.
+func (s *pasteService) NotifyExpiringPastes(ctx context.Context, notice time.Duration) (int64, error) {
+	ctx, span := tracing.Start(ctx, "PasteService.NotifyExpiringPastes")
+	defer span.End()
+
+	expiring, err := s.storage.MarkExpiringPastes(ctx, time.Now().Add(notice))
+	for i := range expiring {
+		p := &expiring[i]
+		s.events.Publish(ctx, events.Event{Type: events.PasteExpiring, EntityID: p.ID, Paste: p})
+	}
+	return int64(len(expiring)), err
+}
+
 func (s *pasteService) ListPastes(ctx context.Context) ([]model.Paste, error) {
-	return s.storage.GetAllPastes()
+	ctx, span := tracing.Start(ctx, "PasteService.ListPastes")
+	defer span.End()
+
+	return s.storage.GetAllPastes(ctx)
 }
@@ -6,7 +6,10 @@ import (
 	"testing"
 	"time"
 
+	"github.com/GritsyukLeonid/pastebin-go/internal/events"
 	"github.com/GritsyukLeonid/pastebin-go/internal/model"
+	"github.com/GritsyukLeonid/pastebin-go/internal/repository"
+	"github.com/GritsyukLeonid/pastebin-go/internal/reqctx"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -16,48 +19,122 @@ type mockStorage struct {
 	getAllFunc    func() ([]model.Paste, error)
 	deleteFunc    func(string) error
 	getByHashFunc func(string) (*model.Paste, error)
+	updateFunc    func(model.Paste) error
+	usageFunc     func(int64, string, time.Time) (*model.Usage, error)
+	expiredFunc   func() ([]model.Paste, error)
+	expiringFunc  func(time.Time) ([]model.Paste, error)
+	getUserFunc   func(string) (*model.User, error)
 }
 
-func (m *mockStorage) SavePaste(p model.Paste) error                { return m.saveFunc(p) }
-func (m *mockStorage) GetPasteByID(id string) (*model.Paste, error) { return m.getByIDFunc(id) }
-func (m *mockStorage) GetAllPastes() ([]model.Paste, error)         { return m.getAllFunc() }
-func (m *mockStorage) DeletePaste(id string) error                  { return m.deleteFunc(id) }
-func (m *mockStorage) GetPasteByHash(hash string) (*model.Paste, error) {
+func (m *mockStorage) SavePaste(ctx context.Context, p model.Paste, since time.Time, check repository.QuotaCheck) error {
+	if err := m.checkQuota(ctx, p, since, check); err != nil {
+		return err
+	}
+	return m.saveFunc(p)
+}
+func (m *mockStorage) GetPasteByID(_ context.Context, id string) (*model.Paste, error) {
+	return m.getByIDFunc(id)
+}
+func (m *mockStorage) GetAllPastes(_ context.Context) ([]model.Paste, error) { return m.getAllFunc() }
+func (m *mockStorage) DeletePaste(_ context.Context, id string) error        { return m.deleteFunc(id) }
+func (m *mockStorage) GetPasteByHash(_ context.Context, hash string) (*model.Paste, error) {
 	return m.getByHashFunc(hash)
 }
+func (m *mockStorage) UpdatePaste(ctx context.Context, p model.Paste, since time.Time, check repository.QuotaCheck) error {
+	if err := m.checkQuota(ctx, p, since, check); err != nil {
+		return err
+	}
+	return m.updateFunc(p)
+}
 
-func (m *mockStorage) SaveStats(model.Stats) error                     { return nil }
-func (m *mockStorage) GetStatsByID(string) (*model.Stats, error)       { return nil, nil }
-func (m *mockStorage) DeleteStats(string) error                        { return nil }
-func (m *mockStorage) GetAllStats() ([]model.Stats, error)             { return nil, nil }
-func (m *mockStorage) SaveUser(model.User) error                       { return nil }
-func (m *mockStorage) GetUserByID(string) (*model.User, error)         { return nil, nil }
-func (m *mockStorage) DeleteUser(string) error                         { return nil }
-func (m *mockStorage) GetAllUsers() ([]model.User, error)              { return nil, nil }
-func (m *mockStorage) SaveShortURL(model.ShortURL) error               { return nil }
-func (m *mockStorage) GetShortURLByID(string) (*model.ShortURL, error) { return &model.ShortURL{}, nil }
-func (m *mockStorage) DeleteShortURL(string) error                     { return nil }
-func (m *mockStorage) GetAllShortURLs() ([]model.ShortURL, error)      { return nil, nil }
-func (m *mockStorage) IncrementStatsViews(id string) error             { return nil }
+func (m *mockStorage) checkQuota(ctx context.Context, p model.Paste, since time.Time, check repository.QuotaCheck) error {
+	u, err := m.GetPasteUsage(ctx, p.UserID, p.ClientIP, since)
+	if err != nil {
+		return err
+	}
+	return check(*u)
+}
+func (m *mockStorage) GetPasteUsage(_ context.Context, userID int64, clientIP string, since time.Time) (*model.Usage, error) {
+	if m.usageFunc == nil {
+		return &model.Usage{}, nil
+	}
+	return m.usageFunc(userID, clientIP, since)
+}
+
+func (m *mockStorage) DeleteExpiredPastes(_ context.Context) ([]model.Paste, error) {
+	if m.expiredFunc == nil {
+		return nil, nil
+	}
+	return m.expiredFunc()
+}
+
+func (m *mockStorage) MarkExpiringPastes(_ context.Context, before time.Time) ([]model.Paste, error) {
+	if m.expiringFunc == nil {
+		return nil, nil
+	}
+	return m.expiringFunc(before)
+}
+
+func (m *mockStorage) SaveStats(context.Context, model.Stats) error               { return nil }
+func (m *mockStorage) GetStatsByID(context.Context, string) (*model.Stats, error) { return nil, nil }
+func (m *mockStorage) DeleteStats(context.Context, string) error                  { return nil }
+func (m *mockStorage) GetAllStats(_ context.Context) ([]model.Stats, error)       { return nil, nil }
+func (m *mockStorage) SaveUser(context.Context, model.User) error                 { return nil }
+func (m *mockStorage) GetUserByID(_ context.Context, id string) (*model.User, error) {
+	if m.getUserFunc == nil {
+		return &model.User{}, nil
+	}
+	return m.getUserFunc(id)
+}
+func (m *mockStorage) DeleteUser(context.Context, string) error            { return nil }
+func (m *mockStorage) GetAllUsers(_ context.Context) ([]model.User, error) { return nil, nil }
+func (m *mockStorage) SaveShortURL(context.Context, model.ShortURL) error  { return nil }
+func (m *mockStorage) GetShortURLByID(context.Context, string) (*model.ShortURL, error) {
+	return &model.ShortURL{}, nil
+}
+func (m *mockStorage) DeleteShortURL(context.Context, string) error                { return nil }
+func (m *mockStorage) GetAllShortURLs(_ context.Context) ([]model.ShortURL, error) { return nil, nil }
+func (m *mockStorage) GetShortURLsByOriginal(context.Context, string) ([]model.ShortURL, error) {
+	return nil, nil
+}
+func (m *mockStorage) IncrementStatsViews(_ context.Context, id string) error { return nil }
 
 type mockShortURLService struct{}
 
 func (m *mockShortURLService) CreateShortURL(ctx context.Context, u model.ShortURL) (model.ShortURL, error) {
 	return u, nil
 }
+func (m *mockShortURLService) GenerateShortURL(ctx context.Context, u model.ShortURL) (model.ShortURL, error) {
+	u.ID = "gen123"
+	return u, nil
+}
+func (m *mockShortURLService) ShortenURL(ctx context.Context, u model.ShortURL) (model.ShortURL, error) {
+	u.TargetType = model.TargetURL
+	return u, nil
+}
 func (m *mockShortURLService) GetShortURLByID(ctx context.Context, id string) (model.ShortURL, error) {
 	return model.ShortURL{}, nil
 }
-func (m *mockShortURLService) DeleteShortURL(ctx context.Context, id string) error {
+func (m *mockShortURLService) GetShortURLForTarget(ctx context.Context, original string) (model.ShortURL, error) {
+	return model.ShortURL{}, ErrShortURLNotFound
+}
+func (m *mockShortURLService) DeleteShortURL(ctx context.Context, id, deleteToken string) error {
+	return nil
+}
+func (m *mockShortURLService) ForceDeleteShortURL(ctx context.Context, id string) error {
 	return nil
 }
 func (m *mockShortURLService) ListShortURLs(ctx context.Context) ([]model.ShortURL, error) {
 	return nil, nil
 }
 
-type mockLogger struct{}
+type mockPublisher struct {
+	published []events.Event
+}
 
-func (m *mockLogger) LogChange(entity, id, action string) error { return nil }
+func (m *mockPublisher) Publish(_ context.Context, e events.Event) {
+	m.published = append(m.published, e)
+}
 
 type mockStatsService struct{}
 
@@ -90,11 +167,11 @@ func TestCreatePaste(t *testing.T) {
 			return nil
 		},
 	}
-	mockLogger := &mockLogger{}
+	publisher := &mockPublisher{}
 	mockStats := &mockStatsService{}
 	mockShort := &mockShortURLService{}
 
-	svc := NewPasteService(mockStorage, mockLogger, mockStats, mockShort)
+	svc := NewPasteService(mockStorage, publisher, mockStats, mockShort, QuotaConfig{})
 
 	ctx := context.Background()
 	paste := model.Paste{Content: "test content", ExpiresAt: time.Now().Add(1 * time.Hour)}
@@ -103,6 +180,121 @@ func TestCreatePaste(t *testing.T) {
 	assert.NotEmpty(t, created.ID)
 	assert.NotEmpty(t, created.CreatedAt)
 	assert.NotEmpty(t, created.Hash)
+	assert.NotEmpty(t, created.DeleteToken)
+	assert.True(t, created.CheckDeleteToken(created.DeleteToken))
+}
+
+func TestPasteEventsCarrySummaries(t *testing.T) {
+	stored := &model.Paste{}
+	mockStorage := &mockStorage{
+		saveFunc:    func(p model.Paste) error { *stored = p; return nil },
+		getByIDFunc: func(string) (*model.Paste, error) { p := *stored; return &p, nil },
+		updateFunc:  func(model.Paste) error { return nil },
+		deleteFunc:  func(string) error { return nil },
+		expiredFunc: func() ([]model.Paste, error) {
+			return []model.Paste{{ID: "old1", Hash: "h1"}, {ID: "old2", Hash: "h2"}}, nil
+		},
+	}
+	publisher := &mockPublisher{}
+	svc := NewPasteService(mockStorage, publisher, &mockStatsService{}, &mockShortURLService{}, QuotaConfig{})
+	ctx := context.Background()
+
+	created, err := svc.CreatePaste(ctx, model.Paste{Content: "abc", ExpiresAt: time.Now().Add(time.Hour)})
+	assert.NoError(t, err)
+	content := "abcdef"
+	_, err = svc.UpdatePaste(ctx, created.ID, model.PasteUpdate{Content: &content}, created.DeleteToken)
+	assert.NoError(t, err)
+	assert.NoError(t, svc.DeletePaste(ctx, created.ID, created.DeleteToken))
+	n, err := svc.DeleteExpiredPastes(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), n)
+
+	var types []events.Type
+	for _, e := range publisher.published {
+		types = append(types, e.Type)
+	}
+	assert.Equal(t, []events.Type{events.PasteCreated, events.PasteUpdated, events.PasteDeleted, events.PasteExpired, events.PasteExpired}, types)
+
+	updated := publisher.published[1]
+	assert.Equal(t, created.ID, updated.EntityID)
+	assert.Equal(t, 3, updated.Before.(pasteAudit).Size)
+	assert.Equal(t, 6, updated.After.(pasteAudit).Size)
+	assert.Nil(t, publisher.published[2].After)
+	assert.Equal(t, "old2", publisher.published[4].Paste.ID)
+}
+
+func TestUpdatePasteChangesOnlyGivenFields(t *testing.T) {
+	stored := &model.Paste{ID: "123", Content: "test", ExpiresAt: time.Now().Add(time.Hour)}
+	token, err := stored.IssueDeleteToken()
+	assert.NoError(t, err)
+	var saved model.Paste
+	mockStorage := &mockStorage{
+		getByIDFunc: func(string) (*model.Paste, error) { p := *stored; return &p, nil },
+		updateFunc:  func(p model.Paste) error { saved = p; return nil },
+	}
+	svc := NewPasteService(mockStorage, &mockPublisher{}, &mockStatsService{}, &mockShortURLService{}, QuotaConfig{})
+	ctx := context.Background()
+
+	expires := time.Now().Add(48 * time.Hour)
+	updated, err := svc.UpdatePaste(ctx, "123", model.PasteUpdate{ExpiresAt: &expires}, token)
+	assert.NoError(t, err)
+	assert.Equal(t, "test", updated.Content)
+	assert.Equal(t, expires, saved.ExpiresAt)
+
+	past := time.Now().Add(-time.Minute)
+	_, err = svc.UpdatePaste(ctx, "123", model.PasteUpdate{ExpiresAt: &past}, token)
+	assert.ErrorIs(t, err, ErrInvalidExpiration)
+}
+
+func TestDeletePasteRequiresToken(t *testing.T) {
+	stored := &model.Paste{ID: "123", Content: "test"}
+	token, err := stored.IssueDeleteToken()
+	assert.NoError(t, err)
+
+	deleted := false
+	mockStorage := &mockStorage{
+		getByIDFunc: func(id string) (*model.Paste, error) {
+			if id == stored.ID {
+				return stored, nil
+			}
+			return nil, errors.New("not found")
+		},
+		deleteFunc: func(id string) error {
+			deleted = true
+			return nil
+		},
+	}
+
+	svc := NewPasteService(mockStorage, &mockPublisher{}, &mockStatsService{}, &mockShortURLService{}, QuotaConfig{})
+	ctx := context.Background()
+
+	err = svc.DeletePaste(ctx, "123", "wrong-token")
+	assert.ErrorIs(t, err, ErrInvalidDeleteToken)
+	assert.False(t, deleted)
+
+	err = svc.DeletePaste(ctx, "123", "")
+	assert.ErrorIs(t, err, ErrInvalidDeleteToken)
+	assert.False(t, deleted)
+
+	err = svc.DeletePaste(ctx, "123", token)
+	assert.NoError(t, err)
+	assert.True(t, deleted)
+}
+
+func TestForceDeletePasteWithoutToken(t *testing.T) {
+	legacy := &model.Paste{ID: "123", Hash: "abc"}
+	deleted := ""
+	mockStorage := &mockStorage{
+		getByIDFunc: func(string) (*model.Paste, error) { return legacy, nil },
+		deleteFunc:  func(id string) error { deleted = id; return nil },
+	}
+	publisher := &mockPublisher{}
+	svc := NewPasteService(mockStorage, publisher, &mockStatsService{}, &mockShortURLService{}, QuotaConfig{})
+
+	assert.ErrorIs(t, svc.DeletePaste(context.Background(), "123", ""), ErrInvalidDeleteToken, "у пасты без хэша токена нет подходящего токена")
+	assert.NoError(t, svc.ForceDeletePaste(context.Background(), "123"))
+	assert.Equal(t, "123", deleted)
+	assert.Equal(t, events.PasteDeleted, publisher.published[0].Type)
 }
 
 func TestGetPasteByHash(t *testing.T) {
@@ -116,14 +308,220 @@ func TestGetPasteByHash(t *testing.T) {
 			return nil, errors.New("not found")
 		},
 	}
-	mockLogger := &mockLogger{}
+	publisher := &mockPublisher{}
 	mockStats := &mockStatsService{}
 	mockShort := &mockShortURLService{}
 
-	svc := NewPasteService(mockStorage, mockLogger, mockStats, mockShort)
+	svc := NewPasteService(mockStorage, publisher, mockStats, mockShort, QuotaConfig{})
 
 	ctx := context.Background()
 	res, err := svc.GetPasteByHash(ctx, "abc")
 	assert.NoError(t, err)
 	assert.Equal(t, expected.ID, res.ID)
 }
+
+func TestCreatePasteQuota(t *testing.T) {
+	quotas := QuotaConfig{
+		User:      model.Quota{MaxPasteBytes: 100, MaxStoredBytes: 1000, MaxLivePastes: 10, MaxPastesPerDay: 5},
+		Anonymous: model.Quota{MaxPasteBytes: 10, MaxStoredBytes: 100, MaxLivePastes: 2, MaxPastesPerDay: 1},
+	}
+
+	tests := []struct {
+		name    string
+		paste   model.Paste
+		usage   model.Usage
+		wantErr error
+	}{
+		{"anonymous within limits", model.Paste{Content: "short"}, model.Usage{}, nil},
+		{"anonymous too large", model.Paste{Content: "this is too long"}, model.Usage{}, ErrPasteTooLarge},
+		{"anonymous storage full", model.Paste{Content: "short"}, model.Usage{StoredBytes: 98}, ErrStorageQuotaExceeded},
+		{"anonymous live limit", model.Paste{Content: "short"}, model.Usage{LivePastes: 2}, ErrLivePastesExceeded},
+		{"anonymous daily limit", model.Paste{Content: "short"}, model.Usage{PastesToday: 1}, ErrDailyPastesExceeded},
+		{"user larger limit", model.Paste{Content: "this is too long", UserID: 7}, model.Usage{PastesToday: 4}, nil},
+		{"user daily limit", model.Paste{Content: "short", UserID: 7}, model.Usage{PastesToday: 5}, ErrDailyPastesExceeded},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotUser int64
+			var gotIP string
+			saved := false
+			mockStorage := &mockStorage{
+				saveFunc: func(model.Paste) error { saved = true; return nil },
+				usageFunc: func(userID int64, clientIP string, since time.Time) (*model.Usage, error) {
+					gotUser, gotIP = userID, clientIP
+					u := tt.usage
+					return &u, nil
+				},
+			}
+			svc := NewPasteService(mockStorage, &mockPublisher{}, &mockStatsService{}, &mockShortURLService{}, quotas)
+
+			ctx := reqctx.WithClientIP(reqctx.WithUser(context.Background(), "service:billing"), "203.0.113.7")
+			tt.paste.ExpiresAt = time.Now().Add(time.Hour)
+			_, err := svc.CreatePaste(ctx, tt.paste)
+
+			assert.Equal(t, tt.paste.UserID, gotUser)
+			if tt.paste.UserID == 0 {
+				assert.Equal(t, "203.0.113.7", gotIP)
+			}
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+				assert.True(t, saved)
+				return
+			}
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.True(t, IsQuotaError(err))
+			assert.False(t, saved)
+		})
+	}
+}
+
+func TestCreatePasteOwnerRequiresAuthenticatedCaller(t *testing.T) {
+	saved := false
+	mockStorage := &mockStorage{
+		saveFunc: func(model.Paste) error { saved = true; return nil },
+		getUserFunc: func(id string) (*model.User, error) {
+			if id == "7" {
+				return &model.User{ID: 7}, nil
+			}
+			return nil, errors.New("no rows")
+		},
+	}
+	svc := NewPasteService(mockStorage, &mockPublisher{}, &mockStatsService{}, &mockShortURLService{}, QuotaConfig{})
+	paste := model.Paste{Content: "hello", UserID: 7, ExpiresAt: time.Now().Add(time.Hour)}
+
+	_, err := svc.CreatePaste(reqctx.WithUser(context.Background(), ""), paste)
+	assert.ErrorIs(t, err, ErrOwnerUnauthenticated)
+
+	authenticated := reqctx.WithUser(context.Background(), reqctx.APIKeyID("secret"))
+	paste.UserID = 8
+	_, err = svc.CreatePaste(authenticated, paste)
+	assert.ErrorIs(t, err, ErrUserNotFound)
+	assert.False(t, saved)
+
+	paste.UserID = 7
+	_, err = svc.CreatePaste(authenticated, paste)
+	assert.NoError(t, err)
+	assert.True(t, saved)
+}
+
+func TestUpdatePasteChecksStorageQuota(t *testing.T) {
+	stored := &model.Paste{ID: "123", Content: "0123456789", ClientIP: "203.0.113.7", ExpiresAt: time.Now().Add(time.Hour)}
+	token, err := stored.IssueDeleteToken()
+	assert.NoError(t, err)
+	updated := false
+	mockStorage := &mockStorage{
+		getByIDFunc: func(string) (*model.Paste, error) { p := *stored; return &p, nil },
+		updateFunc:  func(model.Paste) error { updated = true; return nil },
+		usageFunc: func(userID int64, clientIP string, _ time.Time) (*model.Usage, error) {
+			assert.Equal(t, "203.0.113.7", clientIP)
+			// Прочие пасты владельца, без обновляемой; число паст на изменение не влияет.
+			return &model.Usage{StoredBytes: 90, LivePastes: 2, PastesToday: 1}, nil
+		},
+	}
+	quotas := QuotaConfig{Anonymous: model.Quota{MaxPasteBytes: 50, MaxStoredBytes: 100, MaxLivePastes: 2, MaxPastesPerDay: 1}}
+	svc := NewPasteService(mockStorage, &mockPublisher{}, &mockStatsService{}, &mockShortURLService{}, quotas)
+	ctx := context.Background()
+
+	content := "0123456789"
+	_, err = svc.UpdatePaste(ctx, "123", model.PasteUpdate{Content: &content}, token)
+	assert.NoError(t, err, "размер не растёт")
+
+	assert.True(t, updated)
+
+	updated = false
+	content = "0123456789a"
+	_, err = svc.UpdatePaste(ctx, "123", model.PasteUpdate{Content: &content}, token)
+	assert.ErrorIs(t, err, ErrStorageQuotaExceeded)
+	assert.False(t, updated)
+}
+
+func TestCreateAliasRequiresOwner(t *testing.T) {
+	stored := &model.Paste{ID: "123", Hash: "abcdef1234"}
+	token, _ := stored.IssueDeleteToken()
+
+	mockStorage := &mockStorage{
+		getByHashFunc: func(h string) (*model.Paste, error) {
+			if h == stored.Hash {
+				return stored, nil
+			}
+			return nil, errors.New("not found")
+		},
+	}
+	svc := NewPasteService(mockStorage, &mockPublisher{}, &mockStatsService{}, &mockShortURLService{}, QuotaConfig{})
+	ctx := context.Background()
+
+	_, err := svc.CreateAlias(ctx, *model.NewShortURL(stored.Hash, "release-notes"), "wrong")
+	assert.ErrorIs(t, err, ErrInvalidDeleteToken)
+
+	_, err = svc.CreateAlias(ctx, *model.NewShortURL(stored.Hash, "api"), token)
+	assert.ErrorIs(t, err, ErrInvalidAlias)
+
+	_, err = svc.CreateAlias(ctx, *model.NewShortURL("missing", "release-notes"), token)
+	assert.ErrorIs(t, err, ErrPasteNotFound)
+
+	short, err := svc.CreateAlias(ctx, *model.NewShortURL(stored.Hash, "release-notes"), token)
+	assert.NoError(t, err)
+	assert.Equal(t, "release-notes", short.ID)
+	assert.Equal(t, stored.Hash, short.Original)
+}
+
+func TestCreatePasteWithAlias(t *testing.T) {
+	mockStorage := &mockStorage{saveFunc: func(model.Paste) error { return nil }}
+	svc := NewPasteService(mockStorage, &mockPublisher{}, &mockStatsService{}, &mockShortURLService{}, QuotaConfig{})
+	ctx := context.Background()
+
+	created, err := svc.CreatePaste(ctx, model.Paste{Content: "x", ExpiresAt: time.Now().Add(time.Hour), ShortCode: "my-paste"})
+	assert.NoError(t, err)
+	assert.Equal(t, "my-paste", created.ShortCode)
+
+	_, err = svc.CreatePaste(ctx, model.Paste{Content: "x", ExpiresAt: time.Now().Add(time.Hour), ShortCode: "s"})
+	assert.ErrorIs(t, err, ErrInvalidAlias)
+}
+
+type failingShortURLService struct{ mockShortURLService }
+
+func (m *failingShortURLService) GenerateShortURL(ctx context.Context, u model.ShortURL) (model.ShortURL, error) {
+	return model.ShortURL{}, errors.New("short code space exhausted")
+}
+
+func TestCreatePasteRollsBackWithoutShortURL(t *testing.T) {
+	var deletedID string
+	mockStorage := &mockStorage{
+		saveFunc:   func(model.Paste) error { return nil },
+		deleteFunc: func(id string) error { deletedID = id; return nil },
+	}
+	ctx := context.Background()
+	paste := model.Paste{Content: "x", ExpiresAt: time.Now().Add(time.Hour)}
+
+	svc := NewPasteService(mockStorage, &mockPublisher{}, &mockStatsService{}, &mockShortURLService{}, QuotaConfig{})
+	created, err := svc.CreatePaste(ctx, paste)
+	assert.NoError(t, err)
+	assert.Equal(t, "gen123", created.ShortCode)
+	assert.Empty(t, deletedID)
+
+	svc = NewPasteService(mockStorage, &mockPublisher{}, &mockStatsService{}, &failingShortURLService{}, QuotaConfig{})
+	_, err = svc.CreatePaste(ctx, paste)
+	assert.Error(t, err)
+	assert.NotEmpty(t, deletedID)
+}
+
+func TestNotifyExpiringPastesPublishesOncePerPaste(t *testing.T) {
+	var before time.Time
+	mockStorage := &mockStorage{
+		expiringFunc: func(b time.Time) ([]model.Paste, error) {
+			before = b
+			return []model.Paste{{ID: "p1", Hash: "h1", UserID: 7}}, nil
+		},
+	}
+	publisher := &mockPublisher{}
+	svc := NewPasteService(mockStorage, publisher, &mockStatsService{}, &mockShortURLService{}, QuotaConfig{})
+
+	n, err := svc.NotifyExpiringPastes(context.Background(), time.Hour)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), before, time.Minute)
+	assert.Len(t, publisher.published, 1)
+	assert.Equal(t, events.PasteExpiring, publisher.published[0].Type)
+	assert.Equal(t, int64(7), publisher.published[0].Paste.UserID)
+}
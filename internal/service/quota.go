@@ -0,0 +1,125 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/GritsyukLeonid/pastebin-go/internal/config"
+	"github.com/GritsyukLeonid/pastebin-go/internal/model"
+	"github.com/GritsyukLeonid/pastebin-go/internal/repository"
+	"github.com/GritsyukLeonid/pastebin-go/internal/reqctx"
+)
+
+var (
+	ErrPasteTooLarge        = errors.New("paste exceeds maximum size")
+	ErrStorageQuotaExceeded = errors.New("storage quota exceeded")
+	ErrLivePastesExceeded   = errors.New("live paste limit exceeded")
+	ErrDailyPastesExceeded  = errors.New("daily paste limit exceeded")
+	// ErrOwnerUnauthenticated — user_id прислал клиент, которого не определила аутентификация:
+	// иначе любой мог бы расходовать квоту чужого пользователя или подписаться на его события.
+	ErrOwnerUnauthenticated = errors.New("user_id requires an authenticated caller")
+	ErrUserNotFound         = errors.New("user not found")
+)
+
+// QuotaConfig задаёт лимиты для зарегистрированных пользователей и для анонимных клиентов (по IP).
+// IP анонимного клиента берётся из reqctx.ClientIP: за доверенным прокси это адрес из X-Forwarded-For,
+// иначе все клиенты за прокси делили бы одну квоту.
+type QuotaConfig struct {
+	User      model.Quota
+	Anonymous model.Quota
+}
+
+func DefaultQuotaConfig() QuotaConfig {
+	return QuotaConfig{
+		User: model.Quota{
+			MaxPasteBytes:   1 << 20,
+			MaxStoredBytes:  100 << 20,
+			MaxLivePastes:   1000,
+			MaxPastesPerDay: 500,
+		},
+		Anonymous: model.Quota{
+			MaxPasteBytes:   512 << 10,
+			MaxStoredBytes:  10 << 20,
+			MaxLivePastes:   100,
+			MaxPastesPerDay: 50,
+		},
+	}
+}
+
+func QuotaConfigFrom(c config.QuotaConfig) QuotaConfig {
+	return QuotaConfig{User: quotaFrom(c.User), Anonymous: quotaFrom(c.Anonymous)}
+}
+
+func quotaFrom(q config.QuotaLimits) model.Quota {
+	return model.Quota{
+		MaxPasteBytes:   q.MaxPasteBytes,
+		MaxStoredBytes:  q.MaxStoredBytes,
+		MaxLivePastes:   q.MaxLivePastes,
+		MaxPastesPerDay: q.MaxPastesPerDay,
+	}
+}
+
+// dailyWindow — окно лимита MaxPastesPerDay и счётчика Usage.PastesToday: скользящие 24 часа,
+// а не календарные сутки, поэтому лимит не обнуляется разом в полночь UTC.
+const dailyWindow = 24 * time.Hour
+
+// dailyWindowStart возвращает начало окна dailyWindow, которое заканчивается в now.
+func dailyWindowStart(now time.Time) time.Time {
+	return now.Add(-dailyWindow)
+}
+
+func (c QuotaConfig) forOwner(userID int64) model.Quota {
+	if userID != 0 {
+		return c.User
+	}
+	return c.Anonymous
+}
+
+// checkOwner допускает пасту или вебхук от имени пользователя userID только от аутентифицированного
+// клиента (сервис или API-ключ) и только для существующего пользователя.
+func checkOwner(ctx context.Context, storage repository.StorageInterface, userID int64) error {
+	if reqctx.User(ctx) == "" {
+		return ErrOwnerUnauthenticated
+	}
+	if _, err := storage.GetUserByID(ctx, strconv.FormatInt(userID, 10)); err != nil {
+		return fmt.Errorf("%w: %v", ErrUserNotFound, err)
+	}
+	return nil
+}
+
+// IsQuotaError сообщает, вызвана ли ошибка превышением одного из лимитов.
+func IsQuotaError(err error) bool {
+	return errors.Is(err, ErrPasteTooLarge) ||
+		errors.Is(err, ErrStorageQuotaExceeded) ||
+		errors.Is(err, ErrLivePastesExceeded) ||
+		errors.Is(err, ErrDailyPastesExceeded)
+}
+
+// checkQuota проверяет новую пасту размера size при использовании u (без неё).
+func checkQuota(q model.Quota, u model.Usage, size int64) error {
+	if err := checkSize(q, u, size); err != nil {
+		return err
+	}
+	if q.MaxLivePastes > 0 && u.LivePastes >= q.MaxLivePastes {
+		return fmt.Errorf("%w: %d of %d pastes", ErrLivePastesExceeded, u.LivePastes, q.MaxLivePastes)
+	}
+	if q.MaxPastesPerDay > 0 && u.PastesToday >= q.MaxPastesPerDay {
+		return fmt.Errorf("%w: %d of %d pastes in the last 24h", ErrDailyPastesExceeded, u.PastesToday, q.MaxPastesPerDay)
+	}
+	return nil
+}
+
+// checkSize проверяет только размер: при изменении пасты число паст владельца не меняется,
+// а u не включает её прежнее содержимое.
+func checkSize(q model.Quota, u model.Usage, size int64) error {
+	if q.MaxPasteBytes > 0 && size > q.MaxPasteBytes {
+		return fmt.Errorf("%w: %d bytes, limit %d", ErrPasteTooLarge, size, q.MaxPasteBytes)
+	}
+	if q.MaxStoredBytes > 0 && u.StoredBytes+size > q.MaxStoredBytes {
+		return fmt.Errorf("%w: %d of %d bytes used", ErrStorageQuotaExceeded, u.StoredBytes, q.MaxStoredBytes)
+	}
+	return nil
+}
@@ -3,52 +3,204 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strings"
+	"time"
 
-	"github.com/GritsyukLeonid/pastebin-go/internal/logging"
+	"github.com/GritsyukLeonid/pastebin-go/internal/events"
 	"github.com/GritsyukLeonid/pastebin-go/internal/model"
 	"github.com/GritsyukLeonid/pastebin-go/internal/repository"
+	"github.com/GritsyukLeonid/pastebin-go/internal/shortcode"
+	"github.com/GritsyukLeonid/pastebin-go/internal/tracing"
+)
+
+var (
+	ErrShortURLGone     = errors.New("short url is gone")
+	ErrShortURLNotFound = errors.New("short url not found")
 )
 
 type shortURLService struct {
-	storage repository.StorageInterface
-	logger  logging.Logger
+	storage   repository.StorageInterface
+	events    events.Publisher
+	generator *shortcode.Generator
 }
 
-func NewShortURLService(storage repository.StorageInterface, logger logging.Logger) ShortURLService {
-	return &shortURLService{storage: storage, logger: logger}
+func NewShortURLService(storage repository.StorageInterface, publisher events.Publisher, generator *shortcode.Generator) ShortURLService {
+	return &shortURLService{storage: storage, events: publisher, generator: generator}
 }
 
 func (s *shortURLService) CreateShortURL(ctx context.Context, u model.ShortURL) (model.ShortURL, error) {
-	existing, err := s.storage.GetShortURLByID(u.ID)
+	ctx, span := tracing.Start(ctx, "ShortURLService.CreateShortURL")
+	defer span.End()
+
+	if u.TargetType == "" {
+		u.TargetType = model.TargetPaste
+	}
+	if u.IsURL() {
+		if err := ValidateTargetURL(u.Original); err != nil {
+			return model.ShortURL{}, err
+		}
+	}
+	if u.ExpiresAt != nil && !u.ExpiresAt.After(time.Now()) {
+		return model.ShortURL{}, ErrInvalidExpiration
+	}
+	u.DeletedAt = nil
+
+	existing, err := s.storage.GetShortURLByID(ctx, u.ID)
 	if err == nil && existing != nil {
-		return model.ShortURL{}, errors.New("такой короткий код уже существует")
+		return model.ShortURL{}, ErrShortCodeTaken
 	}
 
 	// Сохраняем
-	err = s.storage.SaveShortURL(u)
+	err = s.storage.SaveShortURL(ctx, u)
+	if errors.Is(err, repository.ErrAlreadyExists) {
+		return model.ShortURL{}, ErrShortCodeTaken
+	}
 	if err != nil {
 		return model.ShortURL{}, err
 	}
 
+	s.events.Publish(ctx, events.Event{Type: events.ShortURLCreated, EntityID: u.ID, ShortURL: &u, After: auditShortURL(&u)})
 	return u, nil
 }
 
-func (s *shortURLService) GetShortURLByID(ctx context.Context, id string) (model.ShortURL, error) {
-	url, err := s.storage.GetShortURLByID(id)
+// ShortenURL сокращает внешний адрес u.Original. Пустой u.ID означает сгенерированный код.
+// Ссылка получает собственный токен удаления (u.DeleteToken в ответе).
+func (s *shortURLService) ShortenURL(ctx context.Context, u model.ShortURL) (model.ShortURL, error) {
+	ctx, span := tracing.Start(ctx, "ShortURLService.ShortenURL")
+	defer span.End()
+
+	u.TargetType = model.TargetURL
+	if err := ValidateTargetURL(u.Original); err != nil {
+		return model.ShortURL{}, err
+	}
+	if _, err := u.IssueDeleteToken(); err != nil {
+		return model.ShortURL{}, err
+	}
+	if u.ID == "" {
+		return s.GenerateShortURL(ctx, u)
+	}
+	if err := ValidateAlias(u.ID); err != nil {
+		return model.ShortURL{}, err
+	}
+	return s.CreateShortURL(ctx, u)
+}
+
+// GenerateShortURL сохраняет u под свободным сгенерированным кодом (u.ID игнорируется).
+func (s *shortURLService) GenerateShortURL(ctx context.Context, u model.ShortURL) (model.ShortURL, error) {
+	ctx, span := tracing.Start(ctx, "ShortURLService.GenerateShortURL")
+	defer span.End()
+
+	var created model.ShortURL
+	_, err := s.generator.Generate(ctx, func(code string) error {
+		if _, reserved := reservedAliases[strings.ToLower(code)]; reserved {
+			return shortcode.ErrConflict
+		}
+		u.ID = code
+		saved, err := s.CreateShortURL(ctx, u)
+		if errors.Is(err, ErrShortCodeTaken) {
+			return shortcode.ErrConflict
+		}
+		created = saved
+		return err
+	})
 	if err != nil {
 		return model.ShortURL{}, err
 	}
+	return created, nil
+}
+
+// GetShortURLByID возвращает ErrShortURLGone для «надгробий» и истёкших ссылок.
+func (s *shortURLService) GetShortURLByID(ctx context.Context, id string) (model.ShortURL, error) {
+	ctx, span := tracing.Start(ctx, "ShortURLService.GetShortURLByID")
+	defer span.End()
+
+	url, err := s.storage.GetShortURLByID(ctx, id)
+	if err != nil {
+		return model.ShortURL{}, fmt.Errorf("%w: %v", ErrShortURLNotFound, err)
+	}
+	if url.Gone(time.Now()) {
+		return model.ShortURL{}, ErrShortURLGone
+	}
 	return *url, nil
 }
 
-func (s *shortURLService) DeleteShortURL(ctx context.Context, id string) error {
-	err := s.storage.DeleteShortURL(id)
+// DeleteShortURL удаляет ссылку на внешний адрес по её токену удаления, а ссылку на пасту —
+// по токену удаления пасты. Удалённый код остаётся «надгробием» и больше не выдаётся.
+func (s *shortURLService) DeleteShortURL(ctx context.Context, id, deleteToken string) error {
+	ctx, span := tracing.Start(ctx, "ShortURLService.DeleteShortURL")
+	defer span.End()
+
+	u, err := s.liveShortURL(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !s.canDelete(ctx, u, deleteToken) {
+		return ErrInvalidDeleteToken
+	}
+	return s.deleteShortURL(ctx, u)
+}
+
+// ForceDeleteShortURL удаляет ссылку без токена — для администратора.
+func (s *shortURLService) ForceDeleteShortURL(ctx context.Context, id string) error {
+	ctx, span := tracing.Start(ctx, "ShortURLService.ForceDeleteShortURL")
+	defer span.End()
+
+	u, err := s.liveShortURL(ctx, id)
+	if err != nil {
+		return err
+	}
+	return s.deleteShortURL(ctx, u)
+}
+
+func (s *shortURLService) liveShortURL(ctx context.Context, id string) (*model.ShortURL, error) {
+	u, err := s.storage.GetShortURLByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrShortURLNotFound, err)
+	}
+	if u.DeletedAt != nil {
+		return nil, ErrShortURLGone
+	}
+	return u, nil
+}
+
+func (s *shortURLService) canDelete(ctx context.Context, u *model.ShortURL, deleteToken string) bool {
+	if u.IsURL() {
+		return u.CheckDeleteToken(deleteToken)
+	}
+	paste, err := s.storage.GetPasteByHash(ctx, u.Original)
+	return err == nil && paste.CheckDeleteToken(deleteToken)
+}
+
+func (s *shortURLService) deleteShortURL(ctx context.Context, u *model.ShortURL) error {
+	err := s.storage.DeleteShortURL(ctx, u.ID)
 	if err == nil {
-		_ = s.logger.LogChange("shorturl", id, "deleted")
+		s.events.Publish(ctx, events.Event{Type: events.ShortURLDeleted, EntityID: u.ID, ShortURL: u, Before: auditShortURL(u)})
 	}
 	return err
 }
 
 func (s *shortURLService) ListShortURLs(ctx context.Context) ([]model.ShortURL, error) {
-	return s.storage.GetAllShortURLs()
+	ctx, span := tracing.Start(ctx, "ShortURLService.ListShortURLs")
+	defer span.End()
+
+	return s.storage.GetAllShortURLs(ctx)
+}
+
+// GetShortURLForTarget возвращает первую действующую ссылку на пасту или адрес.
+func (s *shortURLService) GetShortURLForTarget(ctx context.Context, original string) (model.ShortURL, error) {
+	ctx, span := tracing.Start(ctx, "ShortURLService.GetShortURLForTarget")
+	defer span.End()
+
+	urls, err := s.storage.GetShortURLsByOriginal(ctx, original)
+	if err != nil {
+		return model.ShortURL{}, err
+	}
+	now := time.Now()
+	for _, u := range urls {
+		if !u.Gone(now) {
+			return u, nil
+		}
+	}
+	return model.ShortURL{}, ErrShortURLNotFound
 }
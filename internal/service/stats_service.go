@@ -6,33 +6,40 @@ import (
 	"sort"
 	"time"
 
-	"github.com/GritsyukLeonid/pastebin-go/internal/logging"
+	"github.com/GritsyukLeonid/pastebin-go/internal/events"
 	"github.com/GritsyukLeonid/pastebin-go/internal/model"
 	"github.com/GritsyukLeonid/pastebin-go/internal/repository"
+	"github.com/GritsyukLeonid/pastebin-go/internal/tracing"
 )
 
 type statsService struct {
 	storage repository.StorageInterface
-	logger  logging.Logger
+	events  events.Publisher
 }
 
-func NewStatsService(storage repository.StorageInterface, logger logging.Logger) StatsService {
-	return &statsService{storage: storage, logger: logger}
+func NewStatsService(storage repository.StorageInterface, publisher events.Publisher) StatsService {
+	return &statsService{storage: storage, events: publisher}
 }
 
 func (s *statsService) CreateStats(ctx context.Context, stat model.Stats) (model.Stats, error) {
+	ctx, span := tracing.Start(ctx, "StatsService.CreateStats")
+	defer span.End()
+
 	stat.ID = fmt.Sprintf("%d", time.Now().UnixNano())
 
-	if err := s.storage.SaveStats(stat); err != nil {
+	if err := s.storage.SaveStats(ctx, stat); err != nil {
 		return model.Stats{}, err
 	}
 
-	_ = s.logger.LogChange("stats", stat.ID, "created")
+	s.events.Publish(ctx, events.Event{Type: events.StatsCreated, EntityID: stat.ID, Stats: &stat, After: auditStats(&stat)})
 	return stat, nil
 }
 
 func (s *statsService) GetStatsByID(ctx context.Context, id string) (model.Stats, error) {
-	stat, err := s.storage.GetStatsByID(id)
+	ctx, span := tracing.Start(ctx, "StatsService.GetStatsByID")
+	defer span.End()
+
+	stat, err := s.storage.GetStatsByID(ctx, id)
 	if err != nil {
 		return model.Stats{}, err
 	}
@@ -40,23 +47,40 @@ func (s *statsService) GetStatsByID(ctx context.Context, id string) (model.Stats
 }
 
 func (s *statsService) DeleteStats(ctx context.Context, id string) error {
-	err := s.storage.DeleteStats(id)
+	ctx, span := tracing.Start(ctx, "StatsService.DeleteStats")
+	defer span.End()
+
+	before, _ := s.storage.GetStatsByID(ctx, id)
+	err := s.storage.DeleteStats(ctx, id)
 	if err == nil {
-		_ = s.logger.LogChange("stats", id, "deleted")
+		s.events.Publish(ctx, events.Event{Type: events.StatsDeleted, EntityID: id, Stats: before, Before: auditStats(before)})
 	}
 	return err
 }
 
 func (s *statsService) ListStats(ctx context.Context) ([]model.Stats, error) {
-	return s.storage.GetAllStats()
+	ctx, span := tracing.Start(ctx, "StatsService.ListStats")
+	defer span.End()
+
+	return s.storage.GetAllStats(ctx)
 }
 
 func (s *statsService) IncrementViews(ctx context.Context, pasteID string) error {
-	return s.storage.IncrementStatsViews(pasteID)
+	ctx, span := tracing.Start(ctx, "StatsService.IncrementViews")
+	defer span.End()
+
+	if err := s.storage.IncrementStatsViews(ctx, pasteID); err != nil {
+		return err
+	}
+	s.events.Publish(ctx, events.Event{Type: events.PasteViewed, EntityID: pasteID})
+	return nil
 }
 
 func (s *statsService) ListTopStats(ctx context.Context, limit int) ([]model.Stats, error) {
-	allStats, err := s.storage.GetAllStats()
+	ctx, span := tracing.Start(ctx, "StatsService.ListTopStats")
+	defer span.End()
+
+	allStats, err := s.storage.GetAllStats(ctx)
 	if err != nil {
 		return nil, err
 	}
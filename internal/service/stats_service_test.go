@@ -4,8 +4,11 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
+	"github.com/GritsyukLeonid/pastebin-go/internal/events"
 	"github.com/GritsyukLeonid/pastebin-go/internal/model"
+	"github.com/GritsyukLeonid/pastebin-go/internal/repository"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -15,12 +18,12 @@ type mockStatsStorage struct {
 	stats map[string]model.Stats
 }
 
-func (m *mockStatsStorage) SaveStats(s model.Stats) error {
+func (m *mockStatsStorage) SaveStats(_ context.Context, s model.Stats) error {
 	m.stats[s.ID] = s
 	return nil
 }
 
-func (m *mockStatsStorage) GetStatsByID(id string) (*model.Stats, error) {
+func (m *mockStatsStorage) GetStatsByID(_ context.Context, id string) (*model.Stats, error) {
 	s, ok := m.stats[id]
 	if !ok {
 		return nil, errors.New("not found")
@@ -28,12 +31,12 @@ func (m *mockStatsStorage) GetStatsByID(id string) (*model.Stats, error) {
 	return &s, nil
 }
 
-func (m *mockStatsStorage) DeleteStats(id string) error {
+func (m *mockStatsStorage) DeleteStats(_ context.Context, id string) error {
 	delete(m.stats, id)
 	return nil
 }
 
-func (m *mockStatsStorage) GetAllStats() ([]model.Stats, error) {
+func (m *mockStatsStorage) GetAllStats(_ context.Context) ([]model.Stats, error) {
 	var out []model.Stats
 	for _, s := range m.stats {
 		out = append(out, s)
@@ -41,37 +44,63 @@ func (m *mockStatsStorage) GetAllStats() ([]model.Stats, error) {
 	return out, nil
 }
 
-func (m *mockStatsStorage) GetPasteByHash(hash string) (*model.Paste, error) {
+func (m *mockStatsStorage) GetPasteByHash(_ context.Context, hash string) (*model.Paste, error) {
 	return nil, nil
 }
 
-func (m *mockStatsStorage) IncrementStatsViews(id string) error {
+func (m *mockStatsStorage) UpdatePaste(context.Context, model.Paste, time.Time, repository.QuotaCheck) error {
 	return nil
 }
+func (m *mockStatsStorage) GetPasteUsage(context.Context, int64, string, time.Time) (*model.Usage, error) {
+	return &model.Usage{}, nil
+}
+func (m *mockStatsStorage) DeleteExpiredPastes(context.Context) ([]model.Paste, error) {
+	return nil, nil
+}
+func (m *mockStatsStorage) MarkExpiringPastes(context.Context, time.Time) ([]model.Paste, error) {
+	return nil, nil
+}
 
-func (m *mockStatsStorage) SavePaste(model.Paste) error                     { return nil }
-func (m *mockStatsStorage) GetPasteByID(string) (*model.Paste, error)       { return nil, nil }
-func (m *mockStatsStorage) DeletePaste(string) error                        { return nil }
-func (m *mockStatsStorage) GetAllPastes() ([]model.Paste, error)            { return nil, nil }
-func (m *mockStatsStorage) SaveUser(model.User) error                       { return nil }
-func (m *mockStatsStorage) GetUserByID(string) (*model.User, error)         { return nil, nil }
-func (m *mockStatsStorage) DeleteUser(string) error                         { return nil }
-func (m *mockStatsStorage) GetAllUsers() ([]model.User, error)              { return nil, nil }
-func (m *mockStatsStorage) SaveShortURL(model.ShortURL) error               { return nil }
-func (m *mockStatsStorage) GetShortURLByID(string) (*model.ShortURL, error) { return nil, nil }
-func (m *mockStatsStorage) DeleteShortURL(string) error                     { return nil }
-func (m *mockStatsStorage) GetAllShortURLs() ([]model.ShortURL, error)      { return nil, nil }
-
-type statsMockLogger struct{}
+func (m *mockStatsStorage) IncrementStatsViews(_ context.Context, id string) error {
+	return nil
+}
 
-func (l *statsMockLogger) LogChange(entity, id, action string) error {
+func (m *mockStatsStorage) SavePaste(context.Context, model.Paste, time.Time, repository.QuotaCheck) error {
 	return nil
 }
+func (m *mockStatsStorage) GetPasteByID(context.Context, string) (*model.Paste, error) {
+	return nil, nil
+}
+func (m *mockStatsStorage) DeletePaste(context.Context, string) error                { return nil }
+func (m *mockStatsStorage) GetAllPastes(_ context.Context) ([]model.Paste, error)    { return nil, nil }
+func (m *mockStatsStorage) SaveUser(context.Context, model.User) error               { return nil }
+func (m *mockStatsStorage) GetUserByID(context.Context, string) (*model.User, error) { return nil, nil }
+func (m *mockStatsStorage) DeleteUser(context.Context, string) error                 { return nil }
+func (m *mockStatsStorage) GetAllUsers(_ context.Context) ([]model.User, error)      { return nil, nil }
+func (m *mockStatsStorage) SaveShortURL(context.Context, model.ShortURL) error       { return nil }
+func (m *mockStatsStorage) GetShortURLByID(context.Context, string) (*model.ShortURL, error) {
+	return nil, nil
+}
+func (m *mockStatsStorage) DeleteShortURL(context.Context, string) error { return nil }
+func (m *mockStatsStorage) GetAllShortURLs(_ context.Context) ([]model.ShortURL, error) {
+	return nil, nil
+}
+func (m *mockStatsStorage) GetShortURLsByOriginal(context.Context, string) ([]model.ShortURL, error) {
+	return nil, nil
+}
+
+type statsMockPublisher struct {
+	published []events.Event
+}
+
+func (p *statsMockPublisher) Publish(_ context.Context, e events.Event) {
+	p.published = append(p.published, e)
+}
 
 func setupStatsService() StatsService {
 	storage := &mockStatsStorage{stats: make(map[string]model.Stats)}
-	logger := &statsMockLogger{}
-	return NewStatsService(storage, logger)
+	publisher := &statsMockPublisher{}
+	return NewStatsService(storage, publisher)
 }
 
 // Тесты
@@ -80,11 +109,11 @@ func TestCreateStats(t *testing.T) {
 	service := setupStatsService()
 	ctx := context.Background()
 
-	s := model.Stats{ID: "s1", Views: 42}
+	s := model.Stats{Views: 42}
 	created, err := service.CreateStats(ctx, s)
 
 	assert.NoError(t, err)
-	assert.Equal(t, s.ID, created.ID)
+	assert.NotEmpty(t, created.ID)
 	assert.Equal(t, s.Views, created.Views)
 }
 
@@ -92,8 +121,7 @@ func TestGetStats(t *testing.T) {
 	service := setupStatsService()
 	ctx := context.Background()
 
-	s := model.Stats{ID: "s2", Views: 77}
-	_, _ = service.CreateStats(ctx, s)
+	s, _ := service.CreateStats(ctx, model.Stats{Views: 77})
 
 	got, err := service.ListStats(ctx)
 	assert.NoError(t, err)
@@ -113,8 +141,7 @@ func TestDeleteStats(t *testing.T) {
 	service := setupStatsService()
 	ctx := context.Background()
 
-	s := model.Stats{ID: "s3", Views: 100}
-	_, _ = service.CreateStats(ctx, s)
+	s, _ := service.CreateStats(ctx, model.Stats{Views: 100})
 
 	err := service.DeleteStats(ctx, s.ID)
 	assert.NoError(t, err)
@@ -131,3 +158,15 @@ func TestDeleteStats(t *testing.T) {
 	}
 	assert.False(t, found)
 }
+
+func TestIncrementViewsPublishesPasteViewed(t *testing.T) {
+	storage := &mockStatsStorage{stats: map[string]model.Stats{"p1": {ID: "p1"}}}
+	publisher := &statsMockPublisher{}
+	svc := NewStatsService(storage, publisher)
+
+	assert.NoError(t, svc.IncrementViews(context.Background(), "p1"))
+
+	assert.Len(t, publisher.published, 1)
+	assert.Equal(t, events.PasteViewed, publisher.published[0].Type)
+	assert.Equal(t, "p1", publisher.published[0].EntityID)
+}
@@ -0,0 +1,44 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+const maxTargetURLLength = 2048
+
+var ErrInvalidURL = errors.New("invalid url")
+
+// allowedSchemes: всё остальное (javascript:, data:, file: и т.п.) отклоняется.
+var allowedSchemes = map[string]struct{}{
+	"http":  {},
+	"https": {},
+}
+
+// ValidateTargetURL проверяет внешний адрес для сокращения: абсолютный http(s)-URL
+// с хостом и без учётных данных (user:pass@host часто используется для фишинга).
+func ValidateTargetURL(raw string) error {
+	if raw == "" || len(raw) > maxTargetURLLength {
+		return fmt.Errorf("%w: length must be between 1 and %d", ErrInvalidURL, maxTargetURLLength)
+	}
+	if strings.ContainsAny(raw, " \t\r\n") {
+		return fmt.Errorf("%w: whitespace is not allowed", ErrInvalidURL)
+	}
+
+	u, err := url.Parse(raw)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidURL, err)
+	}
+	if _, ok := allowedSchemes[strings.ToLower(u.Scheme)]; !ok {
+		return fmt.Errorf("%w: scheme %q is not allowed", ErrInvalidURL, u.Scheme)
+	}
+	if u.Host == "" || u.Hostname() == "" {
+		return fmt.Errorf("%w: host is required", ErrInvalidURL)
+	}
+	if u.User != nil {
+		return fmt.Errorf("%w: credentials are not allowed", ErrInvalidURL)
+	}
+	return nil
+}
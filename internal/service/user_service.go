@@ -5,33 +5,42 @@ import (
 	"fmt"
 	"time"
 
-	"github.com/GritsyukLeonid/pastebin-go/internal/logging"
+	"github.com/GritsyukLeonid/pastebin-go/internal/events"
 	"github.com/GritsyukLeonid/pastebin-go/internal/model"
 	"github.com/GritsyukLeonid/pastebin-go/internal/repository"
+	"github.com/GritsyukLeonid/pastebin-go/internal/tracing"
 )
 
 type userService struct {
 	storage repository.StorageInterface
-	logger  logging.Logger
+	events  events.Publisher
+	quotas  QuotaConfig
 }
 
-func NewUserService(storage repository.StorageInterface, logger logging.Logger) UserService {
-	return &userService{storage: storage, logger: logger}
+func NewUserService(storage repository.StorageInterface, publisher events.Publisher, quotas QuotaConfig) UserService {
+	return &userService{storage: storage, events: publisher, quotas: quotas}
 }
 
 func (s *userService) CreateUser(ctx context.Context, u model.User) (model.User, error) {
+	ctx, span := tracing.Start(ctx, "UserService.CreateUser")
+	defer span.End()
+
 	u.ID = time.Now().UnixNano()
 
-	if err := s.storage.SaveUser(u); err != nil {
+	if err := s.storage.SaveUser(ctx, u); err != nil {
 		return model.User{}, err
 	}
 
-	_ = s.logger.LogChange("user", fmt.Sprintf("%d", u.ID), "created")
+	id := fmt.Sprintf("%d", u.ID)
+	s.events.Publish(ctx, events.Event{Type: events.UserCreated, EntityID: id, User: &u, After: auditUser(&u)})
 	return u, nil
 }
 
 func (s *userService) GetUserByID(ctx context.Context, id string) (model.User, error) {
-	user, err := s.storage.GetUserByID(id)
+	ctx, span := tracing.Start(ctx, "UserService.GetUserByID")
+	defer span.End()
+
+	user, err := s.storage.GetUserByID(ctx, id)
 	if err != nil {
 		return model.User{}, err
 	}
@@ -39,13 +48,37 @@ func (s *userService) GetUserByID(ctx context.Context, id string) (model.User, e
 }
 
 func (s *userService) DeleteUser(ctx context.Context, id string) error {
-	err := s.storage.DeleteUser(id)
+	ctx, span := tracing.Start(ctx, "UserService.DeleteUser")
+	defer span.End()
+
+	before, _ := s.storage.GetUserByID(ctx, id)
+	err := s.storage.DeleteUser(ctx, id)
 	if err == nil {
-		_ = s.logger.LogChange("user", id, "deleted")
+		s.events.Publish(ctx, events.Event{Type: events.UserDeleted, EntityID: id, User: before, Before: auditUser(before)})
 	}
 	return err
 }
 
 func (s *userService) ListUsers(ctx context.Context) ([]model.User, error) {
-	return s.storage.GetAllUsers()
+	ctx, span := tracing.Start(ctx, "UserService.ListUsers")
+	defer span.End()
+
+	return s.storage.GetAllUsers(ctx)
+}
+
+func (s *userService) GetUsage(ctx context.Context, id string) (model.Usage, error) {
+	ctx, span := tracing.Start(ctx, "UserService.GetUsage")
+	defer span.End()
+
+	user, err := s.storage.GetUserByID(ctx, id)
+	if err != nil {
+		return model.Usage{}, err
+	}
+
+	usage, err := s.storage.GetPasteUsage(ctx, user.ID, "", dailyWindowStart(time.Now()))
+	if err != nil {
+		return model.Usage{}, err
+	}
+	usage.Limits = s.quotas.User
+	return *usage, nil
 }
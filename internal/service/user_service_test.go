@@ -5,23 +5,27 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
+	"github.com/GritsyukLeonid/pastebin-go/internal/events"
 	"github.com/GritsyukLeonid/pastebin-go/internal/model"
+	"github.com/GritsyukLeonid/pastebin-go/internal/repository"
 	"github.com/stretchr/testify/assert"
 )
 
 // Моки
 
 type mockUserStorage struct {
-	users map[int64]model.User
+	users  map[int64]model.User
+	pastes []model.Paste
 }
 
-func (m *mockUserStorage) SaveUser(u model.User) error {
+func (m *mockUserStorage) SaveUser(_ context.Context, u model.User) error {
 	m.users[u.ID] = u
 	return nil
 }
 
-func (m *mockUserStorage) GetUserByID(id string) (*model.User, error) {
+func (m *mockUserStorage) GetUserByID(_ context.Context, id string) (*model.User, error) {
 	for _, u := range m.users {
 		if fmt.Sprintf("%d", u.ID) == id {
 			return &u, nil
@@ -30,7 +34,7 @@ func (m *mockUserStorage) GetUserByID(id string) (*model.User, error) {
 	return nil, errors.New("not found")
 }
 
-func (m *mockUserStorage) DeleteUser(id string) error {
+func (m *mockUserStorage) DeleteUser(_ context.Context, id string) error {
 	for uid, u := range m.users {
 		if fmt.Sprintf("%d", u.ID) == id {
 			delete(m.users, uid)
@@ -40,7 +44,7 @@ func (m *mockUserStorage) DeleteUser(id string) error {
 	return errors.New("not found")
 }
 
-func (m *mockUserStorage) GetAllUsers() ([]model.User, error) {
+func (m *mockUserStorage) GetAllUsers(_ context.Context) ([]model.User, error) {
 	var out []model.User
 	for _, u := range m.users {
 		out = append(out, u)
@@ -48,37 +52,75 @@ func (m *mockUserStorage) GetAllUsers() ([]model.User, error) {
 	return out, nil
 }
 
-func (m *mockUserStorage) GetPasteByHash(hash string) (*model.Paste, error) {
+func (m *mockUserStorage) GetPasteByHash(_ context.Context, hash string) (*model.Paste, error) {
 	return nil, nil
 }
 
-func (m *mockUserStorage) IncrementStatsViews(id string) error {
+func (m *mockUserStorage) UpdatePaste(context.Context, model.Paste, time.Time, repository.QuotaCheck) error {
 	return nil
 }
+func (m *mockUserStorage) GetPasteUsage(_ context.Context, userID int64, _ string, since time.Time) (*model.Usage, error) {
+	var u model.Usage
+	for _, p := range m.pastes {
+		if p.UserID != userID {
+			continue
+		}
+		u.LivePastes++
+		if !p.CreatedAt.Before(since) {
+			u.PastesToday++
+		}
+	}
+	return &u, nil
+}
+func (m *mockUserStorage) DeleteExpiredPastes(context.Context) ([]model.Paste, error) {
+	return nil, nil
+}
+func (m *mockUserStorage) MarkExpiringPastes(context.Context, time.Time) ([]model.Paste, error) {
+	return nil, nil
+}
 
-func (m *mockUserStorage) SavePaste(model.Paste) error                     { return nil }
-func (m *mockUserStorage) GetPasteByID(string) (*model.Paste, error)       { return nil, nil }
-func (m *mockUserStorage) DeletePaste(string) error                        { return nil }
-func (m *mockUserStorage) GetAllPastes() ([]model.Paste, error)            { return nil, nil }
-func (m *mockUserStorage) SaveShortURL(model.ShortURL) error               { return nil }
-func (m *mockUserStorage) GetShortURLByID(string) (*model.ShortURL, error) { return nil, nil }
-func (m *mockUserStorage) DeleteShortURL(string) error                     { return nil }
-func (m *mockUserStorage) GetAllShortURLs() ([]model.ShortURL, error)      { return nil, nil }
-func (m *mockUserStorage) SaveStats(model.Stats) error                     { return nil }
-func (m *mockUserStorage) GetStatsByID(string) (*model.Stats, error)       { return nil, nil }
-func (m *mockUserStorage) DeleteStats(string) error                        { return nil }
-func (m *mockUserStorage) GetAllStats() ([]model.Stats, error)             { return nil, nil }
-
-type userMockLogger struct{}
+func (m *mockUserStorage) IncrementStatsViews(_ context.Context, id string) error {
+	return nil
+}
 
-func (l *userMockLogger) LogChange(entity, id, action string) error {
+func (m *mockUserStorage) SavePaste(context.Context, model.Paste, time.Time, repository.QuotaCheck) error {
 	return nil
 }
+func (m *mockUserStorage) GetPasteByID(context.Context, string) (*model.Paste, error) {
+	return nil, nil
+}
+func (m *mockUserStorage) DeletePaste(context.Context, string) error             { return nil }
+func (m *mockUserStorage) GetAllPastes(_ context.Context) ([]model.Paste, error) { return nil, nil }
+func (m *mockUserStorage) SaveShortURL(context.Context, model.ShortURL) error    { return nil }
+func (m *mockUserStorage) GetShortURLByID(context.Context, string) (*model.ShortURL, error) {
+	return nil, nil
+}
+func (m *mockUserStorage) DeleteShortURL(context.Context, string) error { return nil }
+func (m *mockUserStorage) GetAllShortURLs(_ context.Context) ([]model.ShortURL, error) {
+	return nil, nil
+}
+func (m *mockUserStorage) GetShortURLsByOriginal(context.Context, string) ([]model.ShortURL, error) {
+	return nil, nil
+}
+func (m *mockUserStorage) SaveStats(context.Context, model.Stats) error { return nil }
+func (m *mockUserStorage) GetStatsByID(context.Context, string) (*model.Stats, error) {
+	return nil, nil
+}
+func (m *mockUserStorage) DeleteStats(context.Context, string) error            { return nil }
+func (m *mockUserStorage) GetAllStats(_ context.Context) ([]model.Stats, error) { return nil, nil }
+
+type userMockPublisher struct {
+	published []events.Event
+}
+
+func (p *userMockPublisher) Publish(_ context.Context, e events.Event) {
+	p.published = append(p.published, e)
+}
 
 func setupUserService() UserService {
 	storage := &mockUserStorage{users: make(map[int64]model.User)}
-	logger := &userMockLogger{}
-	return NewUserService(storage, logger)
+	publisher := &userMockPublisher{}
+	return NewUserService(storage, publisher, DefaultQuotaConfig())
 }
 
 // Тесты
@@ -122,3 +164,36 @@ func TestDeleteUser(t *testing.T) {
 	_, err = service.GetUserByID(ctx, fmt.Sprintf("%d", created.ID))
 	assert.Error(t, err)
 }
+
+func TestGetUserUsage(t *testing.T) {
+	service := setupUserService()
+	ctx := context.Background()
+
+	created, _ := service.CreateUser(ctx, model.User{Username: "dave"})
+
+	usage, err := service.GetUsage(ctx, fmt.Sprintf("%d", created.ID))
+	assert.NoError(t, err)
+	assert.Equal(t, DefaultQuotaConfig().User, usage.Limits)
+
+	_, err = service.GetUsage(ctx, "404")
+	assert.Error(t, err)
+}
+
+func TestGetUserUsageCountsLast24Hours(t *testing.T) {
+	now := time.Now()
+	storage := &mockUserStorage{
+		users: map[int64]model.User{7: {ID: 7, Username: "erin"}},
+		pastes: []model.Paste{
+			{UserID: 7, CreatedAt: now.Add(-time.Hour)},
+			{UserID: 7, CreatedAt: now.Add(-24*time.Hour + time.Minute)},
+			{UserID: 7, CreatedAt: now.Add(-24*time.Hour - time.Minute)},
+		},
+	}
+	service := NewUserService(storage, &userMockPublisher{}, DefaultQuotaConfig())
+
+	usage, err := service.GetUsage(context.Background(), "7")
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, usage.LivePastes)
+	assert.Equal(t, 2, usage.PastesToday, "окно — последние 24 часа, а не календарные сутки")
+}
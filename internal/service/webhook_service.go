@@ -0,0 +1,442 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/GritsyukLeonid/pastebin-go/internal/config"
+	"github.com/GritsyukLeonid/pastebin-go/internal/events"
+	"github.com/GritsyukLeonid/pastebin-go/internal/links"
+	"github.com/GritsyukLeonid/pastebin-go/internal/metrics"
+	"github.com/GritsyukLeonid/pastebin-go/internal/model"
+	"github.com/GritsyukLeonid/pastebin-go/internal/repository"
+	"github.com/GritsyukLeonid/pastebin-go/internal/tracing"
+)
+
+var (
+	ErrInvalidWebhook       = errors.New("invalid webhook")
+	ErrWebhookNotFound      = errors.New("webhook not found")
+	ErrInvalidWebhookSecret = errors.New("invalid webhook secret")
+)
+
+// Заголовки запроса к получателю вебхука.
+const (
+	WebhookEventHeader     = "X-Pastebin-Event"
+	WebhookDeliveryHeader  = "X-Pastebin-Delivery"
+	WebhookTimestampHeader = "X-Pastebin-Timestamp"
+	WebhookSignatureHeader = "X-Pastebin-Signature"
+)
+
+const (
+	DefaultDeliveryLimit = 100
+	MaxDeliveryLimit     = 1000
+
+	minWebhookSecretLength = 16
+	// webhookLeaseMargin добавляется к таймауту запроса: пока идёт попытка, доставку не возьмёт другой экземпляр.
+	webhookLeaseMargin = 30 * time.Second
+)
+
+// WebhookConfig — политика доставки: после неудачной попытки n следующая откладывается
+// на Backoff·2^(n-1), но не больше MaxBackoff; после MaxAttempts попыток доставка становится dead.
+type WebhookConfig struct {
+	MaxAttempts int
+	Backoff     time.Duration
+	MaxBackoff  time.Duration
+	// Timeout ограничивает один запрос к получателю.
+	Timeout time.Duration
+	// BatchSize — сколько доставок отправляется за один вызов DeliverDue.
+	BatchSize int
+	// AllowPrivateTargets разрешает получателей на этой машине и в частных сетях — только для
+	// локальной разработки: иначе вебхуком можно обратиться к внутренним сервисам.
+	AllowPrivateTargets bool
+}
+
+func DefaultWebhookConfig() WebhookConfig {
+	return WebhookConfig{
+		MaxAttempts: 10,
+		Backoff:     10 * time.Second,
+		MaxBackoff:  time.Hour,
+		Timeout:     10 * time.Second,
+		BatchSize:   20,
+	}
+}
+
+// WebhookConfigFrom переводит настройки из config; BatchSize не настраивается.
+func WebhookConfigFrom(c config.WebhooksConfig) WebhookConfig {
+	return WebhookConfig{
+		MaxAttempts:         c.MaxAttempts,
+		Backoff:             time.Duration(c.Backoff),
+		MaxBackoff:          time.Duration(c.MaxBackoff),
+		Timeout:             time.Duration(c.Timeout),
+		BatchSize:           DefaultWebhookConfig().BatchSize,
+		AllowPrivateTargets: c.AllowPrivateTargets,
+	}
+}
+
+// WebhookEvents — события, на которые можно подписать вебхук: изменения и предупреждения об истечении срока.
+func WebhookEvents() []events.Type {
+	return append(events.Changes(), events.PasteExpiring)
+}
+
+type webhookService struct {
+	storage  repository.StorageInterface
+	webhooks repository.WebhookStorage
+	links    *links.Builder
+	cfg      WebhookConfig
+	client   *http.Client
+	now      func() time.Time
+}
+
+// NewWebhookService создаёт сервис вебхуков; владельцев вебхуков ищет в storage, ссылки на пасты
+// в событиях строит lb.
+func NewWebhookService(storage repository.StorageInterface, webhooks repository.WebhookStorage, lb *links.Builder, cfg WebhookConfig) WebhookService {
+	client := &http.Client{
+		Timeout:   cfg.Timeout,
+		Transport: newWebhookTransport(cfg.AllowPrivateTargets),
+		// Перенаправление считается неудачной попыткой: получатель должен ответить 2xx сам.
+		CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse },
+	}
+	return &webhookService{storage: storage, webhooks: webhooks, links: lb, cfg: cfg, client: client, now: time.Now}
+}
+
+func (s *webhookService) CreateWebhook(ctx context.Context, w model.Webhook) (model.Webhook, error) {
+	ctx, span := tracing.Start(ctx, "WebhookService.CreateWebhook")
+	defer span.End()
+
+	if err := ValidateTargetURL(w.URL); err != nil {
+		return model.Webhook{}, fmt.Errorf("%w: %v", ErrInvalidWebhook, err)
+	}
+	if !s.cfg.AllowPrivateTargets {
+		if err := checkWebhookHost(w.URL); err != nil {
+			return model.Webhook{}, fmt.Errorf("%w: %v", ErrInvalidWebhook, err)
+		}
+	}
+	if len(w.Events) == 0 {
+		return model.Webhook{}, fmt.Errorf("%w: at least one event is required", ErrInvalidWebhook)
+	}
+	for _, e := range w.Events {
+		if !isWebhookEvent(e) {
+			return model.Webhook{}, fmt.Errorf("%w: unknown event %q", ErrInvalidWebhook, e)
+		}
+	}
+	if w.UserID < 0 {
+		return model.Webhook{}, fmt.Errorf("%w: userId must not be negative", ErrInvalidWebhook)
+	}
+	if w.UserID != 0 {
+		if err := checkOwner(ctx, s.storage, w.UserID); err != nil {
+			return model.Webhook{}, err
+		}
+	}
+	if w.Secret == "" {
+		secret, err := randomHex(32)
+		if err != nil {
+			return model.Webhook{}, err
+		}
+		w.Secret = secret
+	} else if len(w.Secret) < minWebhookSecretLength {
+		return model.Webhook{}, fmt.Errorf("%w: secret must be at least %d characters", ErrInvalidWebhook, minWebhookSecretLength)
+	}
+	w.CreatedAt = s.now().UTC()
+
+	id, err := s.webhooks.SaveWebhook(ctx, w)
+	if err != nil {
+		return model.Webhook{}, err
+	}
+	w.ID = id
+	return w, nil
+}
+
+func (s *webhookService) GetWebhook(ctx context.Context, id int64, secret string) (model.Webhook, error) {
+	ctx, span := tracing.Start(ctx, "WebhookService.GetWebhook")
+	defer span.End()
+
+	hook, err := s.authorize(ctx, id, secret)
+	if err != nil {
+		return model.Webhook{}, err
+	}
+	hook.Secret = ""
+	return *hook, nil
+}
+
+func (s *webhookService) DeleteWebhook(ctx context.Context, id int64, secret string) error {
+	ctx, span := tracing.Start(ctx, "WebhookService.DeleteWebhook")
+	defer span.End()
+
+	if _, err := s.authorize(ctx, id, secret); err != nil {
+		return err
+	}
+	return s.webhooks.DeleteWebhook(ctx, id)
+}
+
+func (s *webhookService) ListDeliveries(ctx context.Context, id int64, secret, status string, limit int) ([]model.WebhookDelivery, error) {
+	ctx, span := tracing.Start(ctx, "WebhookService.ListDeliveries")
+	defer span.End()
+
+	switch status {
+	case "", model.DeliveryPending, model.DeliverySucceeded, model.DeliveryDead:
+	default:
+		return nil, fmt.Errorf("%w: unknown status %q", ErrInvalidWebhook, status)
+	}
+	if limit == 0 {
+		limit = DefaultDeliveryLimit
+	}
+	if limit < 0 || limit > MaxDeliveryLimit {
+		return nil, fmt.Errorf("%w: limit must be between 1 and %d", ErrInvalidWebhook, MaxDeliveryLimit)
+	}
+	if _, err := s.authorize(ctx, id, secret); err != nil {
+		return nil, err
+	}
+
+	deliveries, err := s.webhooks.ListWebhookDeliveries(ctx, id, status, limit)
+	if err != nil {
+		return nil, err
+	}
+	if deliveries == nil {
+		deliveries = []model.WebhookDelivery{}
+	}
+	return deliveries, nil
+}
+
+// authorize находит вебхук и сверяет секрет за постоянное время.
+func (s *webhookService) authorize(ctx context.Context, id int64, secret string) (*model.Webhook, error) {
+	hook, err := s.webhooks.GetWebhook(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrWebhookNotFound, err)
+	}
+	if secret == "" || subtle.ConstantTimeCompare([]byte(secret), []byte(hook.Secret)) != 1 {
+		return nil, ErrInvalidWebhookSecret
+	}
+	return hook, nil
+}
+
+// webhookPayload — тело запроса к получателю. Data — краткое описание сущности без содержимого паст и токенов,
+// URL — публичный адрес живой пасты.
+type webhookPayload struct {
+	ID         string      `json:"id"`
+	Type       events.Type `json:"type"`
+	OccurredAt time.Time   `json:"occurredAt"`
+	EntityID   string      `json:"entityId"`
+	URL        string      `json:"url,omitempty"`
+	Data       any         `json:"data,omitempty"`
+}
+
+func (s *webhookService) HandleEvent(ctx context.Context, e events.Event) error {
+	ctx, span := tracing.Start(ctx, "WebhookService.HandleEvent")
+	defer span.End()
+
+	hooks, err := s.webhooks.ListWebhooksForEvent(ctx, string(e.Type), eventOwner(e))
+	if err != nil || len(hooks) == 0 {
+		return err
+	}
+
+	eventID, err := randomHex(16)
+	if err != nil {
+		return err
+	}
+	payload, err := json.Marshal(webhookPayload{
+		ID:         eventID,
+		Type:       e.Type,
+		OccurredAt: e.At,
+		EntityID:   e.EntityID,
+		URL:        s.pasteURL(e),
+		Data:       eventSummary(e),
+	})
+	if err != nil {
+		return err
+	}
+
+	now := s.now().UTC()
+	deliveries := make([]model.WebhookDelivery, 0, len(hooks))
+	for _, h := range hooks {
+		deliveries = append(deliveries, model.WebhookDelivery{
+			WebhookID:     h.ID,
+			EventID:       eventID,
+			Event:         string(e.Type),
+			Payload:       payload,
+			Status:        model.DeliveryPending,
+			NextAttemptAt: &now,
+			CreatedAt:     now,
+		})
+	}
+	return s.webhooks.EnqueueWebhookDeliveries(ctx, deliveries)
+}
+
+func (s *webhookService) DeliverDue(ctx context.Context) (int, error) {
+	ctx, span := tracing.Start(ctx, "WebhookService.DeliverDue")
+	defer span.End()
+
+	due, err := s.webhooks.ClaimWebhookDeliveries(ctx, s.now().UTC(), s.cfg.Timeout+webhookLeaseMargin, s.cfg.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []error
+	)
+	hooks := make(map[int64]*model.Webhook)
+	for _, d := range due {
+		hook, ok := hooks[d.WebhookID]
+		if !ok {
+			// Если вебхук удалён, его доставки удаляются вместе с ним.
+			if hook, err = s.webhooks.GetWebhook(ctx, d.WebhookID); err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			hooks[d.WebhookID] = hook
+		}
+		wg.Add(1)
+		go func(hook *model.Webhook, d model.WebhookDelivery) {
+			defer wg.Done()
+			if err := s.attempt(ctx, hook, d); err != nil {
+				mu.Lock()
+				errs = append(errs, err)
+				mu.Unlock()
+			}
+		}(hook, d)
+	}
+	wg.Wait()
+	return len(due), errors.Join(errs...)
+}
+
+// attempt отправляет доставку и сохраняет результат: успех, повтор через backoff или dead.
+func (s *webhookService) attempt(ctx context.Context, hook *model.Webhook, d model.WebhookDelivery) error {
+	start := s.now().UTC()
+	code, err := s.send(ctx, hook, d, start)
+	a := model.WebhookAttempt{
+		Attempt:    d.AttemptCount + 1,
+		At:         start,
+		StatusCode: code,
+		DurationMS: s.now().Sub(start).Milliseconds(),
+	}
+	d.AttemptCount++
+
+	switch {
+	case err == nil:
+		d.Status = model.DeliverySucceeded
+		d.NextAttemptAt = nil
+		metrics.WebhookAttempt(metrics.WebhookSucceeded)
+	case d.AttemptCount >= s.cfg.MaxAttempts:
+		a.Error = err.Error()
+		d.Status = model.DeliveryDead
+		d.NextAttemptAt = nil
+		metrics.WebhookAttempt(metrics.WebhookDead)
+		slog.WarnContext(ctx, "webhook delivery failed, retries exhausted",
+			"webhook_id", hook.ID, "delivery_id", d.ID, "event", d.Event, "attempts", d.AttemptCount, "error", err)
+	default:
+		a.Error = err.Error()
+		next := start.Add(s.backoff(d.AttemptCount))
+		d.NextAttemptAt = &next
+		metrics.WebhookAttempt(metrics.WebhookFailed)
+	}
+	return s.webhooks.RecordWebhookAttempt(ctx, d, a)
+}
+
+func (s *webhookService) send(ctx context.Context, hook *model.Webhook, d model.WebhookDelivery, at time.Time) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.cfg.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, hook.URL, bytes.NewReader(d.Payload))
+	if err != nil {
+		return 0, err
+	}
+	timestamp := at.Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "pastebin-webhooks")
+	req.Header.Set(WebhookEventHeader, d.Event)
+	req.Header.Set(WebhookDeliveryHeader, strconv.FormatInt(d.ID, 10))
+	req.Header.Set(WebhookTimestampHeader, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(WebhookSignatureHeader, SignWebhook(hook.Secret, timestamp, d.Payload))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("receiver responded with %s", resp.Status)
+	}
+	return resp.StatusCode, nil
+}
+
+// backoff возвращает задержку после attempts неудачных попыток.
+func (s *webhookService) backoff(attempts int) time.Duration {
+	delay := s.cfg.Backoff
+	for i := 1; i < attempts && delay < s.cfg.MaxBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, s.cfg.MaxBackoff)
+}
+
+// pasteURL возвращает адрес пасты для событий, после которых она ещё доступна.
+func (s *webhookService) pasteURL(e events.Event) string {
+	if e.Paste == nil || e.Paste.Hash == "" || e.Type == events.PasteDeleted || e.Type == events.PasteExpired {
+		return ""
+	}
+	return s.links.URL(nil, "/api/v1/paste/hash/"+url.PathEscape(e.Paste.Hash))
+}
+
+// SignWebhook возвращает значение заголовка X-Pastebin-Signature: "sha256=" и HMAC-SHA256
+// строки "<timestamp>.<body>" с ключом secret в hex. Получатель сверяет его со своим расчётом.
+func SignWebhook(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func isWebhookEvent(name string) bool {
+	for _, t := range WebhookEvents() {
+		if string(t) == name {
+			return true
+		}
+	}
+	return false
+}
+
+// eventOwner возвращает пользователя, к которому относится событие, или 0.
+func eventOwner(e events.Event) int64 {
+	switch {
+	case e.Paste != nil:
+		return e.Paste.UserID
+	case e.User != nil:
+		return e.User.ID
+	}
+	return 0
+}
+
+func eventSummary(e events.Event) any {
+	if e.Paste != nil {
+		return auditPaste(e.Paste)
+	}
+	if e.After != nil {
+		return e.After
+	}
+	return e.Before
+}
+
+func randomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,355 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/GritsyukLeonid/pastebin-go/internal/events"
+	"github.com/GritsyukLeonid/pastebin-go/internal/links"
+	"github.com/GritsyukLeonid/pastebin-go/internal/model"
+	"github.com/GritsyukLeonid/pastebin-go/internal/reqctx"
+)
+
+type mockWebhookStorage struct {
+	mu         sync.Mutex
+	hooks      map[int64]model.Webhook
+	deliveries []model.WebhookDelivery
+	ownerArg   int64
+}
+
+func newMockWebhookStorage() *mockWebhookStorage {
+	return &mockWebhookStorage{hooks: map[int64]model.Webhook{}}
+}
+
+func (m *mockWebhookStorage) SaveWebhook(_ context.Context, w model.Webhook) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	w.ID = int64(len(m.hooks) + 1)
+	m.hooks[w.ID] = w
+	return w.ID, nil
+}
+
+func (m *mockWebhookStorage) GetWebhook(_ context.Context, id int64) (*model.Webhook, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	w, ok := m.hooks[id]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return &w, nil
+}
+
+func (m *mockWebhookStorage) DeleteWebhook(_ context.Context, id int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.hooks, id)
+	return nil
+}
+
+func (m *mockWebhookStorage) ListWebhooksForEvent(_ context.Context, event string, userID int64) ([]model.Webhook, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.ownerArg = userID
+	var out []model.Webhook
+	for id := int64(1); id <= int64(len(m.hooks)); id++ {
+		w, ok := m.hooks[id]
+		if !ok || w.UserID != userID {
+			continue
+		}
+		for _, e := range w.Events {
+			if e == event {
+				out = append(out, w)
+			}
+		}
+	}
+	return out, nil
+}
+
+func (m *mockWebhookStorage) EnqueueWebhookDeliveries(_ context.Context, ds []model.WebhookDelivery) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, d := range ds {
+		d.ID = int64(len(m.deliveries) + 1)
+		m.deliveries = append(m.deliveries, d)
+	}
+	return nil
+}
+
+func (m *mockWebhookStorage) ClaimWebhookDeliveries(_ context.Context, now time.Time, lease time.Duration, limit int) ([]model.WebhookDelivery, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var out []model.WebhookDelivery
+	for i := range m.deliveries {
+		d := &m.deliveries[i]
+		if d.Status != model.DeliveryPending || d.NextAttemptAt.After(now) || len(out) == limit {
+			continue
+		}
+		leased := now.Add(lease)
+		d.NextAttemptAt = &leased
+		out = append(out, *d)
+	}
+	return out, nil
+}
+
+func (m *mockWebhookStorage) RecordWebhookAttempt(_ context.Context, d model.WebhookDelivery, a model.WebhookAttempt) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	stored := &m.deliveries[d.ID-1]
+	stored.Status, stored.AttemptCount, stored.NextAttemptAt = d.Status, d.AttemptCount, d.NextAttemptAt
+	stored.Attempts = append(stored.Attempts, a)
+	return nil
+}
+
+func (m *mockWebhookStorage) ListWebhookDeliveries(_ context.Context, webhookID int64, status string, limit int) ([]model.WebhookDelivery, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var out []model.WebhookDelivery
+	for _, d := range m.deliveries {
+		if d.WebhookID == webhookID && (status == "" || d.Status == status) && len(out) < limit {
+			out = append(out, d)
+		}
+	}
+	return out, nil
+}
+
+// webhookReceiver — локальный получатель: отвечает кодами из statuses по очереди и запоминает запросы.
+type webhookReceiver struct {
+	mu       sync.Mutex
+	statuses []int
+	requests []*http.Request
+	bodies   [][]byte
+}
+
+func (rcv *webhookReceiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	rcv.mu.Lock()
+	defer rcv.mu.Unlock()
+	rcv.requests = append(rcv.requests, r)
+	rcv.bodies = append(rcv.bodies, body)
+	status := http.StatusNoContent
+	if len(rcv.statuses) > 0 {
+		status, rcv.statuses = rcv.statuses[0], rcv.statuses[1:]
+	}
+	w.WriteHeader(status)
+}
+
+func newTestWebhookService(t *testing.T, storage *mockWebhookStorage, cfg WebhookConfig) (*webhookService, *time.Time) {
+	t.Helper()
+	lb, err := links.NewBuilder(links.DefaultConfig())
+	require.NoError(t, err)
+	svc := NewWebhookService(&mockStorage{}, storage, lb, cfg).(*webhookService)
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	svc.now = func() time.Time { return now }
+	return svc, &now
+}
+
+func TestCreateWebhookValidates(t *testing.T) {
+	svc, _ := newTestWebhookService(t, newMockWebhookStorage(), DefaultWebhookConfig())
+	ctx := context.Background()
+
+	for name, w := range map[string]model.Webhook{
+		"bad url":       {URL: "ftp://example.com", Events: []string{"paste.created"}},
+		"no events":     {URL: "https://example.com/hook"},
+		"unknown event": {URL: "https://example.com/hook", Events: []string{"paste.viewed"}},
+		"short secret":  {URL: "https://example.com/hook", Events: []string{"paste.created"}, Secret: "abc"},
+		"loopback":      {URL: "http://127.0.0.1:8080/hook", Events: []string{"paste.created"}},
+		"private":       {URL: "http://10.0.0.5/hook", Events: []string{"paste.created"}},
+		"metadata":      {URL: "http://169.254.169.254/latest/meta-data", Events: []string{"paste.created"}},
+		"localhost":     {URL: "http://localhost/hook", Events: []string{"paste.created"}},
+		"mapped ipv6":   {URL: "http://[::ffff:127.0.0.1]/hook", Events: []string{"paste.created"}},
+	} {
+		_, err := svc.CreateWebhook(ctx, w)
+		assert.ErrorIs(t, err, ErrInvalidWebhook, name)
+	}
+
+	created, err := svc.CreateWebhook(ctx, model.Webhook{URL: "https://example.com/hook", Events: []string{"paste.expiring"}})
+	require.NoError(t, err)
+	assert.Len(t, created.Secret, 64)
+
+	_, err = svc.GetWebhook(ctx, created.ID, "wrong-secret-value")
+	assert.ErrorIs(t, err, ErrInvalidWebhookSecret)
+	got, err := svc.GetWebhook(ctx, created.ID, created.Secret)
+	require.NoError(t, err)
+	assert.Empty(t, got.Secret)
+	_, err = svc.GetWebhook(ctx, 42, created.Secret)
+	assert.ErrorIs(t, err, ErrWebhookNotFound)
+
+	owned := model.Webhook{URL: "https://example.com/hook", Events: []string{"paste.created"}, UserID: 7}
+	_, err = svc.CreateWebhook(ctx, owned)
+	assert.ErrorIs(t, err, ErrOwnerUnauthenticated, "userId без аутентификации позволил бы читать события чужих паст")
+	_, err = svc.CreateWebhook(reqctx.WithUser(ctx, "service:billing"), owned)
+	assert.NoError(t, err)
+}
+
+func TestWebhookDoesNotDialPrivateAddresses(t *testing.T) {
+	receiver := &webhookReceiver{}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+
+	storage := newMockWebhookStorage()
+	svc, _ := newTestWebhookService(t, storage, DefaultWebhookConfig())
+	ctx := context.Background()
+
+	hook, err := svc.CreateWebhook(ctx, model.Webhook{URL: "https://example.com/hook", Events: []string{"paste.created"}})
+	require.NoError(t, err)
+	// Как после перепривязки DNS: публичное при создании имя теперь ведёт на 127.0.0.1.
+	stored := storage.hooks[hook.ID]
+	stored.URL = server.URL
+	storage.hooks[hook.ID] = stored
+
+	paste := model.Paste{ID: "p1", Hash: "abc"}
+	require.NoError(t, svc.HandleEvent(ctx, events.Event{Type: events.PasteCreated, EntityID: "p1", Paste: &paste}))
+	_, err = svc.DeliverDue(ctx)
+	require.NoError(t, err)
+
+	assert.Empty(t, receiver.requests)
+	require.Len(t, storage.deliveries[0].Attempts, 1)
+	assert.Contains(t, storage.deliveries[0].Attempts[0].Error, ErrForbiddenWebhookTarget.Error())
+}
+
+func TestForbiddenTarget(t *testing.T) {
+	for addr, forbidden := range map[string]bool{
+		"127.0.0.1":       true,
+		"10.1.2.3":        true,
+		"172.16.0.1":      true,
+		"192.168.1.1":     true,
+		"169.254.169.254": true,
+		"100.64.0.1":      true,
+		"0.0.0.0":         true,
+		"::1":             true,
+		"fe80::1":         true,
+		"fd00::1":         true,
+		"::ffff:10.0.0.1": true,
+		"64:ff9b::a00:1":  true,
+		"93.184.216.34":   false,
+		"2606:4700::1111": false,
+	} {
+		assert.Equal(t, forbidden, forbiddenTarget(netip.MustParseAddr(addr)), addr)
+	}
+}
+
+func TestWebhookDeliversSignedPayload(t *testing.T) {
+	receiver := &webhookReceiver{}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+
+	storage := newMockWebhookStorage()
+	cfg := DefaultWebhookConfig()
+	cfg.AllowPrivateTargets = true
+	svc, now := newTestWebhookService(t, storage, cfg)
+	ctx := reqctx.WithUser(context.Background(), "service:billing")
+
+	team, err := svc.CreateWebhook(ctx, model.Webhook{URL: server.URL, Events: []string{"paste.created"}, UserID: 7})
+	require.NoError(t, err)
+	_, err = svc.CreateWebhook(ctx, model.Webhook{URL: server.URL, Events: []string{"paste.created"}, UserID: 8})
+	require.NoError(t, err)
+
+	paste := model.Paste{ID: "p1", Hash: "abc", Content: "secret text", UserID: 7, DeleteToken: "token"}
+	require.NoError(t, svc.HandleEvent(ctx, events.Event{Type: events.PasteCreated, EntityID: "p1", At: *now, Paste: &paste}))
+	assert.Equal(t, int64(7), storage.ownerArg)
+
+	n, err := svc.DeliverDue(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+
+	require.Len(t, receiver.requests, 1)
+	req, body := receiver.requests[0], receiver.bodies[0]
+	timestamp, err := strconv.ParseInt(req.Header.Get(WebhookTimestampHeader), 10, 64)
+	require.NoError(t, err)
+	assert.Equal(t, SignWebhook(team.Secret, timestamp, body), req.Header.Get(WebhookSignatureHeader))
+	assert.Equal(t, "paste.created", req.Header.Get(WebhookEventHeader))
+	assert.NotContains(t, string(body), "secret text")
+	assert.NotContains(t, string(body), "token")
+
+	var payload map[string]any
+	require.NoError(t, json.Unmarshal(body, &payload))
+	assert.Equal(t, "paste.created", payload["type"])
+	assert.Equal(t, "p1", payload["entityId"])
+	assert.Equal(t, "http://localhost:8080/api/v1/paste/hash/abc", payload["url"])
+
+	deliveries, err := svc.ListDeliveries(ctx, team.ID, team.Secret, "", 0)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	assert.Equal(t, model.DeliverySucceeded, deliveries[0].Status)
+	require.Len(t, deliveries[0].Attempts, 1)
+	assert.Equal(t, http.StatusNoContent, deliveries[0].Attempts[0].StatusCode)
+}
+
+func TestOwnerlessWebhookGetsOnlyOwnerlessEvents(t *testing.T) {
+	storage := newMockWebhookStorage()
+	svc, now := newTestWebhookService(t, storage, DefaultWebhookConfig())
+	ctx := reqctx.WithUser(context.Background(), "service:billing")
+
+	ownerless, err := svc.CreateWebhook(ctx, model.Webhook{URL: "https://hooks.example.com/all", Events: []string{"paste.created"}})
+	require.NoError(t, err)
+	owned, err := svc.CreateWebhook(ctx, model.Webhook{URL: "https://hooks.example.com/team", Events: []string{"paste.created"}, UserID: 7})
+	require.NoError(t, err)
+
+	userPaste := model.Paste{ID: "p1", UserID: 7}
+	require.NoError(t, svc.HandleEvent(ctx, events.Event{Type: events.PasteCreated, EntityID: "p1", At: *now, Paste: &userPaste}))
+	anonymous := model.Paste{ID: "p2"}
+	require.NoError(t, svc.HandleEvent(ctx, events.Event{Type: events.PasteCreated, EntityID: "p2", At: *now, Paste: &anonymous}))
+
+	require.Len(t, storage.deliveries, 2)
+	assert.Equal(t, owned.ID, storage.deliveries[0].WebhookID)
+	assert.Equal(t, ownerless.ID, storage.deliveries[1].WebhookID)
+}
+
+func TestWebhookRetriesWithBackoffThenDeadLetters(t *testing.T) {
+	receiver := &webhookReceiver{statuses: []int{500, 500, 500}}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+
+	storage := newMockWebhookStorage()
+	cfg := WebhookConfig{MaxAttempts: 3, Backoff: time.Minute, MaxBackoff: 90 * time.Second, Timeout: time.Second, BatchSize: 10, AllowPrivateTargets: true}
+	svc, now := newTestWebhookService(t, storage, cfg)
+	ctx := context.Background()
+
+	hook, err := svc.CreateWebhook(ctx, model.Webhook{URL: server.URL, Events: []string{"paste.expiring"}})
+	require.NoError(t, err)
+	paste := model.Paste{ID: "p1", Hash: "abc"}
+	require.NoError(t, svc.HandleEvent(ctx, events.Event{Type: events.PasteExpiring, EntityID: "p1", Paste: &paste}))
+
+	start := *now
+	_, err = svc.DeliverDue(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, start.Add(time.Minute), *storage.deliveries[0].NextAttemptAt)
+
+	n, err := svc.DeliverDue(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, n, "retry is not due yet")
+
+	*now = start.Add(time.Minute)
+	_, err = svc.DeliverDue(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, now.Add(90*time.Second), *storage.deliveries[0].NextAttemptAt, "backoff is capped")
+
+	*now = now.Add(90 * time.Second)
+	_, err = svc.DeliverDue(ctx)
+	require.NoError(t, err)
+
+	dead, err := svc.ListDeliveries(ctx, hook.ID, hook.Secret, model.DeliveryDead, 0)
+	require.NoError(t, err)
+	require.Len(t, dead, 1)
+	assert.Equal(t, 3, dead[0].AttemptCount)
+	assert.Nil(t, dead[0].NextAttemptAt)
+	require.Len(t, dead[0].Attempts, 3)
+	assert.Equal(t, http.StatusInternalServerError, dead[0].Attempts[2].StatusCode)
+	assert.Contains(t, dead[0].Attempts[2].Error, "500")
+	assert.Len(t, receiver.requests, 3)
+
+	_, err = svc.ListDeliveries(ctx, hook.ID, hook.Secret, "lost", 0)
+	assert.ErrorIs(t, err, ErrInvalidWebhook)
+}
@@ -0,0 +1,88 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+)
+
+var ErrForbiddenWebhookTarget = errors.New("webhook target is in a local or private network")
+
+// forbiddenPrefixes дополняют проверки netip.Addr сетями, которые не маршрутизируются в интернет
+// или ведут обратно во внутреннюю сеть (NAT64).
+var forbiddenPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+	netip.MustParsePrefix("240.0.0.0/4"),
+	netip.MustParsePrefix("64:ff9b::/96"),
+	netip.MustParsePrefix("64:ff9b:1::/48"),
+}
+
+// forbiddenTarget сообщает, что адрес ведёт на эту машину, в локальную или частную сеть.
+func forbiddenTarget(ip netip.Addr) bool {
+	ip = ip.Unmap()
+	if !ip.IsGlobalUnicast() || ip.IsPrivate() {
+		return true
+	}
+	for _, p := range forbiddenPrefixes {
+		if p.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkWebhookHost отклоняет при создании вебхука адреса, которые заведомо запрещены: IP-литералы
+// внутренних сетей и localhost. Имена, которые резолвятся во внутренние адреса, отсекает dialer.
+func checkWebhookHost(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return err
+	}
+	host := strings.TrimSuffix(strings.ToLower(u.Hostname()), ".")
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return fmt.Errorf("%w: %s", ErrForbiddenWebhookTarget, host)
+	}
+	if ip, err := netip.ParseAddr(host); err == nil && forbiddenTarget(ip) {
+		return fmt.Errorf("%w: %s", ErrForbiddenWebhookTarget, ip)
+	}
+	return nil
+}
+
+// dialControl проверяет уже разрешённый адрес перед соединением, поэтому DNS-имя, которое
+// указывает (или после перепривязки начинает указывать) во внутреннюю сеть, не проходит.
+func dialControl(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip, err := netip.ParseAddr(host)
+	if err != nil {
+		return err
+	}
+	if forbiddenTarget(ip) {
+		return fmt.Errorf("%w: %s", ErrForbiddenWebhookTarget, ip)
+	}
+	return nil
+}
+
+// newWebhookTransport подключается только к публичным адресам, если не задан allowPrivate.
+// Прокси из окружения не используется: иначе проверялся бы адрес прокси, а не получателя.
+func newWebhookTransport(allowPrivate bool) *http.Transport {
+	dialer := &net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second}
+	if !allowPrivate {
+		dialer.Control = dialControl
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return transport
+}
@@ -0,0 +1,119 @@
+// Package shortcode генерирует короткие коды в base62.
+//
+// Generator пробует коды из Source и повторяет попытку при конфликте. Если на текущей
+// длине подряд случается несколько конфликтов, пространство кодов считается заполненным
+// и длина увеличивается.
+package shortcode
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+)
+
+const Alphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+// ErrConflict сообщает генератору, что код занят и нужно попробовать другой.
+var ErrConflict = errors.New("short code conflict")
+
+var ErrExhausted = errors.New("short code space exhausted")
+
+type Source interface {
+	Next(length int) (string, error)
+}
+
+// RandomSource выдаёт криптографически случайные коды.
+type RandomSource struct{}
+
+func (RandomSource) Next(length int) (string, error) {
+	buf := make([]byte, length)
+	max := big.NewInt(int64(len(Alphabet)))
+	for i := range buf {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", err
+		}
+		buf[i] = Alphabet[n.Int64()]
+	}
+	return string(buf), nil
+}
+
+type Config struct {
+	MinLength int
+	MaxLength int
+	// AttemptsPerLength — сколько конфликтов подряд допускается, прежде чем длина вырастет.
+	AttemptsPerLength int
+}
+
+func DefaultConfig() Config {
+	return Config{MinLength: 6, MaxLength: 12, AttemptsPerLength: 3}
+}
+
+type Generator struct {
+	source Source
+	cfg    Config
+
+	mu     sync.Mutex
+	length int
+}
+
+func NewGenerator(source Source, cfg Config) *Generator {
+	if cfg.MinLength <= 0 {
+		cfg.MinLength = DefaultConfig().MinLength
+	}
+	if cfg.MaxLength < cfg.MinLength {
+		cfg.MaxLength = cfg.MinLength
+	}
+	if cfg.AttemptsPerLength <= 0 {
+		cfg.AttemptsPerLength = 1
+	}
+	return &Generator{source: source, cfg: cfg, length: cfg.MinLength}
+}
+
+// Length возвращает текущую длину генерируемых кодов.
+func (g *Generator) Length() int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.length
+}
+
+// Generate подбирает свободный код: claim должен атомарно занять код и вернуть
+// ErrConflict (или обёрнутую им ошибку), если код уже существует.
+func (g *Generator) Generate(ctx context.Context, claim func(code string) error) (string, error) {
+	length := g.Length()
+	for {
+		for attempt := 0; attempt < g.cfg.AttemptsPerLength; attempt++ {
+			if err := ctx.Err(); err != nil {
+				return "", err
+			}
+			code, err := g.source.Next(length)
+			if err != nil {
+				return "", err
+			}
+			err = claim(code)
+			if err == nil {
+				return code, nil
+			}
+			if !errors.Is(err, ErrConflict) {
+				return "", err
+			}
+		}
+
+		if length >= g.cfg.MaxLength {
+			return "", fmt.Errorf("%w: length %d", ErrExhausted, length)
+		}
+		length = g.grow(length)
+	}
+}
+
+func (g *Generator) grow(from int) int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.length <= from {
+		g.length = from + 1
+	}
+	return g.length
+}
@@ -0,0 +1,78 @@
+package shortcode
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRandomSource(t *testing.T) {
+	code, err := RandomSource{}.Next(8)
+	assert.NoError(t, err)
+	assert.Len(t, code, 8)
+	for _, r := range code {
+		assert.True(t, strings.ContainsRune(Alphabet, r))
+	}
+}
+
+// listSource выдаёт коды по порядку.
+type listSource []string
+
+func (s *listSource) Next(int) (string, error) {
+	code := (*s)[0]
+	*s = (*s)[1:]
+	return code, nil
+}
+
+func TestGenerateRetriesOnConflict(t *testing.T) {
+	taken := map[string]bool{"000001": true, "000002": true}
+	gen := NewGenerator(&listSource{"000001", "000002", "000003"}, Config{MinLength: 6, MaxLength: 8, AttemptsPerLength: 5})
+
+	code, err := gen.Generate(context.Background(), func(code string) error {
+		if taken[code] {
+			return ErrConflict
+		}
+		taken[code] = true
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "000003", code)
+	assert.Equal(t, 6, gen.Length())
+}
+
+func TestGenerateGrowsLength(t *testing.T) {
+	gen := NewGenerator(RandomSource{}, Config{MinLength: 4, MaxLength: 6, AttemptsPerLength: 2})
+
+	var tried []int
+	code, err := gen.Generate(context.Background(), func(code string) error {
+		tried = append(tried, len(code))
+		if len(code) < 6 {
+			return ErrConflict
+		}
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Len(t, code, 6)
+	assert.Equal(t, []int{4, 4, 5, 5, 6}, tried)
+	assert.Equal(t, 6, gen.Length(), "длина сохраняется для следующих кодов")
+}
+
+func TestGenerateExhausted(t *testing.T) {
+	gen := NewGenerator(RandomSource{}, Config{MinLength: 4, MaxLength: 4, AttemptsPerLength: 3})
+
+	_, err := gen.Generate(context.Background(), func(string) error { return ErrConflict })
+	assert.ErrorIs(t, err, ErrExhausted)
+}
+
+func TestGenerateStopsOnOtherErrors(t *testing.T) {
+	gen := NewGenerator(RandomSource{}, DefaultConfig())
+	boom := errors.New("db down")
+
+	calls := 0
+	_, err := gen.Generate(context.Background(), func(string) error { calls++; return boom })
+	assert.ErrorIs(t, err, boom)
+	assert.Equal(t, 1, calls)
+}
@@ -0,0 +1,225 @@
+// Package tlsconfig собирает tls.Config для HTTP- и gRPC-листенеров и для клиентов.
+// Сертификаты сервера перечитываются с диска, когда файлы меняются, поэтому ротация
+// (например, certbot или cert-manager) не требует перезапуска.
+package tlsconfig
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+)
+
+// checkInterval — как часто при новых соединениях проверяется время изменения файлов.
+var checkInterval = 5 * time.Second
+
+var ErrInvalid = errors.New("invalid tls config")
+
+// ServerConfig — пустой CertFile отключает TLS. С ClientCAFile сервер требует клиентский
+// сертификат, подписанный одним из этих центров (mTLS).
+type ServerConfig struct {
+	CertFile     string
+	KeyFile      string
+	ClientCAFile string
+	// MinVersion — "1.2" или "1.3"; пусто — 1.2.
+	MinVersion string
+}
+
+type ClientConfig struct {
+	// CAFile — центры сертификации сервера; пусто — системные.
+	CAFile string
+	// CertFile и KeyFile — клиентский сертификат для mTLS.
+	CertFile   string
+	KeyFile    string
+	ServerName string
+	MinVersion string
+}
+
+func (c ServerConfig) Enabled() bool {
+	return c.CertFile != ""
+}
+
+// Validate проверяет настройки без чтения файлов.
+func (c ServerConfig) Validate() error {
+	if (c.CertFile == "") != (c.KeyFile == "") {
+		return fmt.Errorf("%w: cert_file and key_file must be set together", ErrInvalid)
+	}
+	if c.ClientCAFile != "" && c.CertFile == "" {
+		return fmt.Errorf("%w: client_ca_file requires cert_file", ErrInvalid)
+	}
+	_, err := ParseVersion(c.MinVersion)
+	return err
+}
+
+// ParseVersion переводит "1.2" или "1.3" в константу crypto/tls; пустая строка — 1.2.
+func ParseVersion(v string) (uint16, error) {
+	switch v {
+	case "", "1.2":
+		return tls.VersionTLS12, nil
+	case "1.3":
+		return tls.VersionTLS13, nil
+	default:
+		return 0, fmt.Errorf("%w: min_version must be 1.2 or 1.3, got %q", ErrInvalid, v)
+	}
+}
+
+// NewServer читает сертификаты и возвращает конфигурацию, которая подхватывает их изменения.
+// Для отключённого TLS возвращает nil.
+func NewServer(c ServerConfig) (*tls.Config, error) {
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	if !c.Enabled() {
+		return nil, nil
+	}
+	version, _ := ParseVersion(c.MinVersion)
+	r := &reloader{cfg: c}
+	if err := r.load(); err != nil {
+		return nil, err
+	}
+
+	// NextProtos задаются здесь: серверы HTTP и gRPC дописывают их во внешнюю конфигурацию,
+	// а соединение использует ту, что вернул GetConfigForClient.
+	base := &tls.Config{MinVersion: version, NextProtos: []string{"h2", "http/1.1"}}
+	if c.ClientCAFile != "" {
+		base.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return &tls.Config{
+		MinVersion: version,
+		GetCertificate: func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+			cert, _ := r.current()
+			return cert, nil
+		},
+		// Каждое соединение получает копию base с актуальными сертификатом и центрами клиентов.
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			cert, clientCAs := r.current()
+			cfg := base.Clone()
+			cfg.Certificates = []tls.Certificate{*cert}
+			cfg.ClientCAs = clientCAs
+			return cfg, nil
+		},
+	}, nil
+}
+
+// NewClient собирает конфигурацию клиента: свои центры сертификации и клиентский сертификат.
+func NewClient(c ClientConfig) (*tls.Config, error) {
+	version, err := ParseVersion(c.MinVersion)
+	if err != nil {
+		return nil, err
+	}
+	cfg := &tls.Config{MinVersion: version, ServerName: c.ServerName}
+	if c.CAFile != "" {
+		if cfg.RootCAs, err = loadPool(c.CAFile); err != nil {
+			return nil, err
+		}
+	}
+	if (c.CertFile == "") != (c.KeyFile == "") {
+		return nil, fmt.Errorf("%w: client cert and key must be set together", ErrInvalid)
+	}
+	if c.CertFile != "" {
+		cert, err := tls.LoadX509KeyPair(c.CertFile, c.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("load client certificate: %w", err)
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+	return cfg, nil
+}
+
+// reloader хранит сертификат сервера и центры клиентов и перечитывает их при изменении файлов.
+// Если новые файлы не читаются (например, записаны наполовину), остаются прежние.
+type reloader struct {
+	cfg ServerConfig
+
+	mu        sync.Mutex
+	cert      *tls.Certificate
+	clientCAs *x509.CertPool
+	modTimes  []time.Time
+	checked   time.Time
+}
+
+func (r *reloader) current() (*tls.Certificate, *x509.CertPool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if time.Since(r.checked) >= checkInterval {
+		r.checked = time.Now()
+		if r.changed() {
+			if err := r.loadLocked(); err != nil {
+				slog.Warn("tls certificate reload failed, keeping previous", "cert_file", r.cfg.CertFile, "error", err)
+			} else {
+				slog.Info("tls certificate reloaded", "cert_file", r.cfg.CertFile)
+			}
+		}
+	}
+	return r.cert, r.clientCAs
+}
+
+func (r *reloader) load() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.checked = time.Now()
+	return r.loadLocked()
+}
+
+func (r *reloader) loadLocked() error {
+	modTimes, err := r.stat()
+	if err != nil {
+		return err
+	}
+	cert, err := tls.LoadX509KeyPair(r.cfg.CertFile, r.cfg.KeyFile)
+	if err != nil {
+		return fmt.Errorf("load certificate: %w", err)
+	}
+	var clientCAs *x509.CertPool
+	if r.cfg.ClientCAFile != "" {
+		if clientCAs, err = loadPool(r.cfg.ClientCAFile); err != nil {
+			return err
+		}
+	}
+	r.cert, r.clientCAs, r.modTimes = &cert, clientCAs, modTimes
+	return nil
+}
+
+func (r *reloader) changed() bool {
+	modTimes, err := r.stat()
+	if err != nil {
+		return false
+	}
+	for i := range modTimes {
+		if !modTimes[i].Equal(r.modTimes[i]) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *reloader) stat() ([]time.Time, error) {
+	var modTimes []time.Time
+	for _, path := range []string{r.cfg.CertFile, r.cfg.KeyFile, r.cfg.ClientCAFile} {
+		if path == "" {
+			continue
+		}
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		modTimes = append(modTimes, info.ModTime())
+	}
+	return modTimes, nil
+}
+
+func loadPool(path string) (*x509.CertPool, error) {
+	pem, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("%w: no certificates in %s", ErrInvalid, path)
+	}
+	return pool, nil
+}
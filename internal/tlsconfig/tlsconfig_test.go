@@ -0,0 +1,166 @@
+package tlsconfig
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pem  []byte
+}
+
+func newCA(t *testing.T) testCA {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tpl, tpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return testCA{cert: cert, key: key, pem: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})}
+}
+
+// issue выписывает сертификат на cn и пишет его и ключ в dir/name.crt и dir/name.key.
+func (ca testCA) issue(t *testing.T, dir, name, cn string, serial int64) (certFile, keyFile string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tpl := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: cn},
+		DNSNames:     []string{"localhost"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tpl, ca.cert, &key.PublicKey, ca.key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certFile, keyFile = filepath.Join(dir, name+".crt"), filepath.Join(dir, name+".key")
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600))
+	return certFile, keyFile
+}
+
+func serverCert(t *testing.T, cfg *tls.Config) *x509.Certificate {
+	t.Helper()
+	conn, err := cfg.GetConfigForClient(&tls.ClientHelloInfo{})
+	require.NoError(t, err)
+	require.Len(t, conn.Certificates, 1)
+	leaf, err := x509.ParseCertificate(conn.Certificates[0].Certificate[0])
+	require.NoError(t, err)
+	return leaf
+}
+
+func TestServerReloadsChangedCertificate(t *testing.T) {
+	interval := checkInterval
+	checkInterval = 0
+	t.Cleanup(func() { checkInterval = interval })
+
+	dir := t.TempDir()
+	ca := newCA(t)
+	certFile, keyFile := ca.issue(t, dir, "server", "localhost", 2)
+	cfg, err := NewServer(ServerConfig{CertFile: certFile, KeyFile: keyFile, MinVersion: "1.3"})
+	require.NoError(t, err)
+	assert.Equal(t, uint16(tls.VersionTLS13), cfg.MinVersion)
+	assert.Equal(t, int64(2), serverCert(t, cfg).SerialNumber.Int64())
+
+	ca.issue(t, dir, "server", "localhost", 3)
+	future := time.Now().Add(time.Minute)
+	require.NoError(t, os.Chtimes(certFile, future, future))
+	assert.Equal(t, int64(3), serverCert(t, cfg).SerialNumber.Int64(), "новый сертификат подхватывается без перезапуска")
+
+	require.NoError(t, os.WriteFile(certFile, []byte("broken"), 0o600))
+	future = future.Add(time.Minute)
+	require.NoError(t, os.Chtimes(certFile, future, future))
+	assert.Equal(t, int64(3), serverCert(t, cfg).SerialNumber.Int64(), "при ошибке чтения остаётся прежний сертификат")
+}
+
+func TestMutualTLSHandshake(t *testing.T) {
+	dir := t.TempDir()
+	ca := newCA(t)
+	caFile := filepath.Join(dir, "ca.pem")
+	require.NoError(t, os.WriteFile(caFile, ca.pem, 0o600))
+	serverCertFile, serverKeyFile := ca.issue(t, dir, "server", "localhost", 2)
+	clientCertFile, clientKeyFile := ca.issue(t, dir, "client", "billing", 3)
+
+	serverCfg, err := NewServer(ServerConfig{CertFile: serverCertFile, KeyFile: serverKeyFile, ClientCAFile: caFile})
+	require.NoError(t, err)
+	lis, err := tls.Listen("tcp", "127.0.0.1:0", serverCfg)
+	require.NoError(t, err)
+	defer lis.Close()
+	peers := make(chan []*x509.Certificate, 2)
+	go func() {
+		for {
+			conn, err := lis.Accept()
+			if err != nil {
+				return
+			}
+			tlsConn := conn.(*tls.Conn)
+			if tlsConn.Handshake() == nil {
+				peers <- tlsConn.ConnectionState().PeerCertificates
+			}
+			conn.Close()
+		}
+	}()
+
+	clientCfg, err := NewClient(ClientConfig{CAFile: caFile, CertFile: clientCertFile, KeyFile: clientKeyFile, ServerName: "localhost"})
+	require.NoError(t, err)
+	conn, err := tls.Dial("tcp", lis.Addr().String(), clientCfg)
+	require.NoError(t, err)
+	require.NoError(t, conn.Handshake())
+	conn.Close()
+	got := <-peers
+	require.NotEmpty(t, got)
+	assert.Equal(t, "billing", got[0].Subject.CommonName)
+
+	noCert, err := NewClient(ClientConfig{CAFile: caFile, ServerName: "localhost"})
+	require.NoError(t, err)
+	conn, err = tls.Dial("tcp", lis.Addr().String(), noCert)
+	if err == nil {
+		// В TLS 1.3 клиент узнаёт об отказе только при первом чтении.
+		_, err = conn.Read(make([]byte, 1))
+		conn.Close()
+	}
+	assert.Error(t, err, "без клиентского сертификата соединение отклоняется")
+}
+
+func TestServerConfigValidate(t *testing.T) {
+	assert.NoError(t, ServerConfig{}.Validate())
+	assert.ErrorIs(t, ServerConfig{CertFile: "a.crt"}.Validate(), ErrInvalid)
+	assert.ErrorIs(t, ServerConfig{ClientCAFile: "ca.pem"}.Validate(), ErrInvalid)
+	assert.ErrorIs(t, ServerConfig{CertFile: "a.crt", KeyFile: "a.key", MinVersion: "1.1"}.Validate(), ErrInvalid)
+
+	cfg, err := NewServer(ServerConfig{})
+	assert.NoError(t, err)
+	assert.Nil(t, cfg, "без сертификата TLS отключён")
+}
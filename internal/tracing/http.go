@@ -0,0 +1,42 @@
+package tracing
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	semconv "go.opentelemetry.io/otel/semconv/v1.34.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// HTTPHandler открывает серверный спан на каждый запрос, продолжая трассировку
+// из заголовков traceparent/tracestate. Пробы и /metrics не трассируются.
+func HTTPHandler(next http.Handler) http.Handler {
+	return otelhttp.NewHandler(next, "HTTP",
+		otelhttp.WithSpanNameFormatter(func(_ string, r *http.Request) string {
+			return r.Method + " " + r.URL.Path
+		}),
+		otelhttp.WithFilter(func(r *http.Request) bool {
+			switch r.URL.Path {
+			case "/healthz", "/readyz", "/metrics":
+				return false
+			}
+			return true
+		}),
+	)
+}
+
+// RouteNames — middleware mux: переименовывает спан запроса по шаблону маршрута
+// (GET /api/v1/paste/{id}), чтобы имена спанов не зависели от идентификаторов в пути.
+func RouteNames(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if route := mux.CurrentRoute(r); route != nil {
+			if tpl, err := route.GetPathTemplate(); err == nil {
+				span := trace.SpanFromContext(r.Context())
+				span.SetName(r.Method + " " + tpl)
+				span.SetAttributes(semconv.HTTPRoute(tpl))
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,27 @@
+package tracing
+
+import (
+	"context"
+	"strings"
+
+	semconv "go.opentelemetry.io/otel/semconv/v1.34.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// StartQuery открывает спан SQL-запроса; имя — первое слово запроса (SELECT, INSERT, ...).
+func StartQuery(ctx context.Context, query string) (context.Context, trace.Span) {
+	op := queryOperation(query)
+	return Start(ctx, "postgresql "+op,
+		semconv.DBSystemNamePostgreSQL,
+		semconv.DBOperationName(op),
+		semconv.DBQueryText(strings.TrimSpace(query)),
+	)
+}
+
+func queryOperation(query string) string {
+	fields := strings.Fields(query)
+	if len(fields) == 0 {
+		return "QUERY"
+	}
+	return strings.ToUpper(fields[0])
+}
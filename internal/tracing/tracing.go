@@ -0,0 +1,146 @@
+// Package tracing настраивает OpenTelemetry: провайдер трассировки, экспортёр
+// (OTLP, stdout или файл) и распространение контекста W3C Trace Context между сервисами.
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.34.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	ExporterNone   = "none"
+	ExporterOTLP   = "otlp"
+	ExporterStdout = "stdout"
+	ExporterFile   = "file"
+
+	instrumentation = "github.com/GritsyukLeonid/pastebin-go"
+)
+
+var ErrInvalidConfig = errors.New("invalid tracing config")
+
+// Config — куда и как отправлять спаны. Endpoint — адрес OTLP/gRPC коллектора (host:port),
+// File — путь для экспортёра file, SampleRatio — доля трассировок от 0 до 1.
+type Config struct {
+	Exporter    string
+	ServiceName string
+	Endpoint    string
+	Insecure    bool
+	File        string
+	SampleRatio float64
+}
+
+func DefaultConfig() Config {
+	return Config{
+		Exporter:    ExporterNone,
+		ServiceName: "pastebin",
+		Endpoint:    "localhost:4317",
+		Insecure:    true,
+		SampleRatio: 1,
+	}
+}
+
+func (c Config) Validate() error {
+	switch c.Exporter {
+	case ExporterNone, ExporterOTLP, ExporterStdout:
+	case ExporterFile:
+		if c.File == "" {
+			return fmt.Errorf("%w: file exporter needs a path", ErrInvalidConfig)
+		}
+	default:
+		return fmt.Errorf("%w: unknown exporter %q", ErrInvalidConfig, c.Exporter)
+	}
+	if c.SampleRatio < 0 || c.SampleRatio > 1 {
+		return fmt.Errorf("%w: sample ratio must be between 0 and 1", ErrInvalidConfig)
+	}
+	return nil
+}
+
+// Setup регистрирует глобальный провайдер и пропагатор. Без экспортёра (none) спаны
+// не записываются, но входящий контекст трассировки всё равно передаётся дальше.
+// Возвращённая функция сбрасывает буферы и закрывает экспортёр.
+func Setup(ctx context.Context, cfg Config) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	if cfg.Exporter == ExporterNone {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	exporter, closer, err := newExporter(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceName(cfg.ServiceName)))
+	if err != nil {
+		return nil, err
+	}
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+	)
+	otel.SetTracerProvider(provider)
+
+	return func(ctx context.Context) error {
+		err := provider.Shutdown(ctx)
+		if closer != nil {
+			err = errors.Join(err, closer.Close())
+		}
+		return err
+	}, nil
+}
+
+func newExporter(ctx context.Context, cfg Config) (sdktrace.SpanExporter, io.Closer, error) {
+	switch cfg.Exporter {
+	case ExporterOTLP:
+		opts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(cfg.Endpoint)}
+		if cfg.Insecure {
+			opts = append(opts, otlptracegrpc.WithInsecure())
+		}
+		exp, err := otlptracegrpc.New(ctx, opts...)
+		return exp, nil, err
+	case ExporterStdout:
+		exp, err := stdouttrace.New(stdouttrace.WithPrettyPrint())
+		return exp, nil, err
+	default:
+		f, err := os.OpenFile(cfg.File, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+		if err != nil {
+			return nil, nil, err
+		}
+		exp, err := stdouttrace.New(stdouttrace.WithWriter(f))
+		if err != nil {
+			f.Close()
+			return nil, nil, err
+		}
+		return exp, f, nil
+	}
+}
+
+// Start открывает дочерний спан текущего контекста.
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(instrumentation).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// Fail отмечает спан ошибкой; nil ничего не меняет.
+func Fail(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+}
@@ -0,0 +1,105 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func recordSpans(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	t.Cleanup(func() { otel.SetTracerProvider(prev) })
+	return recorder
+}
+
+func TestHTTPSpansContinueIncomingTrace(t *testing.T) {
+	_, err := Setup(context.Background(), DefaultConfig())
+	require.NoError(t, err)
+	recorder := recordSpans(t)
+
+	router := mux.NewRouter()
+	router.Use(RouteNames)
+	router.HandleFunc("/s/{code}", func(w http.ResponseWriter, r *http.Request) {
+		_, span := Start(r.Context(), "ShortURLService.GetShortURLByID")
+		span.End()
+	})
+	handler := HTTPHandler(router)
+
+	req := httptest.NewRequest(http.MethodGet, "/s/abc123", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2, "проба /readyz не трассируется")
+	child, server := spans[0], spans[1]
+	assert.Equal(t, "GET /s/{code}", server.Name())
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", server.SpanContext().TraceID().String())
+	assert.Equal(t, "00f067aa0ba902b7", server.Parent().SpanID().String())
+	assert.Equal(t, server.SpanContext().SpanID(), child.Parent().SpanID())
+}
+
+func TestStartQueryAndFail(t *testing.T) {
+	recorder := recordSpans(t)
+
+	_, span := StartQuery(context.Background(), "\n\t\tselect id FROM pastes WHERE id = $1")
+	Fail(span, errors.New("connection reset"))
+	span.End()
+	_, ok := StartQuery(context.Background(), "INSERT INTO stats (id) VALUES ($1)")
+	Fail(ok, nil)
+	ok.End()
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	assert.Equal(t, "postgresql SELECT", spans[0].Name())
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	assert.Equal(t, "postgresql INSERT", spans[1].Name())
+	assert.Equal(t, codes.Unset, spans[1].Status().Code)
+}
+
+func TestSetupFileExporter(t *testing.T) {
+	prev := otel.GetTracerProvider()
+	t.Cleanup(func() { otel.SetTracerProvider(prev) })
+
+	path := filepath.Join(t.TempDir(), "traces.json")
+	cfg := DefaultConfig()
+	cfg.Exporter = ExporterFile
+	cfg.File = path
+	shutdown, err := Setup(context.Background(), cfg)
+	require.NoError(t, err)
+
+	_, span := Start(context.Background(), "PasteService.CreatePaste")
+	span.End()
+	require.NoError(t, shutdown(context.Background()))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "PasteService.CreatePaste")
+}
+
+func TestConfigValidate(t *testing.T) {
+	assert.NoError(t, DefaultConfig().Validate())
+	for _, cfg := range []Config{
+		{Exporter: "jaeger", SampleRatio: 1},
+		{Exporter: ExporterFile, SampleRatio: 1},
+		{Exporter: ExporterOTLP, SampleRatio: 2},
+	} {
+		assert.ErrorIs(t, cfg.Validate(), ErrInvalidConfig)
+	}
+}